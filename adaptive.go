@@ -0,0 +1,480 @@
+package moea
+
+import (
+	"math"
+)
+
+// ParameterControl sets the crossover and mutation probabilities the simple
+// algorithm uses in each generation. Controls that learn from the offspring
+// may also implement OnOffspring(config, parent1, parent2, child, objective),
+// which is called after every evaluation with the objectives of both
+// parents.
+type ParameterControl interface {
+	Rates(config *Config, generation int) (crossoverProbability, mutationProbability float64)
+}
+
+// ScheduleControl is deterministic parameter control: each probability is a
+// function of the generation. A nil schedule keeps the configured value.
+type ScheduleControl struct {
+	Crossover func(generation, maxGenerations int) float64
+	Mutation  func(generation, maxGenerations int) float64
+}
+
+// OneFifthRule is Rechenberg's 1/5th success rule applied to the mutation
+// probability. Every Period generations (1 by default), the probability is
+// divided by Factor (0.85 by default) when more than a fifth of the offspring
+// improved on their best parent and multiplied by it otherwise. It stays
+// within [Min, Max], by default [1/length, 0.5].
+type OneFifthRule struct {
+	Factor      float64
+	Period      int
+	Min         float64
+	Max         float64
+	probability float64
+	successes   int
+	trials      int
+}
+
+// SelfAdaptiveMutation mutates the rate carried by each individual of a
+// population made by NewSelfAdaptivePopulation, multiplying it by
+// exp(Tau*N(0,1)), and then applies Operator (RegularMutation by default)
+// with the new rate. Tau defaults to 1/sqrt(length). Rates stay within [Min,
+// Max], by default [1/length, 0.5]. Individuals without a rate are mutated
+// with the configured probability.
+type SelfAdaptiveMutation struct {
+	Tau      float64
+	Min      float64
+	Max      float64
+	Operator MutationOperator
+	sum      float64
+	count    int
+}
+
+// SelfAdaptiveIndividual is an individual carrying its own mutation rate.
+// Offspring inherit the rate of the parent their first position is copied
+// from.
+type SelfAdaptiveIndividual struct {
+	Individual
+	MutationRate float64
+}
+
+type selfAdaptivePopulation []*SelfAdaptiveIndividual
+
+// OperatorSelection is an adaptive operator selection strategy over a fixed
+// number of arms.
+type OperatorSelection interface {
+	Reset(arms int)
+	Select(rng RNG) int
+	Reward(arm int, reward float64)
+	Probabilities() []float64
+}
+
+// ProbabilityMatching selects arms with probabilities proportional to their
+// estimated quality, never below PMin (0.1/arms by default). Qualities are
+// exponentially weighted averages of the rewards with rate Alpha (0.3 by
+// default).
+type ProbabilityMatching struct {
+	PMin          float64
+	Alpha         float64
+	quality       []float64
+	probabilities []float64
+}
+
+// AdaptivePursuit moves the probability of the arm with the best estimated
+// quality towards 1-(arms-1)*PMin, and the others towards PMin, at rate Beta
+// (0.8 by default). PMin and Alpha are as in ProbabilityMatching.
+type AdaptivePursuit struct {
+	PMin          float64
+	Alpha         float64
+	Beta          float64
+	quality       []float64
+	probabilities []float64
+}
+
+// UCB is the UCB1 multi-armed bandit: it plays the arm maximising the mean
+// reward plus C*sqrt(2*ln(plays)/arm plays), with C 1 by default. Its
+// probabilities are the fractions of plays of each arm.
+type UCB struct {
+	C       float64
+	rewards []float64
+	plays   []float64
+	total   float64
+}
+
+// AdaptiveCrossover chooses one of Operators for every pair of offspring
+// with Strategy (ProbabilityMatching by default) and rewards it with the
+// improvement of each child on its best parent.
+type AdaptiveCrossover struct {
+	Operators []CrossoverOperator
+	Strategy  OperatorSelection
+	pending   []int
+}
+
+// AdaptiveMutation is like AdaptiveCrossover, choosing a mutation operator
+// for every offspring.
+type AdaptiveMutation struct {
+	Operators []MutationOperator
+	Strategy  OperatorSelection
+	pending   []int
+}
+
+// LinearSchedule returns a schedule going linearly from from, in the first
+// generation, to to, in the last one.
+func LinearSchedule(from, to float64) func(generation, maxGenerations int) float64 {
+	return func(generation, maxGenerations int) float64 {
+		if maxGenerations < 2 {
+			return from
+		}
+		return from + (to-from)*float64(generation)/float64(maxGenerations-1)
+	}
+}
+
+// ExponentialSchedule returns a schedule going geometrically from from to to,
+// which must have the same sign.
+func ExponentialSchedule(from, to float64) func(generation, maxGenerations int) float64 {
+	return func(generation, maxGenerations int) float64 {
+		if maxGenerations < 2 {
+			return from
+		}
+		return from * math.Pow(to/from, float64(generation)/float64(maxGenerations-1))
+	}
+}
+
+func (s *ScheduleControl) Rates(config *Config, generation int) (float64, float64) {
+	crossoverProbability, mutationProbability := config.CrossoverProbability, config.MutationProbability
+	if s.Crossover != nil {
+		crossoverProbability = s.Crossover(generation, config.MaxGenerations)
+	}
+	if s.Mutation != nil {
+		mutationProbability = s.Mutation(generation, config.MaxGenerations)
+	}
+	return crossoverProbability, mutationProbability
+}
+
+func (r *OneFifthRule) Initialize(config *Config) {
+	r.probability = config.MutationProbability
+	r.successes, r.trials = 0, 0
+}
+
+func (r *OneFifthRule) Rates(config *Config, generation int) (float64, float64) {
+	period := r.Period
+	if period <= 0 {
+		period = 1
+	}
+	if generation > 0 && generation%period == 0 && r.trials > 0 {
+		factor := r.Factor
+		if factor <= 0 || factor >= 1 {
+			factor = 0.85
+		}
+		if float64(r.successes)/float64(r.trials) > 0.2 {
+			r.probability /= factor
+		} else {
+			r.probability *= factor
+		}
+		min, max := rateBounds(config, r.Min, r.Max)
+		r.probability = math.Min(max, math.Max(min, r.probability))
+		r.successes, r.trials = 0, 0
+	}
+	return config.CrossoverProbability, r.probability
+}
+
+func (r *OneFifthRule) OnOffspring(config *Config, parent1, parent2 []float64, child Individual, objective []float64) {
+	r.trials++
+	if objective[0] < math.Min(parent1[0], parent2[0]) {
+		r.successes++
+	}
+}
+
+func rateBounds(config *Config, min, max float64) (float64, float64) {
+	if min <= 0 {
+		min = 1 / float64(config.Population.Individual(0).Len())
+	}
+	if max <= 0 {
+		max = 0.5
+	}
+	return min, max
+}
+
+// NewSelfAdaptivePopulation wraps every individual of population in a
+// SelfAdaptiveIndividual with the given initial mutation rate.
+func NewSelfAdaptivePopulation(population Population, rate float64) Population {
+	result := make(selfAdaptivePopulation, population.Len())
+	for i := range result {
+		result[i] = &SelfAdaptiveIndividual{population.Individual(i), rate}
+	}
+	return result
+}
+
+func (p selfAdaptivePopulation) Len() int { return len(p) }
+
+func (p selfAdaptivePopulation) Individual(i int) Individual { return p[i] }
+
+func (p selfAdaptivePopulation) Clone() Population {
+	result := make(selfAdaptivePopulation, len(p))
+	for i, individual := range p {
+		result[i] = individual.Clone().(*SelfAdaptiveIndividual)
+	}
+	return result
+}
+
+func (i *SelfAdaptiveIndividual) Copy(individual Individual, start, end int) {
+	other := individual.(*SelfAdaptiveIndividual)
+	if start == 0 {
+		i.MutationRate = other.MutationRate
+	}
+	i.Individual.Copy(other.Individual, start, end)
+}
+
+func (i *SelfAdaptiveIndividual) Resize(n int) { resize(i.Individual, n) }
+
+func (i *SelfAdaptiveIndividual) Clone() Individual {
+	return &SelfAdaptiveIndividual{i.Individual.Clone(), i.MutationRate}
+}
+
+func (m *SelfAdaptiveMutation) Initialize(config *Config) {
+	if m.Operator == nil {
+		m.Operator = &RegularMutation{}
+	}
+	if i, ok := m.Operator.(initializer); ok {
+		i.Initialize(config)
+	}
+}
+
+func (m *SelfAdaptiveMutation) Mutation(config *Config, individual Individual, probability float64) {
+	if i, ok := individual.(*SelfAdaptiveIndividual); ok {
+		tau := m.Tau
+		if tau <= 0 {
+			tau = 1 / math.Sqrt(float64(individual.Len()))
+		}
+		min, max := rateBounds(config, m.Min, m.Max)
+		i.MutationRate *= math.Exp(tau * config.RandomNumberGenerator.NormFloat64())
+		i.MutationRate = math.Min(max, math.Max(min, i.MutationRate))
+		probability = i.MutationRate
+	}
+	m.sum += probability
+	m.count++
+	m.Operator.Mutation(config, individual, probability)
+}
+
+// Report sets the mutation probability of the result to the mean rate used
+// since the last report.
+func (m *SelfAdaptiveMutation) Report(result *Result) {
+	if m.count > 0 {
+		result.MutationProbability = m.sum / float64(m.count)
+	}
+	m.sum, m.count = 0, 0
+}
+
+func (m *SelfAdaptiveMutation) Finalize(config *Config, population Population, objectives [][]float64, result *Result) {
+	if f, ok := m.Operator.(finalizer); ok {
+		f.Finalize(config, population, objectives, result)
+	}
+}
+
+func (pm *ProbabilityMatching) Reset(arms int) {
+	pm.quality = make([]float64, arms)
+	pm.probabilities = make([]float64, arms)
+	for i := range pm.quality {
+		pm.quality[i] = 1
+		pm.probabilities[i] = 1 / float64(arms)
+	}
+}
+
+func (pm *ProbabilityMatching) Select(rng RNG) int {
+	return spinProbabilities(rng, pm.probabilities)
+}
+
+func (pm *ProbabilityMatching) Reward(arm int, reward float64) {
+	updateQuality(pm.quality, arm, reward, pm.Alpha)
+	pMin := defaultPMin(pm.PMin, len(pm.quality))
+	sum := 0.0
+	for _, q := range pm.quality {
+		sum += q
+	}
+	for i, q := range pm.quality {
+		if sum == 0 {
+			pm.probabilities[i] = 1 / float64(len(pm.quality))
+		} else {
+			pm.probabilities[i] = pMin + (1-float64(len(pm.quality))*pMin)*q/sum
+		}
+	}
+}
+
+func (pm *ProbabilityMatching) Probabilities() []float64 { return pm.probabilities }
+
+func (ap *AdaptivePursuit) Reset(arms int) {
+	ap.quality = make([]float64, arms)
+	ap.probabilities = make([]float64, arms)
+	for i := range ap.quality {
+		ap.quality[i] = 1
+		ap.probabilities[i] = 1 / float64(arms)
+	}
+}
+
+func (ap *AdaptivePursuit) Select(rng RNG) int {
+	return spinProbabilities(rng, ap.probabilities)
+}
+
+func (ap *AdaptivePursuit) Reward(arm int, reward float64) {
+	updateQuality(ap.quality, arm, reward, ap.Alpha)
+	beta := ap.Beta
+	if beta <= 0 || beta > 1 {
+		beta = 0.8
+	}
+	pMin := defaultPMin(ap.PMin, len(ap.quality))
+	pMax := 1 - float64(len(ap.quality)-1)*pMin
+	best := 0
+	for i, q := range ap.quality {
+		if q > ap.quality[best] {
+			best = i
+		}
+	}
+	for i := range ap.probabilities {
+		if i == best {
+			ap.probabilities[i] += beta * (pMax - ap.probabilities[i])
+		} else {
+			ap.probabilities[i] += beta * (pMin - ap.probabilities[i])
+		}
+	}
+}
+
+func (ap *AdaptivePursuit) Probabilities() []float64 { return ap.probabilities }
+
+func (u *UCB) Reset(arms int) {
+	u.rewards = make([]float64, arms)
+	u.plays = make([]float64, arms)
+	u.total = 0
+}
+
+func (u *UCB) Select(rng RNG) int {
+	c := u.C
+	if c <= 0 {
+		c = 1
+	}
+	best, bestValue := 0, math.Inf(-1)
+	for i, n := range u.plays {
+		if n == 0 {
+			return i
+		}
+		if v := u.rewards[i]/n + c*math.Sqrt(2*math.Log(u.total)/n); v > bestValue {
+			best, bestValue = i, v
+		}
+	}
+	return best
+}
+
+func (u *UCB) Reward(arm int, reward float64) {
+	u.rewards[arm] += reward
+	u.plays[arm]++
+	u.total++
+}
+
+func (u *UCB) Probabilities() []float64 {
+	result := make([]float64, len(u.plays))
+	for i, n := range u.plays {
+		if u.total == 0 {
+			result[i] = 1 / float64(len(u.plays))
+		} else {
+			result[i] = n / u.total
+		}
+	}
+	return result
+}
+
+func updateQuality(quality []float64, arm int, reward, alpha float64) {
+	if alpha <= 0 || alpha > 1 {
+		alpha = 0.3
+	}
+	quality[arm] += alpha * (reward - quality[arm])
+}
+
+func defaultPMin(pMin float64, arms int) float64 {
+	if pMin <= 0 || pMin*float64(arms) >= 1 {
+		return 0.1 / float64(arms)
+	}
+	return pMin
+}
+
+func spinProbabilities(rng RNG, probabilities []float64) int {
+	r := rng.Float64()
+	for i, p := range probabilities {
+		if r < p {
+			return i
+		}
+		r -= p
+	}
+	return len(probabilities) - 1
+}
+
+// improvement is the reward of an offspring: how much it improved on the
+// first objective of its best parent, or 0.
+func improvement(parent1, parent2, objective []float64) float64 {
+	return math.Max(0, math.Min(parent1[0], parent2[0])-objective[0])
+}
+
+func (ac *AdaptiveCrossover) Initialize(config *Config) {
+	if ac.Strategy == nil {
+		ac.Strategy = &ProbabilityMatching{}
+	}
+	ac.Strategy.Reset(len(ac.Operators))
+	ac.pending = ac.pending[:0]
+	for _, operator := range ac.Operators {
+		if i, ok := operator.(initializer); ok {
+			i.Initialize(config)
+		}
+	}
+}
+
+func (ac *AdaptiveCrossover) Crossover(config *Config, parent1, parent2, child1, child2 Individual, probability float64) int {
+	arm := ac.Strategy.Select(config.RandomNumberGenerator)
+	ac.pending = append(ac.pending, arm, arm)
+	return ac.Operators[arm].Crossover(config, parent1, parent2, child1, child2, probability)
+}
+
+func (ac *AdaptiveCrossover) OnOffspring(config *Config, parent1, parent2 []float64, child Individual, objective []float64) {
+	ac.Strategy.Reward(ac.pending[0], improvement(parent1, parent2, objective))
+	ac.pending = ac.pending[1:]
+}
+
+func (ac *AdaptiveCrossover) Report(result *Result) {
+	result.CrossoverOperatorProbabilities = append([]float64(nil), ac.Strategy.Probabilities()...)
+}
+
+func (am *AdaptiveMutation) Initialize(config *Config) {
+	if am.Strategy == nil {
+		am.Strategy = &ProbabilityMatching{}
+	}
+	am.Strategy.Reset(len(am.Operators))
+	am.pending = am.pending[:0]
+	for _, operator := range am.Operators {
+		if i, ok := operator.(initializer); ok {
+			i.Initialize(config)
+		}
+	}
+}
+
+func (am *AdaptiveMutation) Mutation(config *Config, individual Individual, probability float64) {
+	arm := am.Strategy.Select(config.RandomNumberGenerator)
+	am.pending = append(am.pending, arm)
+	am.Operators[arm].Mutation(config, individual, probability)
+}
+
+func (am *AdaptiveMutation) OnOffspring(config *Config, parent1, parent2 []float64, child Individual, objective []float64) {
+	am.Strategy.Reward(am.pending[0], improvement(parent1, parent2, objective))
+	am.pending = am.pending[1:]
+}
+
+func (am *AdaptiveMutation) Report(result *Result) {
+	result.MutationOperatorProbabilities = append([]float64(nil), am.Strategy.Probabilities()...)
+}
+
+func (am *AdaptiveMutation) Finalize(config *Config, population Population, objectives [][]float64, result *Result) {
+	mutations := 0
+	for _, operator := range am.Operators {
+		if f, ok := operator.(finalizer); ok {
+			f.Finalize(config, population, objectives, result)
+			mutations += result.Mutations
+		}
+	}
+	result.Mutations = mutations
+}
@@ -0,0 +1,134 @@
+package moea
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCrossoverOperators(t *testing.T) {
+	rng := NewXorshiftWithSeed(21)
+	population := NewRandomBooleanPopulationWithRNG(4, []int{10, 7}, rng)
+	parent1, parent2 := population.Individual(0), population.Individual(1)
+	for i := 0; i < 2; i++ {
+		for j := range parent1.Value(i).([]bool) {
+			parent1.Value(i).([]bool)[j] = true
+			parent2.Value(i).([]bool)[j] = false
+		}
+	}
+	config := &Config{Population: population, RandomNumberGenerator: rng}
+	for _, operator := range []CrossoverOperator{&OnePointCrossover{}, &TwoPointCrossover{}, &UniformCrossover{}} {
+		for n := 0; n < 20; n++ {
+			child1, child2 := population.Individual(2), population.Individual(3)
+			if site := operator.Crossover(config, parent1, parent2, child1, child2, 1); site < 0 {
+				t.Errorf("%T: expected crossover", operator)
+			}
+			swapped := false
+			for i := 0; i < 2; i++ {
+				v1, v2 := child1.Value(i).([]bool), child2.Value(i).([]bool)
+				for j := range v1 {
+					if v1[j] == v2[j] {
+						t.Errorf("%T: children are not complementary: %v %v", operator, v1, v2)
+					}
+					swapped = swapped || !v1[j]
+				}
+			}
+			if _, ok := operator.(*TwoPointCrossover); ok && !swapped {
+				t.Errorf("%T: a crossover returned copies of the parents", operator)
+			}
+		}
+		if operator.Crossover(config, parent1, parent2, population.Individual(2), population.Individual(3), 0) != -1 {
+			t.Errorf("%T: expected no crossover", operator)
+		}
+	}
+}
+
+func TestScheduleControl(t *testing.T) {
+	config := onemaxConfig(NewXorshiftWithSeed(1))
+	config.Algorithm = NewSimpleAlgorithmWithOperators(SimpleAlgorithmOperators{
+		ParameterControl: &ScheduleControl{Mutation: LinearSchedule(0.1, 0.01), Crossover: ExponentialSchedule(1, 0.5)},
+	})
+	config.OnGenerationFunc = func(generation int, result *Result) {
+		mutation := 0.1 + (0.01-0.1)*float64(generation)/9
+		crossover := math.Pow(0.5, float64(generation)/9)
+		if math.Abs(result.MutationProbability-mutation) > 1e-12 || math.Abs(result.CrossoverProbability-crossover) > 1e-12 {
+			t.Errorf("generation %v: unexpected rates %v %v", generation, result.CrossoverProbability, result.MutationProbability)
+		}
+	}
+	if _, err := Run(config); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOneFifthRule(t *testing.T) {
+	config := onemaxConfig(NewXorshiftWithSeed(1))
+	config.MaxGenerations = 30
+	config.Algorithm = NewSimpleAlgorithmWithOperators(SimpleAlgorithmOperators{ParameterControl: &OneFifthRule{}})
+	rates := map[float64]bool{}
+	config.OnGenerationFunc = func(generation int, result *Result) {
+		rates[result.MutationProbability] = true
+		if result.MutationProbability < 1.0/32 || result.MutationProbability > 0.5 {
+			t.Errorf("rate %v out of bounds", result.MutationProbability)
+		}
+	}
+	if _, err := Run(config); err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) < 2 {
+		t.Errorf("expected the rate to change but was %v", rates)
+	}
+}
+
+func TestSelfAdaptiveMutation(t *testing.T) {
+	rng := NewXorshiftWithSeed(1)
+	config := onemaxConfig(rng)
+	config.Population = NewSelfAdaptivePopulation(config.Population, 0.1)
+	config.Algorithm = NewSimpleAlgorithmWithOperators(SimpleAlgorithmOperators{Mutation: &SelfAdaptiveMutation{}})
+	config.OnGenerationFunc = func(generation int, result *Result) {
+		if result.MutationProbability < 1.0/32 || result.MutationProbability > 0.5 || result.MutationProbability == 0.1 {
+			t.Errorf("unexpected mean rate %v", result.MutationProbability)
+		}
+	}
+	result, err := Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Mutations == 0 {
+		t.Errorf("expected mutations")
+	}
+}
+
+func TestOperatorSelectionLearns(t *testing.T) {
+	rng := NewXorshiftWithSeed(3)
+	for _, strategy := range []OperatorSelection{&ProbabilityMatching{}, &AdaptivePursuit{}, &UCB{}} {
+		strategy.Reset(3)
+		for i := 0; i < 500; i++ {
+			arm := strategy.Select(rng)
+			reward := 0.0
+			if arm == 1 {
+				reward = 1
+			}
+			strategy.Reward(arm, reward)
+		}
+		p := strategy.Probabilities()
+		if math.Abs(p[0]+p[1]+p[2]-1) > 1e-9 || p[1] < p[0] || p[1] < p[2] || p[1] < 0.5 {
+			t.Errorf("%T: unexpected probabilities %v", strategy, p)
+		}
+	}
+}
+
+func TestAdaptiveOperators(t *testing.T) {
+	config := onemaxConfig(NewXorshiftWithSeed(1))
+	config.Algorithm = NewSimpleAlgorithmWithOperators(SimpleAlgorithmOperators{
+		Crossover: &AdaptiveCrossover{Operators: []CrossoverOperator{&OnePointCrossover{}, &TwoPointCrossover{}, &UniformCrossover{}}},
+		Mutation:  &AdaptiveMutation{Operators: []MutationOperator{&RegularMutation{}, &FastMutation{}}, Strategy: &AdaptivePursuit{}},
+	})
+	config.OnGenerationFunc = func(generation int, result *Result) {
+		if len(result.CrossoverOperatorProbabilities) != 3 || len(result.MutationOperatorProbabilities) != 2 {
+			t.Errorf("expected 3 crossover and 2 mutation probabilities but was %v and %v",
+				result.CrossoverOperatorProbabilities, result.MutationOperatorProbabilities)
+		}
+	}
+	if _, err := Run(config); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,140 @@
+package moea
+
+import "math"
+
+type SimulatedAnnealingOptions struct {
+	// InitialTemperature is estimated, when 0, so that four out of five
+	// worsening moves from the initial solutions are accepted.
+	InitialTemperature float64
+	// Cooling defaults to GeometricCooling with Alpha 0.95.
+	Cooling Cooling
+	// Moves is the number of moves of each solution per generation, at a
+	// constant temperature. It defaults to the length of the individuals.
+	Moves int
+	// ReheatAfter is the number of generations without improving the best
+	// solution after which the temperature is raised back to Reheat times
+	// the initial temperature (0.5 by default). Zero never reheats.
+	ReheatAfter int
+	Reheat      float64
+}
+
+// Cooling returns the temperature of the next generation from the current
+// one and the fraction of the moves of the generation that were accepted.
+type Cooling interface {
+	Cool(temperature, acceptance float64) float64
+}
+
+// GeometricCooling multiplies the temperature by Alpha (0.95 by default).
+type GeometricCooling struct{ Alpha float64 }
+
+// AdaptiveCooling cools by Alpha (0.9 by default) while the acceptance is
+// at least Target (0.2 by default), and proportionally slower as fewer
+// moves are accepted, down to a tenth of the rate, so that the search
+// lingers at the temperatures where it still makes progress.
+type AdaptiveCooling struct{ Alpha, Target float64 }
+
+type simulatedAnnealing struct {
+	trajectory
+	options     SimulatedAnnealingOptions
+	temperature float64
+	initial     float64
+	stall       int
+	bestSoFar   float64
+}
+
+// NewSimulatedAnnealing returns simulated annealing over the first
+// objective, whose moves mutate a single random position of the current
+// solution with Individual.Mutate. Every member of the population is an
+// independent annealing chain sharing the temperature.
+func NewSimulatedAnnealing(options SimulatedAnnealingOptions) Algorithm {
+	if options.Cooling == nil {
+		options.Cooling = &GeometricCooling{0.95}
+	}
+	if options.Reheat <= 0 {
+		options.Reheat = 0.5
+	}
+	return &simulatedAnnealing{options: options}
+}
+
+func (a *simulatedAnnealing) Initialize(config *Config) {
+	a.initialize(config)
+	a.temperature = a.options.InitialTemperature
+	if a.temperature <= 0 {
+		a.temperature = a.estimateTemperature()
+	}
+	a.initial = a.temperature
+	a.stall = 0
+	a.bestSoFar = math.MaxFloat64
+}
+
+// estimateTemperature samples a move from each initial solution, or at
+// least 20 moves, and solves exp(-mean worsening / T) = 0.8.
+func (a *simulatedAnnealing) estimateTemperature() float64 {
+	n := a.config.Population.Len()
+	sum, worse := 0.0, 0
+	for k := 0; k < n || k < 20; k++ {
+		i := k % n
+		position := a.config.RandomNumberGenerator.Intn(a.candidate.Len())
+		f := a.neighbour(a.candidate, i, position)
+		if delta := f[0] - a.objectives[i][0]; delta > 0 {
+			sum += delta
+			worse++
+		}
+	}
+	a.result.Mutations = 0
+	if worse == 0 {
+		return 1
+	}
+	return -sum / float64(worse) / math.Log(0.8)
+}
+
+func (a *simulatedAnnealing) Generation() (*Result, error) {
+	a.reset()
+	rng := a.config.RandomNumberGenerator
+	accepted, moves := 0, 0
+	for i := range a.objectives {
+		current := a.config.Population.Individual(i)
+		n := a.options.Moves
+		if n <= 0 {
+			n = current.Len()
+		}
+		for m := 0; m < n; m++ {
+			f := a.neighbour(a.candidate, i, rng.Intn(current.Len()))
+			moves++
+			delta := f[0] - a.objectives[i][0]
+			if delta <= 0 || rng.Flip(math.Exp(-delta/a.temperature)) {
+				a.move(i, a.candidate, f)
+				accepted++
+			}
+		}
+	}
+	result := a.summarize()
+	if result.BestObjective[0] < a.bestSoFar {
+		a.bestSoFar = result.BestObjective[0]
+		a.stall = 0
+	} else if a.stall++; a.options.ReheatAfter > 0 && a.stall >= a.options.ReheatAfter {
+		a.temperature = a.options.Reheat * a.initial
+		a.stall = 0
+		return result, nil
+	}
+	a.temperature = a.options.Cooling.Cool(a.temperature, float64(accepted)/float64(moves))
+	return result, nil
+}
+
+func (c *GeometricCooling) Cool(temperature, acceptance float64) float64 {
+	if c.Alpha <= 0 || c.Alpha >= 1 {
+		return 0.95 * temperature
+	}
+	return c.Alpha * temperature
+}
+
+func (c *AdaptiveCooling) Cool(temperature, acceptance float64) float64 {
+	alpha, target := c.Alpha, c.Target
+	if alpha <= 0 || alpha >= 1 {
+		alpha = 0.9
+	}
+	if target <= 0 {
+		target = 0.2
+	}
+	return temperature * (1 - (1-alpha)*math.Max(0.1, math.Min(1, acceptance/target)))
+}
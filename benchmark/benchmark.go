@@ -0,0 +1,135 @@
+package benchmark
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+type Problem struct {
+	Name               string
+	NumberOfVariables  int
+	NumberOfObjectives int
+	Bounds             func(i int) (float64, float64)
+	Evaluate           func(x []float64) []float64
+	// Constraints returns the values of the constraints at x, each one
+	// satisfied when not negative. It is nil for unconstrained problems.
+	Constraints func(x []float64) []float64
+	// ReferenceFront returns about points members of the Pareto front, to
+	// compute the distance-based indicators. It is nil when the front is
+	// not known.
+	ReferenceFront func(points int) [][]float64
+}
+
+// Factory builds a problem instance. Zero means the problem's default number
+// of variables or objectives; problems that are not scalable ignore them.
+type Factory func(variables, objectives int) (*Problem, error)
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Factory{}
+)
+
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic("benchmark: problem registered twice: " + name)
+	}
+	registry[name] = factory
+}
+
+func Get(name string, variables, objectives int) (*Problem, error) {
+	mu.RLock()
+	factory, ok := registry[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown problem %q", name)
+	}
+	return factory(variables, objectives)
+}
+
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	result := make([]string, 0, len(registry))
+	for name := range registry {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Violation returns the sum of the negative values of constraints, 0 when
+// all of them are satisfied, the measure NSGA-II compares infeasible
+// solutions by.
+func Violation(constraints []float64) float64 {
+	result := 0.0
+	for _, c := range constraints {
+		result += math.Min(0, c)
+	}
+	return result
+}
+
+func constantBounds(min, max float64) func(int) (float64, float64) {
+	return func(int) (float64, float64) { return min, max }
+}
+
+func fixed(p Problem) Factory {
+	return func(variables, objectives int) (*Problem, error) {
+		if variables != 0 && variables != p.NumberOfVariables {
+			return nil, fmt.Errorf("%s has %d variables", p.Name, p.NumberOfVariables)
+		}
+		if objectives != 0 && objectives != p.NumberOfObjectives {
+			return nil, fmt.Errorf("%s has %d objectives", p.Name, p.NumberOfObjectives)
+		}
+		result := p
+		return &result, nil
+	}
+}
+
+func scalableVariables(p Problem, min int, evaluate func(n int) func([]float64) []float64) Factory {
+	return func(variables, objectives int) (*Problem, error) {
+		if objectives != 0 && objectives != p.NumberOfObjectives {
+			return nil, fmt.Errorf("%s has %d objectives", p.Name, p.NumberOfObjectives)
+		}
+		if variables == 0 {
+			variables = p.NumberOfVariables
+		}
+		if variables < min {
+			return nil, fmt.Errorf("%s needs at least %d variables", p.Name, min)
+		}
+		result := p
+		result.NumberOfVariables = variables
+		result.Evaluate = evaluate(variables)
+		return &result, nil
+	}
+}
+
+// scalable builds problems of any number of objectives, 3 by default, and
+// a number of variables that defaults to variables(objectives). check
+// rejects the combinations the problem does not support.
+func scalable(p Problem, variables func(m int) int, check func(n, m int) error,
+	evaluate func(n, m int) func([]float64) []float64, front func(m int) func(int) [][]float64) Factory {
+	return func(n, m int) (*Problem, error) {
+		if m == 0 {
+			m = p.NumberOfObjectives
+		}
+		if m < 2 {
+			return nil, fmt.Errorf("%s needs at least 2 objectives", p.Name)
+		}
+		if n == 0 {
+			n = variables(m)
+		}
+		if err := check(n, m); err != nil {
+			return nil, err
+		}
+		result := p
+		result.NumberOfVariables = n
+		result.NumberOfObjectives = m
+		result.Evaluate = evaluate(n, m)
+		result.ReferenceFront = front(m)
+		return &result, nil
+	}
+}
@@ -0,0 +1,247 @@
+package benchmark
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+func TestGet(t *testing.T) {
+	for _, f := range []struct {
+		name       string
+		variables  int
+		x          []float64
+		objectives []float64
+	}{
+		{"sch", 0, []float64{1}, []float64{1, 1}},
+		{"zdt1", 3, []float64{0.25, 0, 0}, []float64{0.25, 0.5}},
+		{"zdt2", 0, make([]float64, 30), []float64{0, 1}},
+		{"zdt4", 2, []float64{1, 0}, []float64{1, 0}},
+	} {
+		p, err := Get(f.name, f.variables, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(f.x) != p.NumberOfVariables {
+			t.Fatal("Expected", len(f.x), "variables but was", p.NumberOfVariables, f.name)
+		}
+		o := p.Evaluate(f.x)
+		for i := range o {
+			if math.Abs(o[i]-f.objectives[i]) > 1e-12 {
+				t.Error("Expected", f.objectives, "but was", o, f.name)
+			}
+		}
+	}
+}
+
+func TestGetErrors(t *testing.T) {
+	if _, err := Get("unknown", 0, 0); err == nil {
+		t.Error("Expected error for unknown problem")
+	}
+	if _, err := Get("sch", 2, 0); err == nil {
+		t.Error("Expected error for wrong number of variables")
+	}
+	if _, err := Get("zdt1", 0, 3); err == nil {
+		t.Error("Expected error for wrong number of objectives")
+	}
+}
+
+func TestDTLZ(t *testing.T) {
+	for _, f := range []struct {
+		name       string
+		x          []float64
+		objectives []float64
+	}{
+		{"dtlz1", []float64{0.5, 0.5, 0.5, 0.5}, []float64{0.125, 0.125, 0.25}},
+		{"dtlz2", []float64{0, 0.5, 0.5}, []float64{math.Sqrt(0.5), math.Sqrt(0.5), 0}},
+		{"dtlz2", []float64{1, 1, 0.5}, []float64{0, 0, 1}},
+		{"dtlz5", []float64{0.5, 0, 0.5}, []float64{0.5, 0.5, math.Sqrt(0.5)}},
+		{"dtlz7", []float64{0, 0, 0}, []float64{0, 0, 6}},
+	} {
+		p, err := Get(f.name, len(f.x), 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		o := p.Evaluate(f.x)
+		for i := range o {
+			if math.Abs(o[i]-f.objectives[i]) > 1e-12 {
+				t.Error("Expected", f.objectives, "but was", o, f.name)
+			}
+		}
+	}
+	if p, _ := Get("dtlz4", 0, 5); p.NumberOfVariables != 14 {
+		t.Error("Expected 14 variables for 5 objectives, got", p.NumberOfVariables)
+	}
+}
+
+func TestReferenceFronts(t *testing.T) {
+	for _, f := range []struct {
+		name       string
+		objectives int
+		norm       func(f []float64) float64
+		expected   float64
+	}{
+		{"dtlz1", 5, func(f []float64) float64 { return f[0] + f[1] + f[2] + f[3] + f[4] }, 0.5},
+		{"dtlz3", 8, sphereNorm, 1},
+		{"dtlz6", 10, sphereNorm, 1},
+		{"wfg4", 5, func(f []float64) float64 {
+			return sphereNorm([]float64{f[0] / 2, f[1] / 4, f[2] / 6, f[3] / 8, f[4] / 10})
+		}, 1},
+	} {
+		p, err := Get(f.name, 0, f.objectives)
+		if err != nil {
+			t.Fatal(err)
+		}
+		front := p.ReferenceFront(100)
+		if len(front) != 100 {
+			t.Error("Expected 100 members of the front of", f.name, "got", len(front))
+		}
+		for _, o := range front {
+			if len(o) != f.objectives || math.Abs(f.norm(o)-f.expected) > 1e-9 {
+				t.Error("Expected", o, "on the front of", f.name)
+			}
+		}
+	}
+}
+
+// TestWFGFronts checks that the WFG problems reach their reference fronts
+// with the distance variables at 0.35 of their range.
+func TestWFGFronts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, name := range []string{"wfg1", "wfg3", "wfg4", "wfg5", "wfg6", "wfg7"} {
+		p, err := Get(name, 0, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		front := p.ReferenceFront(1891)
+		x := make([]float64, p.NumberOfVariables)
+		for trial := 0; trial < 20; trial++ {
+			for i := range x {
+				_, max := p.Bounds(i)
+				x[i] = 0.35 * max
+				if i < 4 {
+					x[i] = rng.Float64() * max
+				}
+			}
+			o := p.Evaluate(x)
+			closest := math.Inf(1)
+			for _, r := range front {
+				closest = math.Min(closest, distance(o, r))
+			}
+			if closest > 0.5 {
+				t.Error("Expected", o, "on the front of", name, "but was", closest, "away")
+			}
+			if name >= "wfg4" && math.Abs(sphereNorm([]float64{o[0] / 2, o[1] / 4, o[2] / 6})-1) > 1e-9 {
+				t.Error("Expected", o, "on the sphere")
+			}
+		}
+	}
+}
+
+func distance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
+
+func TestViolation(t *testing.T) {
+	if v := Violation([]float64{1, -0.5, 0, -2}); v != -2.5 {
+		t.Errorf("Want -2.5, got %v", v)
+	}
+	if v := Violation(nil); v != 0 {
+		t.Errorf("Want 0, got %v", v)
+	}
+}
+
+func TestConstrained(t *testing.T) {
+	for _, f := range []struct {
+		name     string
+		x        []float64
+		feasible bool
+	}{
+		{"bnh", []float64{0, 0}, true},
+		{"bnh", []float64{5, 3}, true},
+		{"bnh", []float64{0, 3}, false},
+		{"srn", []float64{-2.5, 5}, true},
+		{"srn", []float64{2, 1}, false},
+		{"tnk", []float64{1, 0.5}, true},
+		{"tnk", []float64{0.5, 0.5}, false},
+		{"osy", []float64{5, 1, 5, 0, 5, 0}, true},
+		{"osy", []float64{0, 0, 1, 0, 1, 0}, false},
+		{"constr", []float64{0.5, 1.5}, true},
+		{"constr", []float64{0.1, 0}, false},
+		{"welded-beam", []float64{1, 5, 10, 1}, true},
+		{"welded-beam", []float64{1, 5, 10, 0.9}, false},
+		{"car-side-impact", []float64{1.5, 1.35, 1.5, 1.5, 2.625, 1.2, 1.2}, true},
+		{"car-side-impact", []float64{1, 0.9, 1, 1, 1.75, 0.8, 0.8}, false},
+	} {
+		p, err := Get(f.name, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if feasible := Violation(p.Constraints(f.x)) == 0; feasible != f.feasible {
+			t.Errorf("%s %v: want feasible %v, got %v", f.name, f.x, f.feasible, p.Constraints(f.x))
+		}
+	}
+	for _, name := range Names() {
+		p, err := Get(name, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Constraints == nil || p.ReferenceFront == nil {
+			continue
+		}
+		front := p.ReferenceFront(100)
+		if len(front) == 0 {
+			t.Errorf("%s: empty front", name)
+		}
+		for i, a := range front {
+			for j, b := range front {
+				if i != j && moea.Dominates(a, b) {
+					t.Errorf("%s: %v dominates %v", name, a, b)
+				}
+			}
+		}
+	}
+}
+
+func TestConstrainedFronts(t *testing.T) {
+	// The constraints of these problems are on the objectives, which their
+	// fronts must satisfy.
+	for name, constraints := range map[string]func([]float64) []float64{
+		"c2-dtlz2": c2DTLZ2,
+		"c3-dtlz1": c3DTLZ1,
+		"c3-dtlz4": c3DTLZ4,
+		"ctp2": func(f []float64) []float64 {
+			return []float64{ctpConstraint(f, []float64{-0.2 * math.Pi, 0.2, 10, 1, 6, 1})}
+		},
+		"ctp7": func(f []float64) []float64 {
+			return []float64{ctpConstraint(f, []float64{-0.05 * math.Pi, 40, 5, 1, 6, 0})}
+		},
+	} {
+		p, err := Get(name, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range p.ReferenceFront(100) {
+			if v := Violation(constraints(f)); v < -1e-9 {
+				t.Errorf("%s: %v violates the constraints by %v", name, f, v)
+			}
+		}
+	}
+	p, err := Get("c3-dtlz1", 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The front of C3-DTLZ1 with two objectives is the line f1 + 2f2 = 1
+	// where f1 >= f2, and 2f1 + f2 = 1 elsewhere.
+	for _, f := range p.ReferenceFront(50) {
+		if math.Abs(math.Min(f[0]+2*f[1], f[1]+2*f[0])-1) > 1e-9 {
+			t.Errorf("%v is not on the front", f)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package benchmark
+
+import "math"
+
+func init() {
+	Register("sch", fixed(Problem{
+		Name:               "sch",
+		NumberOfVariables:  1,
+		NumberOfObjectives: 2,
+		Bounds:             constantBounds(-1000, 1000),
+		Evaluate: func(x []float64) []float64 {
+			return []float64{x[0] * x[0], (x[0] - 2.0) * (x[0] - 2.0)}
+		},
+	}))
+	Register("fon", fixed(Problem{
+		Name:               "fon",
+		NumberOfVariables:  3,
+		NumberOfObjectives: 2,
+		Bounds:             constantBounds(-4, 4),
+		Evaluate: func(x []float64) []float64 {
+			s1, s2 := 0.0, 0.0
+			for i := 0; i < 3; i++ {
+				s1 += math.Pow(x[i]-1/math.Sqrt(3), 2)
+				s2 += math.Pow(x[i]+1/math.Sqrt(3), 2)
+			}
+			return []float64{1 - math.Exp(-s1), 1 - math.Exp(-s2)}
+		},
+	}))
+	Register("pol", fixed(Problem{
+		Name:               "pol",
+		NumberOfVariables:  2,
+		NumberOfObjectives: 2,
+		Bounds:             constantBounds(-math.Pi, math.Pi),
+		Evaluate: func(x []float64) []float64 {
+			a1 := 0.5*math.Sin(1) - 2*math.Cos(1) + math.Sin(2) - 1.5*math.Cos(2)
+			a2 := 1.5*math.Sin(1) - math.Cos(1) + 2*math.Sin(2) - 0.5*math.Cos(2)
+			b1 := 0.5*math.Sin(x[0]) - 2*math.Cos(x[0]) + math.Sin(x[1]) - 1.5*math.Cos(x[1])
+			b2 := 1.5*math.Sin(x[0]) - math.Cos(x[0]) + 2*math.Sin(x[1]) - 0.5*math.Cos(x[1])
+			return []float64{1 + math.Pow(a1-b1, 2) + math.Pow(a2-b2, 2),
+				math.Pow(x[0]+3, 2) + math.Pow(x[1]+1, 2)}
+		},
+	}))
+	Register("kur", fixed(Problem{
+		Name:               "kur",
+		NumberOfVariables:  3,
+		NumberOfObjectives: 2,
+		Bounds:             constantBounds(-5, 5),
+		Evaluate: func(x []float64) []float64 {
+			s1, s2 := 0.0, 0.0
+			for i := 0; i < 3; i++ {
+				if i < 2 {
+					s1 += -10 * math.Exp(-0.2*math.Sqrt(x[i]*x[i]+x[i+1]*x[i+1]))
+				}
+				s2 += math.Pow(math.Abs(x[i]), 0.8) + 5*math.Sin(x[i]*x[i]*x[i])
+			}
+			return []float64{s1, s2}
+		},
+	}))
+	Register("zdt1", scalableVariables(Problem{Name: "zdt1", NumberOfVariables: 30, NumberOfObjectives: 2,
+		Bounds: constantBounds(0, 1)}, 2, func(n int) func([]float64) []float64 {
+		return func(x []float64) []float64 {
+			g := zdtG(x, n)
+			return []float64{x[0], g * (1 - math.Sqrt(x[0]/g))}
+		}
+	}))
+	Register("zdt2", scalableVariables(Problem{Name: "zdt2", NumberOfVariables: 30, NumberOfObjectives: 2,
+		Bounds: constantBounds(0, 1)}, 2, func(n int) func([]float64) []float64 {
+		return func(x []float64) []float64 {
+			g := zdtG(x, n)
+			return []float64{x[0], g * (1 - math.Pow(x[0]/g, 2))}
+		}
+	}))
+	Register("zdt3", scalableVariables(Problem{Name: "zdt3", NumberOfVariables: 30, NumberOfObjectives: 2,
+		Bounds: constantBounds(0, 1)}, 2, func(n int) func([]float64) []float64 {
+		return func(x []float64) []float64 {
+			g := zdtG(x, n)
+			return []float64{x[0], g * (1 - math.Sqrt(x[0]/g) - x[0]/g*math.Sin(10*math.Pi*x[0]))}
+		}
+	}))
+	Register("zdt4", scalableVariables(Problem{Name: "zdt4", NumberOfVariables: 10, NumberOfObjectives: 2,
+		Bounds: func(i int) (float64, float64) {
+			if i == 0 {
+				return 0, 1
+			}
+			return -5, 5
+		}}, 2, func(n int) func([]float64) []float64 {
+		return func(x []float64) []float64 {
+			g := 1.0 + 10.0*float64(n-1)
+			for i := 1; i < n; i++ {
+				g += x[i]*x[i] - 10.0*math.Cos(4.0*math.Pi*x[i])
+			}
+			return []float64{x[0], g * (1.0 - math.Sqrt(x[0]/g))}
+		}
+	}))
+	Register("zdt6", scalableVariables(Problem{Name: "zdt6", NumberOfVariables: 10, NumberOfObjectives: 2,
+		Bounds: constantBounds(0, 1)}, 2, func(n int) func([]float64) []float64 {
+		return func(x []float64) []float64 {
+			s := 0.0
+			for i := 1; i < n; i++ {
+				s += x[i]
+			}
+			g := 1 + 9*math.Pow(s/float64(n-1), 0.25)
+			f1 := 1 - math.Exp(-4*x[0])*math.Pow(math.Sin(6*math.Pi*x[0]), 6)
+			return []float64{f1, g * (1 - math.Pow(f1/g, 2))}
+		}
+	}))
+}
+
+func zdtG(x []float64, n int) float64 {
+	s := 0.0
+	for i := 1; i < n; i++ {
+		s += x[i]
+	}
+	return 1 + 9*s/float64(n-1)
+}
@@ -0,0 +1,54 @@
+package benchmark
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The combinatorial problems are not registered: their instances, read
+// from the standard files or generated, evaluate binary strings or
+// permutations rather than real vectors. Their objectives are to be
+// minimized, the profits and fitnesses to be maximized being negated.
+
+// words returns the whitespace separated words of r, but those of the
+// lines starting with comment.
+func words(r io.Reader, comment string) ([]string, error) {
+	var result []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<24)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if comment != "" && strings.HasPrefix(line, comment) {
+			continue
+		}
+		result = append(result, strings.Fields(line)...)
+	}
+	return result, scanner.Err()
+}
+
+// numbers parses count numbers from words.
+func numbers(words []string, count int) ([]float64, error) {
+	if len(words) < count {
+		return nil, fmt.Errorf("want %d numbers, got %d", count, len(words))
+	}
+	result := make([]float64, count)
+	for i := range result {
+		var err error
+		if result[i], err = strconv.ParseFloat(words[i], 64); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// matrix splits values in rows of n.
+func matrix(values []float64, n int) [][]float64 {
+	result := make([][]float64, len(values)/n)
+	for i := range result {
+		result[i] = values[i*n : (i+1)*n]
+	}
+	return result
+}
@@ -0,0 +1,192 @@
+package benchmark
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/binary"
+	"github.com/project-draco/moea/permutation"
+)
+
+func TestKnapsack(t *testing.T) {
+	k, err := ReadKnapsack(strings.NewReader(`knapsack problem specification (2 knapsacks, 3 items)
+=
+knapsack 1:
+ capacity: +30
+ item 1:
+  weight: +20
+  profit: +10
+ item 2:
+  weight: +10
+  profit: +40
+ item 3:
+  weight: +15
+  profit: +30
+=
+knapsack 2:
+ capacity: +25
+ item 1:
+  weight: +10
+  profit: +50
+ item 2:
+  weight: +20
+  profit: +20
+ item 3:
+  weight: +10
+  profit: +10
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(k.Capacities, []float64{30, 25}) || !reflect.DeepEqual(k.Profits[1], []float64{50, 20, 10}) {
+		t.Errorf("Wrong instance %v %v", k.Capacities, k.Profits)
+	}
+	selected := []bool{true, true, true}
+	if f := k.Evaluate(selected); !reflect.DeepEqual(f, []float64{-80, -80}) {
+		t.Errorf("Want [-80 -80], got %v", f)
+	}
+	// The ratios are 5, 4 and 2: item 3 goes first, then item 2.
+	if removed := k.Repair(selected); !reflect.DeepEqual(removed, []int{2, 1}) {
+		t.Errorf("Want [2 1] removed, got %v", removed)
+	}
+	rng := moea.NewXorshiftWithSeed(1)
+	k = NewKnapsack(100, 2, rng)
+	population := binary.NewRandomBinaryPopulation(10, []int{100}, nil, rng)
+	for i := 0; i < population.Len(); i++ {
+		individual := population.Individual(i)
+		f := k.ObjectiveFunc()(individual)
+		bits := individual.Value(0).(binary.BinaryString)
+		selected := make([]bool, 100)
+		for j := range selected {
+			selected[j] = bits.Test(j)
+		}
+		if len(k.Repair(selected)) > 0 {
+			t.Errorf("Individual %d was not repaired", i)
+		}
+		if !reflect.DeepEqual(f, k.Evaluate(selected)) {
+			t.Errorf("Want %v, got %v", k.Evaluate(selected), f)
+		}
+	}
+}
+
+func TestTSP(t *testing.T) {
+	a, err := ReadTSPLIB(strings.NewReader(`NAME : square
+TYPE : TSP
+DIMENSION : 4
+EDGE_WEIGHT_TYPE : EUC_2D
+NODE_COORD_SECTION
+1 0 0
+2 3 0
+3 3 4
+4 0 4
+EOF
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ReadTSPLIB(strings.NewReader(`NAME: explicit
+TYPE: TSP
+DIMENSION: 4
+EDGE_WEIGHT_TYPE: EXPLICIT
+EDGE_WEIGHT_FORMAT: UPPER_ROW
+EDGE_WEIGHT_SECTION
+1 2 3
+4 5
+6
+EOF
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a[0][2] != 5 || b[3][1] != 5 || b[1][1] != 0 {
+		t.Errorf("Wrong distances %v %v", a, b)
+	}
+	tsp := &TSP{[][][]float64{a, b}}
+	if f := tsp.Evaluate([]int{0, 1, 2, 3}); !reflect.DeepEqual(f, []float64{14, 14}) {
+		t.Errorf("Want [14 14], got %v", f)
+	}
+	rng := moea.NewXorshiftWithSeed(1)
+	individual := permutation.NewRandomPermutationPopulation(2, 4, rng).Individual(0)
+	if f := tsp.ObjectiveFunc()(individual); !reflect.DeepEqual(f, tsp.Evaluate(permutation.Permutation(individual))) {
+		t.Errorf("Wrong objectives %v", f)
+	}
+	if _, err := ReadTSPLIB(strings.NewReader("DIMENSION: 2\nEDGE_WEIGHT_TYPE: GEO\nNODE_COORD_SECTION\n1 0 0\n2 1 1\n")); err == nil {
+		t.Errorf("Want an error for GEO distances")
+	}
+	random := NewTSP(10, 3, rng)
+	if len(random.Distances) != 3 || random.Distances[2][4][7] != random.Distances[2][7][4] {
+		t.Errorf("Wrong random instance")
+	}
+}
+
+func TestQAP(t *testing.T) {
+	q, err := ReadQAP(strings.NewReader(`# 3 facilities, 2 objectives
+3
+0 1 2
+1 0 3
+2 3 0
+
+0 5 0
+5 0 1
+0 1 0
+
+0 0 2
+0 0 0
+2 0 0
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.Flows) != 2 {
+		t.Fatalf("Want 2 objectives, got %d", len(q.Flows))
+	}
+	// Facilities 0 and 1 at locations 2 and 0, 2 apart, and facilities 1
+	// and 2 at locations 0 and 1, 1 apart.
+	if f := q.Evaluate([]int{2, 0, 1}); !reflect.DeepEqual(f, []float64{2 * (5*2 + 1*1), 2 * 2 * 3}) {
+		t.Errorf("Want [22 12], got %v", f)
+	}
+	if _, err := ReadQAP(strings.NewReader("3\n0 1 2\n1 0 3\n2 3 0\n")); err == nil {
+		t.Errorf("Want an error without flows")
+	}
+}
+
+func TestNK(t *testing.T) {
+	l, err := ReadNK(strings.NewReader(`c two objectives, two bits, one link
+p rMNK 0.5 2 2 1
+p links
+0 1
+1 0
+0 1
+1 0
+p tables
+0.1 0.2 0.3 0.4
+0.5 0.6 0.7 0.8
+0.9 0.8 0.7 0.6
+0.5 0.4 0.3 0.2
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Bits 0 and 1 read 10 from bit 0 and 01 from bit 1.
+	f := l.Evaluate([]bool{true, false})
+	if math.Abs(f[0]+(0.3+0.6)/2) > 1e-12 || math.Abs(f[1]+(0.7+0.4)/2) > 1e-12 {
+		t.Errorf("Want [-0.45 -0.55], got %v", f)
+	}
+	rng := moea.NewXorshiftWithSeed(1)
+	same := NewNK(20, 3, 3, 1, rng)
+	opposite := NewNK(20, 3, 2, -1, rng)
+	for i := 0; i < 20; i++ {
+		for v := 0; v < 16; v++ {
+			c := same.Contributions
+			if math.Abs(c[0][i][v]-c[1][i][v]) > 1e-12 || math.Abs(c[0][i][v]-c[2][i][v]) > 1e-12 {
+				t.Fatalf("Contributions %v %v %v are not equal", c[0][i][v], c[1][i][v], c[2][i][v])
+			}
+			if c := opposite.Contributions; math.Abs(c[0][i][v]+c[1][i][v]-1) > 1e-12 {
+				t.Fatalf("Contributions %v %v are not opposite", c[0][i][v], c[1][i][v])
+			}
+		}
+	}
+}
@@ -0,0 +1,473 @@
+package benchmark
+
+import (
+	"math"
+
+	"github.com/project-draco/moea/indicator"
+)
+
+// The constrained problems. Their fronts are those of the feasible
+// solutions; the fronts of the engineering problems, welded beam and car
+// side impact, are not known in closed form.
+func init() {
+	Register("bnh", fixed(Problem{
+		Name:               "bnh",
+		NumberOfVariables:  2,
+		NumberOfObjectives: 2,
+		Bounds: func(i int) (float64, float64) {
+			if i == 0 {
+				return 0, 5
+			}
+			return 0, 3
+		},
+		Evaluate: bnh,
+		Constraints: func(x []float64) []float64 {
+			return []float64{
+				25 - (x[0]-5)*(x[0]-5) - x[1]*x[1],
+				(x[0]-8)*(x[0]-8) + (x[1]+3)*(x[1]+3) - 7.7,
+			}
+		},
+		ReferenceFront: func(points int) [][]float64 {
+			return paretoSetFront(points, bnh, func(t float64) []float64 {
+				return []float64{5 * t, math.Min(5*t, 3)}
+			})
+		},
+	}))
+	Register("srn", fixed(Problem{
+		Name:               "srn",
+		NumberOfVariables:  2,
+		NumberOfObjectives: 2,
+		Bounds:             constantBounds(-20, 20),
+		Evaluate:           srn,
+		Constraints: func(x []float64) []float64 {
+			return []float64{225 - x[0]*x[0] - x[1]*x[1], 3*x[1] - x[0] - 10}
+		},
+		ReferenceFront: func(points int) [][]float64 {
+			return paretoSetFront(points, srn, func(t float64) []float64 {
+				return []float64{-2.5, 2.5 + t*(math.Sqrt(225-6.25)-2.5)}
+			})
+		},
+	}))
+	Register("tnk", fixed(Problem{
+		Name:               "tnk",
+		NumberOfVariables:  2,
+		NumberOfObjectives: 2,
+		Bounds:             constantBounds(0, math.Pi),
+		Evaluate:           func(x []float64) []float64 { return []float64{x[0], x[1]} },
+		Constraints:        tnk,
+		ReferenceFront: func(points int) [][]float64 {
+			// The boundary of the first constraint, in polar coordinates
+			// from the x2 axis, where the second one holds.
+			var front [][]float64
+			for i := 0; i < points; i++ {
+				phi := math.Pi / 2 * float64(i) / float64(points-1)
+				r := math.Sqrt(1 + 0.1*math.Cos(16*phi))
+				x := []float64{r * math.Sin(phi), r * math.Cos(phi)}
+				if tnk(x)[1] >= 0 {
+					front = append(front, x)
+				}
+			}
+			return indicator.Nondominated(front)
+		},
+	}))
+	Register("osy", fixed(Problem{
+		Name:               "osy",
+		NumberOfVariables:  6,
+		NumberOfObjectives: 2,
+		Bounds: func(i int) (float64, float64) {
+			switch i {
+			case 2, 4:
+				return 1, 5
+			case 3:
+				return 0, 6
+			}
+			return 0, 10
+		},
+		Evaluate: osy,
+		Constraints: func(x []float64) []float64 {
+			return []float64{
+				x[0] + x[1] - 2,
+				6 - x[0] - x[1],
+				2 - x[1] + x[0],
+				2 - x[0] + 3*x[1],
+				4 - (x[2]-3)*(x[2]-3) - x[3],
+				(x[4]-3)*(x[4]-3) + x[5] - 4,
+			}
+		},
+		ReferenceFront: func(points int) [][]float64 {
+			// The five regions of Deb (2001), where x4 = x6 = 0.
+			regions := []func(t float64) []float64{
+				func(t float64) []float64 { return []float64{5, 1, 1 + 4*t, 0, 5, 0} },
+				func(t float64) []float64 { return []float64{5, 1, 1 + 4*t, 0, 1, 0} },
+				func(t float64) []float64 {
+					x1 := 4.056 + 0.944*t
+					return []float64{x1, (x1 - 2) / 3, 1, 0, 1, 0}
+				},
+				func(t float64) []float64 { return []float64{0, 2, 1 + (1+math.Sqrt(3))*t, 0, 1, 0} },
+				func(t float64) []float64 { return []float64{t, 2 - t, 1, 0, 1, 0} },
+			}
+			var front [][]float64
+			for _, region := range regions {
+				front = append(front, paretoSetFront(points/len(regions)+1, osy, region)...)
+			}
+			return indicator.Nondominated(front)
+		},
+	}))
+	Register("constr", fixed(Problem{
+		Name:               "constr",
+		NumberOfVariables:  2,
+		NumberOfObjectives: 2,
+		Bounds: func(i int) (float64, float64) {
+			if i == 0 {
+				return 0.1, 1
+			}
+			return 0, 5
+		},
+		Evaluate: constr,
+		Constraints: func(x []float64) []float64 {
+			return []float64{x[1] + 9*x[0] - 6, -x[1] + 9*x[0] - 1}
+		},
+		ReferenceFront: func(points int) [][]float64 {
+			return paretoSetFront(points, constr, func(t float64) []float64 {
+				x1 := 7.0/18 + t*(1-7.0/18)
+				return []float64{x1, math.Max(0, 6-9*x1)}
+			})
+		},
+	}))
+	Register("ctp1", ctp("ctp1", func(x []float64, g float64) []float64 {
+		return []float64{x[0], g * math.Exp(-x[0]/g)}
+	}, func(f []float64) []float64 {
+		return []float64{f[1] - 0.858*math.Exp(-0.541*f[0]), f[1] - 0.728*math.Exp(-0.295*f[0])}
+	}, func(f1 float64) float64 { return math.Exp(-f1) }))
+	for i, parameters := range [][]float64{
+		{-0.2 * math.Pi, 0.2, 10, 1, 6, 1},
+		{-0.2 * math.Pi, 0.1, 10, 1, 0.5, 1},
+		{-0.2 * math.Pi, 0.75, 10, 1, 0.5, 1},
+		{-0.2 * math.Pi, 0.1, 10, 2, 0.5, 1},
+		{0.1 * math.Pi, 40, 0.5, 1, 2, -2},
+		{-0.05 * math.Pi, 40, 5, 1, 6, 0},
+	} {
+		parameters := parameters
+		name := "ctp" + string(rune('2'+i))
+		Register(name, ctp(name, ctpObjectives, func(f []float64) []float64 {
+			return []float64{ctpConstraint(f, parameters)}
+		}, func(f1 float64) float64 { return 1 - f1 }))
+	}
+	Register("ctp8", ctp("ctp8", ctpObjectives, func(f []float64) []float64 {
+		return []float64{
+			ctpConstraint(f, []float64{0.1 * math.Pi, 40, 0.5, 1, 2, -2}),
+			ctpConstraint(f, []float64{-0.05 * math.Pi, 40, 2, 1, 6, 0}),
+		}
+	}, func(f1 float64) float64 { return 1 - f1 }))
+	Register("c1-dtlz1", objectiveConstraints(dtlz("c1-dtlz1", 5, dtlz1, linearFront), func(m int) func([]float64) []float64 {
+		return func(f []float64) []float64 {
+			c := 1 - f[m-1]/0.6
+			for _, y := range f[:m-1] {
+				c -= y / 0.5
+			}
+			return []float64{c}
+		}
+	}))
+	Register("c1-dtlz3", objectiveConstraints(dtlz("c1-dtlz3", 10, dtlz3, sphericalFront), func(m int) func([]float64) []float64 {
+		r := 15.0
+		if m <= 3 {
+			r = 9
+		} else if m <= 8 {
+			r = 12.5
+		}
+		return func(f []float64) []float64 {
+			s := sphereNorm(f)
+			return []float64{(s*s - 16) * (s*s - r*r)}
+		}
+	}))
+	Register("c2-dtlz2", objectiveConstraints(dtlz("c2-dtlz2", 10, dtlz2, func(m int) func(int) [][]float64 {
+		return func(points int) [][]float64 {
+			var front [][]float64
+			for _, f := range SphericalFront(points, m) {
+				if c2DTLZ2(f)[0] >= 0 {
+					front = append(front, f)
+				}
+			}
+			return front
+		}
+	}), func(int) func([]float64) []float64 { return c2DTLZ2 }))
+	Register("c3-dtlz1", objectiveConstraints(dtlz("c3-dtlz1", 5, dtlz1, func(m int) func(int) [][]float64 {
+		return func(points int) [][]float64 {
+			return rayFront(points, m, c3DTLZ1)
+		}
+	}), func(int) func([]float64) []float64 { return c3DTLZ1 }))
+	Register("c3-dtlz4", objectiveConstraints(dtlz("c3-dtlz4", 10, dtlz4, func(m int) func(int) [][]float64 {
+		return func(points int) [][]float64 {
+			return rayFront(points, m, c3DTLZ4)
+		}
+	}), func(int) func([]float64) []float64 { return c3DTLZ4 }))
+	Register("welded-beam", fixed(Problem{
+		Name:               "welded-beam",
+		NumberOfVariables:  4,
+		NumberOfObjectives: 2,
+		Bounds: func(i int) (float64, float64) {
+			if i == 0 || i == 3 {
+				return 0.125, 5
+			}
+			return 0.1, 10
+		},
+		Evaluate: func(x []float64) []float64 {
+			h, l, t, b := x[0], x[1], x[2], x[3]
+			return []float64{1.10471*h*h*l + 0.04811*t*b*(14+l), 2.1952 / (t * t * t * b)}
+		},
+		Constraints: func(x []float64) []float64 {
+			h, l, t, b := x[0], x[1], x[2], x[3]
+			r := math.Sqrt(0.25 * (l*l + (h+t)*(h+t)))
+			tau1 := 6000 / (math.Sqrt2 * h * l)
+			tau2 := 6000 * (14 + 0.5*l) * r / (2 * 0.707 * h * l * (l*l/12 + 0.25*(h+t)*(h+t)))
+			tau := math.Sqrt(tau1*tau1 + tau2*tau2 + l*tau1*tau2/r)
+			sigma := 504000 / (t * t * b)
+			buckling := 64746.022 * (1 - 0.0282346*t) * t * b * b * b
+			return []float64{13600 - tau, 30000 - sigma, b - h, buckling - 6000}
+		},
+	}))
+	Register("car-side-impact", fixed(Problem{
+		Name:               "car-side-impact",
+		NumberOfVariables:  7,
+		NumberOfObjectives: 3,
+		Bounds: func(i int) (float64, float64) {
+			bounds := [][2]float64{{0.5, 1.5}, {0.45, 1.35}, {0.5, 1.5}, {0.5, 1.5}, {0.875, 2.625}, {0.4, 1.2}, {0.4, 1.2}}
+			return bounds[i][0], bounds[i][1]
+		},
+		Evaluate: func(x []float64) []float64 {
+			mbp, fd := carSideImpactVelocities(x)
+			return []float64{
+				1.98 + 4.9*x[0] + 6.67*x[1] + 6.98*x[2] + 4.01*x[3] + 1.78*x[4] + 0.00001*x[5] + 2.73*x[6],
+				4.72 - 0.5*x[3] - 0.19*x[1]*x[2],
+				0.5 * (mbp + fd),
+			}
+		},
+		Constraints: func(x []float64) []float64 {
+			mbp, fd := carSideImpactVelocities(x)
+			return []float64{
+				1 - (1.16 - 0.3717*x[1]*x[3] - 0.0092928*x[2]),
+				0.32 - (0.261 - 0.0159*x[0]*x[1] - 0.06486*x[0] - 0.019*x[1]*x[6] + 0.0144*x[2]*x[4] + 0.0154464*x[5]),
+				0.32 - (0.214 + 0.00817*x[4] - 0.045195*x[0] - 0.0135168*x[0] + 0.03099*x[1]*x[5] - 0.018*x[1]*x[6] +
+					0.007176*x[2] + 0.023232*x[2] - 0.00364*x[4]*x[5] - 0.018*x[1]*x[1]),
+				0.32 - (0.74 - 0.61*x[1] - 0.031296*x[2] - 0.031872*x[6] + 0.227*x[1]*x[1]),
+				32 - (28.98 + 3.818*x[2] - 4.2*x[0]*x[1] + 1.27296*x[5] - 2.68065*x[6]),
+				32 - (33.86 + 2.95*x[2] - 5.057*x[0]*x[1] - 3.795*x[1] - 3.4431*x[6] + 1.45728),
+				32 - (46.36 - 9.9*x[1] - 4.4505*x[0]),
+				4 - (4.72 - 0.5*x[3] - 0.19*x[1]*x[2]),
+				9.9 - mbp,
+				15.7 - fd,
+			}
+		},
+	}))
+}
+
+func bnh(x []float64) []float64 {
+	return []float64{4*x[0]*x[0] + 4*x[1]*x[1], (x[0]-5)*(x[0]-5) + (x[1]-5)*(x[1]-5)}
+}
+
+func srn(x []float64) []float64 {
+	return []float64{2 + (x[0]-2)*(x[0]-2) + (x[1]-1)*(x[1]-1), 9*x[0] - (x[1]-1)*(x[1]-1)}
+}
+
+func tnk(x []float64) []float64 {
+	return []float64{
+		x[0]*x[0] + x[1]*x[1] - 1 - 0.1*math.Cos(16*math.Atan2(x[0], x[1])),
+		0.5 - (x[0]-0.5)*(x[0]-0.5) - (x[1]-0.5)*(x[1]-0.5),
+	}
+}
+
+func osy(x []float64) []float64 {
+	return []float64{
+		-(25*(x[0]-2)*(x[0]-2) + (x[1]-2)*(x[1]-2) + (x[2]-1)*(x[2]-1) + (x[3]-4)*(x[3]-4) + (x[4]-1)*(x[4]-1)),
+		x[0]*x[0] + x[1]*x[1] + x[2]*x[2] + x[3]*x[3] + x[4]*x[4] + x[5]*x[5],
+	}
+}
+
+func constr(x []float64) []float64 {
+	return []float64{x[0], (1 + x[1]) / x[0]}
+}
+
+func carSideImpactVelocities(x []float64) (float64, float64) {
+	return 10.58 - 0.674*x[0]*x[1] - 0.67275*x[1], 16.45 - 0.489*x[2]*x[6] - 0.843*x[4]*x[5]
+}
+
+// paretoSetFront evaluates points members of the Pareto set, evenly spaced
+// along set.
+func paretoSetFront(points int, evaluate func([]float64) []float64, set func(t float64) []float64) [][]float64 {
+	front := make([][]float64, points)
+	for i := range front {
+		t := 0.0
+		if points > 1 {
+			t = float64(i) / float64(points-1)
+		}
+		front[i] = evaluate(set(t))
+	}
+	return front
+}
+
+// ctp builds a CTP problem of Deb, Pratap and Meyarivan (2001), whose
+// first variable lies in [0, 1] and the other ones, 9 by default, in [-5,
+// 5], where they make a Rastrigin function g. The constraints, on the
+// objectives, cut the unconstrained front.
+func ctp(name string, objectives func(x []float64, g float64) []float64, constraints func(f []float64) []float64,
+	unconstrained func(f1 float64) float64) Factory {
+	return objectiveConstraints(scalableVariables(Problem{
+		Name:               name,
+		NumberOfVariables:  10,
+		NumberOfObjectives: 2,
+		Bounds: func(i int) (float64, float64) {
+			if i == 0 {
+				return 0, 1
+			}
+			return -5, 5
+		},
+		ReferenceFront: func(points int) [][]float64 { return ctpFront(points, constraints, unconstrained) },
+	}, 2, func(n int) func([]float64) []float64 {
+		return func(x []float64) []float64 {
+			g := 1 + 10*float64(n-1)
+			for _, y := range x[1:] {
+				g += y*y - 10*math.Cos(4*math.Pi*y)
+			}
+			return objectives(x, g)
+		}
+	}), func(int) func([]float64) []float64 { return constraints })
+}
+
+func ctpObjectives(x []float64, g float64) []float64 {
+	return []float64{x[0], g - x[0]}
+}
+
+// ctpConstraint is the constraint of CTP2 to CTP7 with the parameters
+// theta, a, b, c, d and e.
+func ctpConstraint(f []float64, parameters []float64) float64 {
+	theta, a, b, c, d, e := parameters[0], parameters[1], parameters[2], parameters[3], parameters[4], parameters[5]
+	sin, cos := math.Sin(theta), math.Cos(theta)
+	return cos*(f[1]-e) - sin*f[0] - a*math.Pow(math.Abs(math.Sin(b*math.Pi*math.Pow(sin*(f[1]-e)+cos*f[0], c))), d)
+}
+
+// ctpFront finds, for points evenly spaced values of f1, the smallest
+// feasible f2 not below the unconstrained front, and keeps the
+// nondominated ones.
+func ctpFront(points int, constraints func(f []float64) []float64, unconstrained func(f1 float64) float64) [][]float64 {
+	feasible := func(f1, f2 float64) bool { return Violation(constraints([]float64{f1, f2})) == 0 }
+	var front [][]float64
+	for i := 0; i < points; i++ {
+		f1 := float64(i) / float64(points-1)
+		low, high := unconstrained(f1), unconstrained(f1)
+		for ; !feasible(f1, high) && high < low+20; high += 0.001 {
+		}
+		if !feasible(f1, high) {
+			continue
+		}
+		if high > low {
+			for low = high - 0.001; high-low > 1e-12; {
+				if middle := (low + high) / 2; feasible(f1, middle) {
+					high = middle
+				} else {
+					low = middle
+				}
+			}
+		}
+		front = append(front, []float64{f1, high})
+	}
+	return indicator.Nondominated(front)
+}
+
+// objectiveConstraints adds to the problems of factory constraints on
+// their m objectives.
+func objectiveConstraints(factory Factory, constraints func(m int) func(f []float64) []float64) Factory {
+	return func(n, m int) (*Problem, error) {
+		p, err := factory(n, m)
+		if err != nil {
+			return nil, err
+		}
+		evaluate, c := p.Evaluate, constraints(p.NumberOfObjectives)
+		p.Constraints = func(x []float64) []float64 { return c(evaluate(x)) }
+		return p, nil
+	}
+}
+
+// c2DTLZ2 keeps the front of DTLZ2 within a radius of its extreme points
+// and of its centre (Jain and Deb, 2014).
+func c2DTLZ2(f []float64) []float64 {
+	m := len(f)
+	r := 0.5
+	if m == 2 {
+		r = 0.2
+	} else if m == 3 {
+		r = 0.4
+	}
+	squares := 0.0
+	for _, y := range f {
+		squares += y * y
+	}
+	closest := math.Inf(1)
+	centre := 0.0
+	for _, y := range f {
+		closest = math.Min(closest, squares-y*y+(y-1)*(y-1)-r*r)
+		centre += (y - 1/math.Sqrt(float64(m))) * (y - 1/math.Sqrt(float64(m)))
+	}
+	return []float64{-math.Min(closest, centre-r*r)}
+}
+
+func c3DTLZ1(f []float64) []float64 {
+	sum := 0.0
+	for _, y := range f {
+		sum += y
+	}
+	result := make([]float64, len(f))
+	for j, y := range f {
+		result[j] = sum + y - 1
+	}
+	return result
+}
+
+func c3DTLZ4(f []float64) []float64 {
+	squares := 0.0
+	for _, y := range f {
+		squares += y * y
+	}
+	result := make([]float64, len(f))
+	for j, y := range f {
+		result[j] = squares - 0.75*y*y - 1
+	}
+	return result
+}
+
+// rayFront follows the directions of reference.Uniform from the origin to
+// the first point where the constraints, which hold far enough along every
+// direction, are satisfied, and keeps the nondominated ones. The DTLZ
+// objectives reach every point of the orthant far enough along them.
+func rayFront(points, m int, constraints func(f []float64) []float64) [][]float64 {
+	var front [][]float64
+	f := make([]float64, m)
+	at := func(direction []float64, t float64) []float64 {
+		for j := range f {
+			f[j] = t * direction[j]
+		}
+		return f
+	}
+	for _, direction := range SphericalFront(points, m) {
+		low, high := 0.0, 1.0
+		for Violation(constraints(at(direction, high))) < 0 {
+			low, high = high, 2*high
+		}
+		for high-low > 1e-12 {
+			if middle := (low + high) / 2; Violation(constraints(at(direction, middle))) < 0 {
+				low = middle
+			} else {
+				high = middle
+			}
+		}
+		front = append(front, append([]float64(nil), at(direction, high)...))
+	}
+	return indicator.Nondominated(front)
+}
+
+func sphereNorm(f []float64) float64 {
+	sum := 0.0
+	for _, y := range f {
+		sum += y * y
+	}
+	return math.Sqrt(sum)
+}
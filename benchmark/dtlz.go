@@ -0,0 +1,145 @@
+package benchmark
+
+import (
+	"fmt"
+	"math"
+)
+
+// The DTLZ problems of Deb, Thiele, Laumanns and Zitzler (2005) scale to
+// any number m of objectives. Their last k = n-m+1 variables set the
+// distance to the front, which is reached with them at 0.5, or at 0 for
+// DTLZ6 and DTLZ7. k defaults to 5 for DTLZ1, 20 for DTLZ7 and 10 for the
+// others.
+func init() {
+	Register("dtlz1", dtlz("dtlz1", 5, dtlz1, linearFront))
+	Register("dtlz2", dtlz("dtlz2", 10, dtlz2, sphericalFront))
+	Register("dtlz3", dtlz("dtlz3", 10, dtlz3, sphericalFront))
+	Register("dtlz4", dtlz("dtlz4", 10, dtlz4, sphericalFront))
+	Register("dtlz5", dtlz("dtlz5", 10, func(m int) func([]float64) []float64 {
+		return func(x []float64) []float64 { return dtlzDegenerate(x, m, dtlzSphere(x[m-1:])) }
+	}, degenerateFront))
+	Register("dtlz6", dtlz("dtlz6", 10, func(m int) func([]float64) []float64 {
+		return func(x []float64) []float64 {
+			g := 0.0
+			for _, y := range x[m-1:] {
+				g += math.Pow(y, 0.1)
+			}
+			return dtlzDegenerate(x, m, g)
+		}
+	}, degenerateFront))
+	Register("dtlz7", dtlz("dtlz7", 20, func(m int) func([]float64) []float64 {
+		return func(x []float64) []float64 {
+			g := 0.0
+			for _, y := range x[m-1:] {
+				g += y
+			}
+			g = 1 + 9*g/float64(len(x)-m+1)
+			return dtlz7(x[:m-1], g)
+		}
+	}, func(m int) func(int) [][]float64 {
+		// The front is disconnected in 2^(m-1) regions.
+		return func(points int) [][]float64 {
+			return sampleFront(points, m-1, func(x []float64) []float64 { return dtlz7(x, 1) })
+		}
+	}))
+}
+
+func dtlz(name string, k int, evaluate func(m int) func([]float64) []float64,
+	front func(m int) func(int) [][]float64) Factory {
+	return scalable(Problem{Name: name, NumberOfObjectives: 3, Bounds: constantBounds(0, 1)},
+		func(m int) int { return m + k - 1 },
+		func(n, m int) error {
+			if n < m {
+				return fmt.Errorf("%s needs at least %d variables for %d objectives", name, m, m)
+			}
+			return nil
+		},
+		func(n, m int) func([]float64) []float64 { return evaluate(m) }, front)
+}
+
+func dtlz1(m int) func([]float64) []float64 {
+	return func(x []float64) []float64 {
+		g := dtlzRastrigin(x[m-1:])
+		f := make([]float64, m)
+		for i := range f {
+			f[i] = 0.5 * (1 + g)
+			for j := 0; j < m-1-i; j++ {
+				f[i] *= x[j]
+			}
+			if i > 0 {
+				f[i] *= 1 - x[m-1-i]
+			}
+		}
+		return f
+	}
+}
+
+func dtlz2(m int) func([]float64) []float64 {
+	return func(x []float64) []float64 { return dtlzSpherical(x, m, 1, dtlzSphere(x[m-1:])) }
+}
+
+func dtlz3(m int) func([]float64) []float64 {
+	return func(x []float64) []float64 { return dtlzSpherical(x, m, 1, dtlzRastrigin(x[m-1:])) }
+}
+
+func dtlz4(m int) func([]float64) []float64 {
+	return func(x []float64) []float64 { return dtlzSpherical(x, m, 100, dtlzSphere(x[m-1:])) }
+}
+
+func dtlzSphere(x []float64) float64 {
+	g := 0.0
+	for _, y := range x {
+		g += (y - 0.5) * (y - 0.5)
+	}
+	return g
+}
+
+func dtlzRastrigin(x []float64) float64 {
+	g := float64(len(x))
+	for _, y := range x {
+		g += (y-0.5)*(y-0.5) - math.Cos(20*math.Pi*(y-0.5))
+	}
+	return 100 * g
+}
+
+// dtlzSpherical maps the first m-1 variables, raised to alpha, to the
+// sphere of radius 1+g.
+func dtlzSpherical(x []float64, m int, alpha, g float64) []float64 {
+	theta := make([]float64, m-1)
+	for j := range theta {
+		theta[j] = math.Pow(x[j], alpha) * math.Pi / 2
+	}
+	return spherical(theta, 1+g)
+}
+
+// dtlzDegenerate maps the first m-1 variables to the sphere of radius
+// 1+g, with all but the first angle squeezed towards π/4 as g decreases.
+func dtlzDegenerate(x []float64, m int, g float64) []float64 {
+	theta := make([]float64, m-1)
+	theta[0] = x[0] * math.Pi / 2
+	for j := 1; j < m-1; j++ {
+		theta[j] = math.Pi / (4 * (1 + g)) * (1 + 2*g*x[j])
+	}
+	return spherical(theta, 1+g)
+}
+
+func dtlz7(x []float64, g float64) []float64 {
+	f := append(make([]float64, 0, len(x)+1), x...)
+	h := float64(len(x) + 1)
+	for _, y := range x {
+		h -= y / (1 + g) * (1 + math.Sin(3*math.Pi*y))
+	}
+	return append(f, (1+g)*h)
+}
+
+func linearFront(m int) func(int) [][]float64 {
+	return func(points int) [][]float64 { return LinearFront(points, m) }
+}
+
+func sphericalFront(m int) func(int) [][]float64 {
+	return func(points int) [][]float64 { return SphericalFront(points, m) }
+}
+
+func degenerateFront(m int) func(int) [][]float64 {
+	return func(points int) [][]float64 { return DegenerateFront(points, m) }
+}
@@ -0,0 +1,95 @@
+package benchmark
+
+import (
+	"math"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/indicator"
+	"github.com/project-draco/moea/reference"
+)
+
+// LinearFront returns points members of the front of DTLZ1, the simplex
+// of the objectives summing to 0.5, spread as reference.Uniform.
+func LinearFront(points, m int) [][]float64 {
+	result := reference.Uniform(points, m)
+	for _, p := range result {
+		for j := range p {
+			p[j] /= 2
+		}
+	}
+	return result
+}
+
+// SphericalFront returns points members of the front of DTLZ2 to DTLZ4,
+// the unit sphere in the positive orthant: the points of
+// reference.Uniform projected onto it.
+func SphericalFront(points, m int) [][]float64 {
+	result := reference.Uniform(points, m)
+	for _, p := range result {
+		norm := 0.0
+		for _, x := range p {
+			norm += x * x
+		}
+		for j := range p {
+			p[j] /= math.Sqrt(norm)
+		}
+	}
+	return result
+}
+
+// DegenerateFront returns points members, evenly spaced in angle, of the
+// front of DTLZ5 and DTLZ6, the quarter of a circle where all but the
+// first angle are π/4. With more than three objectives the actual fronts
+// of the problems also have parts off that curve.
+func DegenerateFront(points, m int) [][]float64 {
+	result := make([][]float64, points)
+	theta := make([]float64, m-1)
+	for i := range result {
+		theta[0] = math.Pi / 2
+		if points > 1 {
+			theta[0] *= float64(i) / float64(points-1)
+		}
+		for j := 1; j < m-1; j++ {
+			theta[j] = math.Pi / 4
+		}
+		result[i] = spherical(theta, 1)
+	}
+	return result
+}
+
+// sampleFront maps points position vectors, evenly spaced with one
+// position and at random otherwise, through shape and keeps the
+// nondominated images.
+func sampleFront(points, positions int, shape func(x []float64) []float64) [][]float64 {
+	rng := moea.NewXorshiftWithSeed(1)
+	result := make([][]float64, points)
+	x := make([]float64, positions)
+	for i := range result {
+		for j := range x {
+			if positions == 1 && points > 1 {
+				x[j] = float64(i) / float64(points-1)
+			} else {
+				x[j] = rng.Float64()
+			}
+		}
+		result[i] = shape(x)
+	}
+	return indicator.Nondominated(result)
+}
+
+// spherical returns the point at the given angles of the sphere of the
+// given radius, with one more coordinate than angles.
+func spherical(theta []float64, radius float64) []float64 {
+	m := len(theta) + 1
+	result := make([]float64, m)
+	for i := range result {
+		result[i] = radius
+		for j := 0; j < m-1-i; j++ {
+			result[i] *= math.Cos(theta[j])
+		}
+		if i > 0 {
+			result[i] *= math.Sin(theta[m-1-i])
+		}
+	}
+	return result
+}
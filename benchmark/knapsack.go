@@ -0,0 +1,197 @@
+package benchmark
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/binary"
+)
+
+// Knapsack is a multi-objective 0/1 knapsack instance of Zitzler and
+// Thiele (1999): each item, with a weight and a profit in each knapsack,
+// goes into all the knapsacks or none, and the profit in every knapsack
+// is maximized within its capacity.
+type Knapsack struct {
+	Capacities []float64
+	// Weights and Profits hold the items of each knapsack.
+	Weights, Profits [][]float64
+	once             sync.Once
+	order            []int
+}
+
+// NewKnapsack generates an instance like those of Zitzler and Thiele:
+// weights and profits are random integers in [10, 100] and the capacity
+// of each knapsack is half the weight of its items.
+func NewKnapsack(items, knapsacks int, rng moea.RNG) *Knapsack {
+	if items < 1 || knapsacks < 1 {
+		panic(fmt.Sprintf("Invalid knapsack instance size %d, %d", items, knapsacks))
+	}
+	k := &Knapsack{
+		Capacities: make([]float64, knapsacks),
+		Weights:    make([][]float64, knapsacks),
+		Profits:    make([][]float64, knapsacks),
+	}
+	for i := range k.Capacities {
+		k.Weights[i], k.Profits[i] = make([]float64, items), make([]float64, items)
+		for j := 0; j < items; j++ {
+			k.Weights[i][j] = float64(10 + rng.Intn(91))
+			k.Profits[i][j] = float64(10 + rng.Intn(91))
+			k.Capacities[i] += k.Weights[i][j] / 2
+		}
+	}
+	return k
+}
+
+// ReadKnapsack reads an instance in the format of the knapsack.*.*.txt
+// files of Zitzler, where the capacity, then the weight and the profit of
+// every item, follow the heading of each knapsack:
+//
+//	knapsack problem specification (2 knapsacks, 100 items)
+//	=
+//	knapsack 1:
+//	 capacity: +2732
+//	 item 1:
+//	  weight: +94
+//	  profit: +57
+//	 ...
+func ReadKnapsack(r io.Reader) (*Knapsack, error) {
+	k := &Knapsack{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] == "=" {
+			continue
+		}
+		if fields[0] == "knapsack" {
+			if len(fields) > 1 && fields[1] == "problem" {
+				continue
+			}
+			k.Capacities = append(k.Capacities, 0)
+			k.Weights, k.Profits = append(k.Weights, nil), append(k.Profits, nil)
+			continue
+		}
+		if fields[0] == "item" {
+			continue
+		}
+		last := len(k.Capacities) - 1
+		if last < 0 || len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: unexpected %q", line, scanner.Text())
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		switch fields[0] {
+		case "capacity:":
+			k.Capacities[last] = value
+		case "weight:":
+			k.Weights[last] = append(k.Weights[last], value)
+		case "profit:":
+			k.Profits[last] = append(k.Profits[last], value)
+		default:
+			return nil, fmt.Errorf("line %d: unexpected %q", line, scanner.Text())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(k.Capacities) == 0 {
+		return nil, fmt.Errorf("no knapsacks")
+	}
+	for i := range k.Capacities {
+		if len(k.Weights[i]) != len(k.Weights[0]) || len(k.Profits[i]) != len(k.Weights[0]) {
+			return nil, fmt.Errorf("knapsack %d has not as many weights and profits as items", i+1)
+		}
+	}
+	return k, nil
+}
+
+func (k *Knapsack) Items() int { return len(k.Weights[0]) }
+
+// Evaluate returns the profits in each knapsack of the selected items,
+// negated, whether they fit or not.
+func (k *Knapsack) Evaluate(selected []bool) []float64 {
+	result := make([]float64, len(k.Capacities))
+	for i := range result {
+		for j, s := range selected {
+			if s {
+				result[i] -= k.Profits[i][j]
+			}
+		}
+	}
+	return result
+}
+
+// Repair is the greedy repair of Zitzler and Thiele: it removes from
+// selected the items in increasing order of their largest profit to
+// weight ratio until they fit in every knapsack. It returns the items
+// removed.
+func (k *Knapsack) Repair(selected []bool) []int {
+	k.once.Do(func() {
+		ratios := make([]float64, k.Items())
+		for j := range ratios {
+			for i := range k.Capacities {
+				ratios[j] = math.Max(ratios[j], k.Profits[i][j]/k.Weights[i][j])
+			}
+		}
+		k.order = make([]int, len(ratios))
+		for j := range k.order {
+			k.order[j] = j
+		}
+		sort.SliceStable(k.order, func(a, b int) bool { return ratios[k.order[a]] < ratios[k.order[b]] })
+	})
+	weights := make([]float64, len(k.Capacities))
+	for i := range weights {
+		for j, s := range selected {
+			if s {
+				weights[i] += k.Weights[i][j]
+			}
+		}
+	}
+	fits := func() bool {
+		for i, w := range weights {
+			if w > k.Capacities[i] {
+				return false
+			}
+		}
+		return true
+	}
+	var removed []int
+	for _, j := range k.order {
+		if fits() {
+			break
+		}
+		if selected[j] {
+			selected[j] = false
+			removed = append(removed, j)
+			for i := range weights {
+				weights[i] -= k.Weights[i][j]
+			}
+		}
+	}
+	return removed
+}
+
+// ObjectiveFunc evaluates the individuals of binary populations of one
+// variable with a bit per item, set for the selected items. Individuals
+// that do not fit are repaired in place first.
+func (k *Knapsack) ObjectiveFunc() moea.ObjectiveFunc {
+	return func(individual moea.Individual) []float64 {
+		bits := individual.Value(0).(binary.BinaryString)
+		selected := make([]bool, bits.Len())
+		for j := range selected {
+			selected[j] = bits.Test(j)
+		}
+		if removed := k.Repair(selected); len(removed) > 0 {
+			individual.Mutate(removed)
+		}
+		return k.Evaluate(selected)
+	}
+}
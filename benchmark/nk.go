@@ -0,0 +1,180 @@
+package benchmark
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/binary"
+)
+
+// NK is a ρMNK-landscape of Verel, Liefooghe, Jourdan and Dhaenens (2013):
+// m NK-landscapes over n bits, where the fitness in each is the mean of
+// the contributions of the bits, every one depending on its value and on
+// those of k other bits, its links. The contributions of a bit to the
+// different objectives are correlated by ρ.
+type NK struct {
+	// Links holds, for each objective and bit, the k+1 bits its
+	// contribution depends on.
+	Links [][][]int
+	// Contributions holds, for each objective and bit, its contribution for
+	// each of the 2^(k+1) values of its links, the first link being the
+	// most significant bit.
+	Contributions [][][]float64
+}
+
+// NewNK generates a ρMNK-landscape, with the bit itself and k other ones
+// at random as links, the same in all the objectives, and contributions
+// uniform in [0, 1) with a correlation ρ, at least -1/(m-1), between
+// objectives. With a single objective it is a plain NK-landscape.
+func NewNK(n, k, m int, rho float64, rng moea.RNG) *NK {
+	if k < 0 || k >= n || m < 1 || rho > 1 || m > 1 && rho < -1/float64(m-1) {
+		panic(fmt.Sprintf("Invalid ρMNK-landscape %d, %d, %d, %v", n, k, m, rho))
+	}
+	links := make([][]int, n)
+	for i := range links {
+		links[i] = []int{i}
+		for _, j := range rng.Perm(n - 1)[:k] {
+			if j >= i {
+				j++
+			}
+			links[i] = append(links[i], j)
+		}
+	}
+	cholesky := equicorrelation(m, rho)
+	l := &NK{make([][][]int, m), make([][][]float64, m)}
+	for o := range l.Links {
+		l.Links[o] = links
+		l.Contributions[o] = matrix(make([]float64, n<<uint(k+1)), 1<<uint(k+1))
+	}
+	z, correlated := make([]float64, m), make([]float64, m)
+	for i := 0; i < n; i++ {
+		for v := 0; v < 1<<uint(k+1); v++ {
+			for o := range z {
+				z[o] = rng.NormFloat64()
+			}
+			for o := range correlated {
+				correlated[o] = 0
+				for p := 0; p <= o; p++ {
+					correlated[o] += cholesky[o][p] * z[p]
+				}
+				// The normal cumulative distribution makes it uniform.
+				l.Contributions[o][i][v] = 0.5 * math.Erfc(-correlated[o]/math.Sqrt2)
+			}
+		}
+	}
+	return l
+}
+
+// equicorrelation returns the lower triangular Cholesky factor of the m by
+// m matrix of ones on the diagonal and rho elsewhere.
+func equicorrelation(m int, rho float64) [][]float64 {
+	result := matrix(make([]float64, m*m), m)
+	for i := range result {
+		for j := 0; j <= i; j++ {
+			sum := rho
+			if i == j {
+				sum = 1
+			}
+			for p := 0; p < j; p++ {
+				sum -= result[i][p] * result[j][p]
+			}
+			if i == j {
+				result[i][j] = math.Sqrt(math.Max(0, sum))
+			} else if result[j][j] > 0 {
+				result[i][j] = sum / result[j][j]
+			}
+		}
+	}
+	return result
+}
+
+// ReadNK reads an instance in the format of the ρMNK-landscape generator
+// of Verel et al.: after the comment lines, starting with c, the line
+// "p rMNK ρ m n k", then "p links" followed, for each objective, by the
+// links of every bit, and "p tables" followed, for each objective, by the
+// contributions of every bit.
+func ReadNK(r io.Reader) (*NK, error) {
+	words, err := words(r, "c")
+	if err != nil {
+		return nil, err
+	}
+	if len(words) < 6 || words[0] != "p" || words[1] != "rMNK" {
+		return nil, fmt.Errorf("missing the p rMNK line")
+	}
+	sizes, err := numbers(words[3:], 3)
+	if err != nil {
+		return nil, err
+	}
+	m, n, k := int(sizes[0]), int(sizes[1]), int(sizes[2])
+	if m < 1 || n < 1 || k < 0 || k >= n {
+		return nil, fmt.Errorf("invalid sizes %v", words[3:6])
+	}
+	section := func(words []string, name string, count int) ([]float64, []string, error) {
+		if len(words) < 2 || words[0] != "p" || words[1] != name {
+			return nil, nil, fmt.Errorf("missing the p %s line", name)
+		}
+		values, err := numbers(words[2:], count)
+		if err != nil {
+			return nil, nil, err
+		}
+		return values, words[2+count:], nil
+	}
+	links, words, err := section(words[6:], "links", m*n*(k+1))
+	if err != nil {
+		return nil, err
+	}
+	tables, _, err := section(words, "tables", m*n<<uint(k+1))
+	if err != nil {
+		return nil, err
+	}
+	l := &NK{make([][][]int, m), make([][][]float64, m)}
+	for o := range l.Links {
+		l.Links[o] = make([][]int, n)
+		for i := range l.Links[o] {
+			l.Links[o][i] = make([]int, k+1)
+			for j := range l.Links[o][i] {
+				link := links[(o*n+i)*(k+1)+j]
+				if link < 0 || link >= float64(n) || link != math.Trunc(link) {
+					return nil, fmt.Errorf("invalid link %v", link)
+				}
+				l.Links[o][i][j] = int(link)
+			}
+		}
+		l.Contributions[o] = matrix(tables[o*n<<uint(k+1):(o+1)*n<<uint(k+1)], 1<<uint(k+1))
+	}
+	return l, nil
+}
+
+// Evaluate returns the fitness of bits in each objective, negated.
+func (l *NK) Evaluate(bits []bool) []float64 {
+	result := make([]float64, len(l.Links))
+	for o, links := range l.Links {
+		for i, bitLinks := range links {
+			v := 0
+			for _, j := range bitLinks {
+				v <<= 1
+				if bits[j] {
+					v |= 1
+				}
+			}
+			result[o] -= l.Contributions[o][i][v]
+		}
+		result[o] /= float64(len(links))
+	}
+	return result
+}
+
+// ObjectiveFunc evaluates the individuals of binary populations of one
+// variable of n bits.
+func (l *NK) ObjectiveFunc() moea.ObjectiveFunc {
+	return func(individual moea.Individual) []float64 {
+		bits := individual.Value(0).(binary.BinaryString)
+		values := make([]bool, bits.Len())
+		for i := range values {
+			values[i] = bits.Test(i)
+		}
+		return l.Evaluate(values)
+	}
+}
@@ -0,0 +1,93 @@
+package benchmark
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/permutation"
+)
+
+// QAP is a multi-objective quadratic assignment instance of Knowles and
+// Corne (2003): n facilities go to n locations, and objective k is the sum
+// over the pairs of facilities of their flow in Flows[k] times the
+// distance between their locations.
+type QAP struct {
+	Distances [][]float64
+	Flows     [][][]float64
+}
+
+// NewQAP generates an instance whose distances and flows are random
+// integers in [1, 99], symmetric and 0 on the diagonal.
+func NewQAP(n, objectives int, rng moea.RNG) *QAP {
+	if n < 2 || objectives < 1 {
+		panic(fmt.Sprintf("Invalid QAP instance size %d, %d", n, objectives))
+	}
+	random := func() [][]float64 {
+		result := matrix(make([]float64, n*n), n)
+		for i := range result {
+			for j := i + 1; j < n; j++ {
+				result[i][j] = float64(1 + rng.Intn(99))
+				result[j][i] = result[i][j]
+			}
+		}
+		return result
+	}
+	q := &QAP{Distances: random(), Flows: make([][][]float64, objectives)}
+	for k := range q.Flows {
+		q.Flows[k] = random()
+	}
+	return q
+}
+
+// ReadQAP reads an instance in the format of the mQAP instances of
+// Knowles and Corne: n, then the distance matrix and a flow matrix per
+// objective, the lines starting with # being comments.
+func ReadQAP(r io.Reader) (*QAP, error) {
+	words, err := words(r, "#")
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("empty instance")
+	}
+	size, err := numbers(words, 1)
+	if err != nil {
+		return nil, err
+	}
+	n := int(size[0])
+	if n < 1 || float64(n) != size[0] || (len(words)-1)%(n*n) != 0 || len(words)-1 < 2*n*n {
+		return nil, fmt.Errorf("want %d by %d matrices, got %d numbers", n, n, len(words)-1)
+	}
+	values, err := numbers(words[1:], len(words)-1)
+	if err != nil {
+		return nil, err
+	}
+	q := &QAP{Distances: matrix(values[:n*n], n)}
+	for values = values[n*n:]; len(values) > 0; values = values[n*n:] {
+		q.Flows = append(q.Flows, matrix(values[:n*n], n))
+	}
+	return q, nil
+}
+
+// Evaluate returns the cost in each objective of assigning facility i to
+// location assignment[i].
+func (q *QAP) Evaluate(assignment []int) []float64 {
+	result := make([]float64, len(q.Flows))
+	for k, flows := range q.Flows {
+		for i, a := range assignment {
+			for j, b := range assignment {
+				result[k] += flows[i][j] * q.Distances[a][b]
+			}
+		}
+	}
+	return result
+}
+
+// ObjectiveFunc evaluates the individuals of permutation populations as
+// assignments.
+func (q *QAP) ObjectiveFunc() moea.ObjectiveFunc {
+	return func(individual moea.Individual) []float64 {
+		return q.Evaluate(permutation.Permutation(individual))
+	}
+}
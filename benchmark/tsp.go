@@ -0,0 +1,171 @@
+package benchmark
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/permutation"
+)
+
+// TSP is a multi-objective travelling salesman instance, with a matrix of
+// distances between the cities per objective, such as kroAB100, made of
+// kroA100 and kroB100 of TSPLIB.
+type TSP struct{ Distances [][][]float64 }
+
+// NewTSP generates an instance like the kro ones, whose distances in each
+// objective are the rounded Euclidean distances between cities at random
+// in a square of side 4000.
+func NewTSP(cities, objectives int, rng moea.RNG) *TSP {
+	if cities < 2 || objectives < 1 {
+		panic(fmt.Sprintf("Invalid TSP instance size %d, %d", cities, objectives))
+	}
+	t := &TSP{make([][][]float64, objectives)}
+	for k := range t.Distances {
+		x, y := make([]float64, cities), make([]float64, cities)
+		for i := range x {
+			x[i], y[i] = float64(rng.Intn(4000)), float64(rng.Intn(4000))
+		}
+		t.Distances[k] = coordinateDistances(x, y, euclidean)
+	}
+	return t
+}
+
+// ReadTSPLIB reads the distances of a symmetric TSPLIB instance, given by
+// coordinates with the EUC_2D, CEIL_2D or ATT distances or explicitly, as
+// a FULL_MATRIX or as the UPPER_ROW, LOWER_ROW, UPPER_DIAG_ROW or
+// LOWER_DIAG_ROW triangle.
+func ReadTSPLIB(r io.Reader) ([][]float64, error) {
+	scanner := bufio.NewScanner(r)
+	header := map[string]string{}
+	section := ""
+	for section == "" && scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, ":"); i >= 0 {
+			header[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+1:])
+		} else if line == "NODE_COORD_SECTION" || line == "EDGE_WEIGHT_SECTION" {
+			section = line
+		} else if line != "" {
+			return nil, fmt.Errorf("unexpected %q", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(header["DIMENSION"])
+	if err != nil || n < 2 {
+		return nil, fmt.Errorf("invalid dimension %q", header["DIMENSION"])
+	}
+	var rest []string
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 && (fields[0] == "EOF" || strings.HasSuffix(fields[0], "_SECTION")) {
+			break
+		}
+		rest = append(rest, fields...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	distanceType := header["EDGE_WEIGHT_TYPE"]
+	if section == "NODE_COORD_SECTION" {
+		distance, ok := map[string]func(dx, dy float64) float64{
+			"EUC_2D": euclidean, "CEIL_2D": ceiling, "ATT": pseudoEuclidean,
+		}[distanceType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported edge weight type %q", distanceType)
+		}
+		values, err := numbers(rest, 3*n)
+		if err != nil {
+			return nil, err
+		}
+		x, y := make([]float64, n), make([]float64, n)
+		for i, node := range matrix(values, 3) {
+			x[i], y[i] = node[1], node[2]
+		}
+		return coordinateDistances(x, y, distance), nil
+	}
+	if distanceType != "EXPLICIT" {
+		return nil, fmt.Errorf("unsupported edge weight type %q", distanceType)
+	}
+	// in tells whether the entry of row i and column j is given by the
+	// format, all of them being given row by row.
+	in, ok := map[string]func(i, j int) bool{
+		"FULL_MATRIX":    func(i, j int) bool { return true },
+		"UPPER_ROW":      func(i, j int) bool { return j > i },
+		"LOWER_ROW":      func(i, j int) bool { return j < i },
+		"UPPER_DIAG_ROW": func(i, j int) bool { return j >= i },
+		"LOWER_DIAG_ROW": func(i, j int) bool { return j <= i },
+	}[header["EDGE_WEIGHT_FORMAT"]]
+	if !ok {
+		return nil, fmt.Errorf("unsupported edge weight format %q", header["EDGE_WEIGHT_FORMAT"])
+	}
+	count := 0
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if in(i, j) {
+				count++
+			}
+		}
+	}
+	values, err := numbers(rest, count)
+	if err != nil {
+		return nil, err
+	}
+	result := matrix(make([]float64, n*n), n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if in(i, j) {
+				result[i][j], result[j][i] = values[0], values[0]
+				values = values[1:]
+			}
+		}
+	}
+	return result, nil
+}
+
+func coordinateDistances(x, y []float64, distance func(dx, dy float64) float64) [][]float64 {
+	result := matrix(make([]float64, len(x)*len(x)), len(x))
+	for i := range result {
+		for j := range result[i] {
+			result[i][j] = distance(x[i]-x[j], y[i]-y[j])
+		}
+	}
+	return result
+}
+
+func euclidean(dx, dy float64) float64 { return math.Floor(math.Sqrt(dx*dx+dy*dy) + 0.5) }
+
+func ceiling(dx, dy float64) float64 { return math.Ceil(math.Sqrt(dx*dx + dy*dy)) }
+
+func pseudoEuclidean(dx, dy float64) float64 {
+	r := math.Sqrt((dx*dx + dy*dy) / 10)
+	if t := math.Floor(r + 0.5); t >= r {
+		return t
+	}
+	return math.Floor(r+0.5) + 1
+}
+
+// Evaluate returns the length of tour, back to its first city, in each
+// objective.
+func (t *TSP) Evaluate(tour []int) []float64 {
+	result := make([]float64, len(t.Distances))
+	for k, distances := range t.Distances {
+		for i, city := range tour {
+			result[k] += distances[city][tour[(i+1)%len(tour)]]
+		}
+	}
+	return result
+}
+
+// ObjectiveFunc evaluates the individuals of permutation populations as
+// tours.
+func (t *TSP) ObjectiveFunc() moea.ObjectiveFunc {
+	return func(individual moea.Individual) []float64 {
+		return t.Evaluate(permutation.Permutation(individual))
+	}
+}
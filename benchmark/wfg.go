@@ -0,0 +1,323 @@
+package benchmark
+
+import (
+	"fmt"
+	"math"
+)
+
+// The WFG problems of Huband, Hingston, Barone and While (2006) scale to
+// any number m of objectives. Their first k = 2(m-1) variables set the
+// position on the front and the other l, 20 by default, the distance to
+// it; variable i lies in [0, 2(i+1)]. l must be even for WFG2 and WFG3.
+// Objective i of the front is scaled by 2(i+1).
+func init() {
+	Register("wfg1", wfg{name: "wfg1", transform: func(y []float64, k, m int) []float64 {
+		for i := k; i < len(y); i++ {
+			y[i] = bFlat(sLinear(y[i], 0.35), 0.8, 0.75, 0.85)
+		}
+		for i := range y {
+			y[i] = bPoly(y[i], 0.02)
+		}
+		w := make([]float64, len(y))
+		for i := range w {
+			w[i] = 2 * float64(i+1)
+		}
+		return wfgReduce(y, w, k, m, rSum)
+	}, shape: func(x []float64, m int) []float64 {
+		return wfgShape(x, m, convex, mixed(x[0], 5, 1))
+	}}.factory())
+	Register("wfg2", wfg{name: "wfg2", pairs: true, transform: wfgPairs, shape: func(x []float64, m int) []float64 {
+		return wfgShape(x, m, convex, disc(x[0], 5, 1, 1))
+	}}.factory())
+	Register("wfg3", wfg{name: "wfg3", pairs: true, degenerate: true, transform: wfgPairs,
+		shape: func(x []float64, m int) []float64 { return wfgShape(x, m, linear, 1-x[0]) }}.factory())
+	Register("wfg4", wfg{name: "wfg4", transform: func(y []float64, k, m int) []float64 {
+		for i := range y {
+			y[i] = sMulti(y[i], 30, 10, 0.35)
+		}
+		return wfgReduce(y, nil, k, m, rSum)
+	}}.factory())
+	Register("wfg5", wfg{name: "wfg5", transform: func(y []float64, k, m int) []float64 {
+		for i := range y {
+			y[i] = sDeceptive(y[i], 0.35, 0.001, 0.05)
+		}
+		return wfgReduce(y, nil, k, m, rSum)
+	}}.factory())
+	Register("wfg6", wfg{name: "wfg6", transform: func(y []float64, k, m int) []float64 {
+		for i := k; i < len(y); i++ {
+			y[i] = sLinear(y[i], 0.35)
+		}
+		return wfgReduce(y, nil, k, m, rNonsep)
+	}}.factory())
+	Register("wfg7", wfg{name: "wfg7", transform: func(y []float64, k, m int) []float64 {
+		z := append([]float64(nil), y...)
+		for i := 0; i < k; i++ {
+			y[i] = bParam(z[i], rSum(z[i+1:], nil), 0.98/49.98, 0.02, 50)
+		}
+		for i := k; i < len(y); i++ {
+			y[i] = sLinear(y[i], 0.35)
+		}
+		return wfgReduce(y, nil, k, m, rSum)
+	}}.factory())
+	Register("wfg8", wfg{name: "wfg8", transform: func(y []float64, k, m int) []float64 {
+		z := append([]float64(nil), y...)
+		for i := k; i < len(y); i++ {
+			y[i] = sLinear(bParam(z[i], rSum(z[:i], nil), 0.98/49.98, 0.02, 50), 0.35)
+		}
+		return wfgReduce(y, nil, k, m, rSum)
+	}}.factory())
+	Register("wfg9", wfg{name: "wfg9", transform: func(y []float64, k, m int) []float64 {
+		z := append([]float64(nil), y...)
+		for i := 0; i < len(y)-1; i++ {
+			y[i] = bParam(z[i], rSum(z[i+1:], nil), 0.98/49.98, 0.02, 50)
+		}
+		for i := range y {
+			if i < k {
+				y[i] = sDeceptive(y[i], 0.35, 0.001, 0.05)
+			} else {
+				y[i] = sMulti(y[i], 30, 95, 0.35)
+			}
+		}
+		return wfgReduce(y, nil, k, m, rNonsep)
+	}}.factory())
+}
+
+// wfg is a WFG problem. Its transformations turn the normalized variables
+// into m values, the last one the distance to the front, and its shape
+// maps m-1 positions in [0, 1] to the front; the shape is concave, the
+// front being the sphere scaled by 2(i+1) in objective i, when nil.
+type wfg struct {
+	name string
+	// pairs tells that the distance variables are reduced in pairs, so
+	// that there must be an even number of them.
+	pairs bool
+	// degenerate fronts vary in the first position only.
+	degenerate bool
+	transform  func(y []float64, k, m int) []float64
+	shape      func(x []float64, m int) []float64
+}
+
+func (p wfg) factory() Factory {
+	return scalable(Problem{Name: p.name, NumberOfObjectives: 3,
+		Bounds: func(i int) (float64, float64) { return 0, 2 * float64(i+1) }},
+		func(m int) int { return 2*(m-1) + 20 },
+		func(n, m int) error {
+			if k := 2 * (m - 1); n <= k || p.pairs && (n-k)%2 != 0 {
+				return fmt.Errorf("%s needs more than %d variables for %d objectives", p.name, k, m)
+			}
+			return nil
+		}, p.evaluate, p.front)
+}
+
+func (p wfg) evaluate(n, m int) func([]float64) []float64 {
+	k := 2 * (m - 1)
+	return func(x []float64) []float64 {
+		y := make([]float64, n)
+		for i := range y {
+			y[i] = x[i] / (2 * float64(i+1))
+		}
+		t := p.transform(y, k, m)
+		positions := make([]float64, m-1)
+		for i := range positions {
+			a := 1.0
+			if p.degenerate && i > 0 {
+				a = 0
+			}
+			positions[i] = math.Max(t[m-1], a)*(t[i]-0.5) + 0.5
+		}
+		f := p.scaledShape(positions, m)
+		for i := range f {
+			f[i] += t[m-1]
+		}
+		return f
+	}
+}
+
+func (p wfg) scaledShape(positions []float64, m int) []float64 {
+	var f []float64
+	if p.shape == nil {
+		f = wfgShape(positions, m, concave, math.Cos(positions[0]*math.Pi/2))
+	} else {
+		f = p.shape(positions, m)
+	}
+	for i := range f {
+		f[i] *= 2 * float64(i+1)
+	}
+	return f
+}
+
+// front samples the front, but for concave shapes, whose front is spread
+// as SphericalFront. On degenerate fronts, the positions other than the
+// first one are 0.5.
+func (p wfg) front(m int) func(int) [][]float64 {
+	return func(points int) [][]float64 {
+		switch {
+		case p.shape == nil:
+			result := SphericalFront(points, m)
+			for _, f := range result {
+				for i := range f {
+					f[i] *= 2 * float64(i+1)
+				}
+			}
+			return result
+		case p.degenerate:
+			return sampleFront(points, 1, func(x []float64) []float64 {
+				positions := make([]float64, m-1)
+				for i := range positions {
+					positions[i] = 0.5
+				}
+				positions[0] = x[0]
+				return p.scaledShape(positions, m)
+			})
+		}
+		return sampleFront(points, m-1, func(x []float64) []float64 { return p.scaledShape(x, m) })
+	}
+}
+
+// wfgPairs are the transformations of WFG2 and WFG3, which reduce the
+// pairs of distance variables non-separably.
+func wfgPairs(y []float64, k, m int) []float64 {
+	for i := k; i < len(y); i++ {
+		y[i] = sLinear(y[i], 0.35)
+	}
+	l := len(y) - k
+	for i := 0; i < l/2; i++ {
+		y[k+i] = rNonsep(y[k+2*i:k+2*i+2], nil)
+	}
+	return wfgReduce(y[:k+l/2], nil, k, m, rSum)
+}
+
+// wfgReduce reduces the k position variables, in m-1 groups, and the
+// distance variables to m values, by weighted sums or non-separably.
+func wfgReduce(y, w []float64, k, m int, reduce func(y, w []float64) float64) []float64 {
+	t := make([]float64, m)
+	group := k / (m - 1)
+	weights := func(from, to int) []float64 {
+		if w == nil {
+			return nil
+		}
+		return w[from:to]
+	}
+	for i := 0; i < m-1; i++ {
+		t[i] = reduce(y[i*group:(i+1)*group], weights(i*group, (i+1)*group))
+	}
+	t[m-1] = reduce(y[k:], weights(k, len(y)))
+	return t
+}
+
+// rSum is the weighted sum of y, with equal weights when w is nil.
+func rSum(y, w []float64) float64 {
+	sum, total := 0.0, 0.0
+	for i, v := range y {
+		weight := 1.0
+		if w != nil {
+			weight = w[i]
+		}
+		sum += weight * v
+		total += weight
+	}
+	return clamp(sum / total)
+}
+
+// rNonsep is the non-separable reduction of y, of degree len(y). The
+// weights are not used.
+func rNonsep(y []float64, _ []float64) float64 {
+	n := len(y)
+	sum := 0.0
+	for j := 0; j < n; j++ {
+		sum += y[j]
+		for k := 0; k <= n-2; k++ {
+			sum += math.Abs(y[j] - y[(1+j+k)%n])
+		}
+	}
+	half := math.Ceil(float64(n) / 2)
+	return clamp(sum / (half * (1 + 2*float64(n) - 2*half)))
+}
+
+func sLinear(y, a float64) float64 {
+	return clamp(math.Abs(y-a) / math.Abs(math.Floor(a-y)+a))
+}
+
+func sDeceptive(y, a, b, c float64) float64 {
+	t1 := math.Floor(y-a+b) * (1 - c + (a-b)/b) / (a - b)
+	t2 := math.Floor(a+b-y) * (1 - c + (1-a-b)/b) / (1 - a - b)
+	return clamp(1 + (math.Abs(y-a)-b)*(t1+t2+1/b))
+}
+
+func sMulti(y, a, b, c float64) float64 {
+	t1 := math.Abs(y-c) / (2 * (math.Floor(c-y) + c))
+	t2 := (4*a + 2) * math.Pi * (0.5 - t1)
+	return clamp((1 + math.Cos(t2) + 4*b*t1*t1) / (b + 2))
+}
+
+func bFlat(y, a, b, c float64) float64 {
+	return clamp(a + math.Min(0, math.Floor(y-b))*a*(b-y)/b - math.Min(0, math.Floor(c-y))*(1-a)*(y-c)/(1-c))
+}
+
+func bPoly(y, alpha float64) float64 {
+	return clamp(math.Pow(y, alpha))
+}
+
+func bParam(y, u, a, b, c float64) float64 {
+	v := a - (1-2*u)*math.Abs(math.Floor(0.5-u)+a)
+	return clamp(math.Pow(y, b+(c-b)*v))
+}
+
+// clamp corrects the rounding errors that take values off [0, 1].
+func clamp(y float64) float64 {
+	return math.Min(1, math.Max(0, y))
+}
+
+// wfgShape returns the shape of the m objectives for the m-1 positions x,
+// the last one given.
+func wfgShape(x []float64, m int, shape func(x []float64, i, m int) float64, last float64) []float64 {
+	h := make([]float64, m)
+	for i := 0; i < m-1; i++ {
+		h[i] = shape(x, i, m)
+	}
+	h[m-1] = last
+	return h
+}
+
+// linear, convex and concave are the shapes of objective i < m-1.
+func linear(x []float64, i, m int) float64 {
+	result := 1.0
+	for j := 0; j < m-1-i; j++ {
+		result *= x[j]
+	}
+	if i > 0 {
+		result *= 1 - x[m-1-i]
+	}
+	return result
+}
+
+func convex(x []float64, i, m int) float64 {
+	result := 1.0
+	for j := 0; j < m-1-i; j++ {
+		result *= 1 - math.Cos(x[j]*math.Pi/2)
+	}
+	if i > 0 {
+		result *= 1 - math.Sin(x[m-1-i]*math.Pi/2)
+	}
+	return result
+}
+
+func concave(x []float64, i, m int) float64 {
+	result := 1.0
+	for j := 0; j < m-1-i; j++ {
+		result *= math.Sin(x[j] * math.Pi / 2)
+	}
+	if i > 0 {
+		result *= math.Cos(x[m-1-i] * math.Pi / 2)
+	}
+	return result
+}
+
+func mixed(x, a, alpha float64) float64 {
+	return math.Pow(1-x-math.Cos(2*a*math.Pi*x+math.Pi/2)/(2*a*math.Pi), alpha)
+}
+
+func disc(x, a, alpha, beta float64) float64 {
+	c := math.Cos(a * math.Pow(x, beta) * math.Pi)
+	return 1 - math.Pow(x, alpha)*c*c
+}
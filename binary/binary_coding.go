@@ -2,6 +2,7 @@ package binary
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"unsafe"
 
@@ -28,12 +29,29 @@ type binaryIndividual struct {
 	variableWordCountTotal int
 	variablesInitialized   bool
 	rng                    moea.RNG
+	gray                   bool
+	realBounds             []RealBound
+	reals                  []float64
 }
 
 type Bound struct {
 	Min, Max string
 }
 
+type Options struct {
+	// Gray decodes each variable as reflected binary Gray code, so that
+	// adjacent values differ in a single bit. Bounds apply to the decoded
+	// value.
+	Gray bool
+	// RealBounds makes Value return a float64 mapped linearly from the bits
+	// of each variable onto its bound. Bounds are ignored when set.
+	RealBounds []RealBound
+}
+
+type RealBound struct {
+	Min, Max float64
+}
+
 type mapping struct {
 	min   *big.Int
 	coeff *big.Rat
@@ -42,6 +60,16 @@ type mapping struct {
 const wordBitsize = int(8 * unsafe.Sizeof(big.Word(0)))
 
 func NewRandomBinaryPopulation(size int, lengths []int, bounds []Bound, rng moea.RNG) moea.Population {
+	return NewRandomBinaryPopulationWithOptions(size, lengths, bounds, rng, Options{})
+}
+
+func NewRandomBinaryPopulationWithOptions(size int, lengths []int, bounds []Bound, rng moea.RNG, options Options) moea.Population {
+	if options.RealBounds != nil {
+		if len(options.RealBounds) != len(lengths) {
+			panic("Invalid real bounds")
+		}
+		bounds = nil
+	}
 	if size%2 == 1 {
 		size++
 	}
@@ -82,6 +110,11 @@ func NewRandomBinaryPopulation(size int, lengths []int, bounds []Bound, rng moea
 		result.bi[i].variableWordCount = variableWordCount
 		result.bi[i].variableWordCountTotal = variableWordCountTotal
 		result.bi[i].rng = rng
+		result.bi[i].gray = options.Gray
+		result.bi[i].realBounds = options.RealBounds
+		if options.RealBounds != nil {
+			result.bi[i].reals = make([]float64, len(lengths))
+		}
 		result.individuals[i] = &result.bi[i]
 	}
 	return result
@@ -115,13 +148,16 @@ func (p *binaryPopulation) Clone() moea.Population {
 		result.bi[i].representation = newBinString(first.representation.Len(), w, bigint, bigbits, bsi)
 		result.bi[i].variables = pointersToAllVariables[i*len(first.lengths) : (i+1)*len(first.lengths)]
 		result.mapVars(i)
+		if first.reals != nil {
+			result.bi[i].reals = append([]float64(nil), p.bi[i].reals...)
+		}
 		result.individuals[i] = &result.bi[i]
 	}
 	return result
 }
 
 func (r *binaryIndividual) Clone() moea.Individual {
-	result := NewRandomBinaryPopulation(1, r.lengths, r.bounds, r.rng).Individual(0)
+	result := NewRandomBinaryPopulationWithOptions(1, r.lengths, r.bounds, r.rng, Options{r.gray, r.realBounds}).Individual(0)
 	result.Copy(r, 0, result.Len())
 	return result
 }
@@ -176,50 +212,83 @@ func (r *binaryIndividual) Len() int {
 
 func (r *binaryIndividual) Value(idx int) interface{} {
 	if r.variablesInitialized {
+		if r.reals != nil {
+			return r.reals[idx]
+		}
 		return r.variables[idx]
 	}
+	var scratch *big.Int
 	for i := 0; i < len(r.variables); i++ {
 		r.representation.Slice(r.starts[i], r.starts[i]+r.lengths[i], r.variables[i])
-		if r.mappings != nil {
+		if r.mappings != nil || r.gray {
 			bigint := r.variables[i].Int()
-			if r.mappings[i].coeff.Num().BitLen() != 1 {
-				bigint.Mul(bigint, r.mappings[i].coeff.Num())
-			}
-			bigint.Quo(bigint, r.mappings[i].coeff.Denom())
-			bigint = bigint.Add(bigint, r.mappings[i].min)
-			if len(r.variables[i].w) > 1 {
-				rmd := r.lengths[i] % wordBitsize
-				b0 := bigint.Bits()[0]
-				b0 = (b0 << uint(wordBitsize-rmd)) >> uint(wordBitsize-rmd)
-				bigint = bigint.Rsh(bigint, uint(rmd))
-				bigbits := bigint.Bits()
-				ll := len(r.variables[i].w) - len(bigbits)
-				if rmd > 0 && ll > 0 {
-					ll--
+			if r.gray {
+				if scratch == nil {
+					scratch = new(big.Int)
 				}
-				for j := ll; j < len(r.variables[i].w)-1; j++ {
-					r.variables[i].w[j] = bigbits[len(bigbits)-1-j+ll]
-				}
-				for j := 0; j < ll; j++ {
-					r.variables[i].w[j] = 0
-				}
-				if rmd > 0 {
-					r.variables[i].w[len(r.variables[i].w)-1] = b0
-				}
-			} else {
-				bits := bigint.Bits()
-				if len(bits) == 0 {
-					r.variables[i].w[0] = 0
-				} else {
-					r.variables[i].w[0] = bits[0]
+				grayToBinary(bigint, r.lengths[i], scratch)
+			}
+			if r.mappings != nil {
+				if r.mappings[i].coeff.Num().BitLen() != 1 {
+					bigint.Mul(bigint, r.mappings[i].coeff.Num())
 				}
+				bigint.Quo(bigint, r.mappings[i].coeff.Denom())
+				bigint = bigint.Add(bigint, r.mappings[i].min)
 			}
+			r.variables[i].setInt(bigint)
+		}
+		if r.reals != nil {
+			r.reals[i] = ToFloat64(r.variables[i], r.realBounds[i])
 		}
 	}
 	r.variablesInitialized = true
+	if r.reals != nil {
+		return r.reals[idx]
+	}
 	return r.variables[idx]
 }
 
+// grayToBinary converts the reflected binary Gray code of the given length
+// in x to plain binary, in place, by xoring x with all its right shifts.
+func grayToBinary(x *big.Int, length int, scratch *big.Int) {
+	for shift := uint(1); shift < uint(length); shift <<= 1 {
+		x.Xor(x, scratch.Rsh(x, shift))
+	}
+}
+
+// ToFloat64 maps the bits of value linearly onto bound, so that all zeros
+// is bound.Min and all ones is bound.Max. Unlike Int().Int64(), it does not
+// truncate values longer than 63 bits.
+func ToFloat64(value BinaryString, bound RealBound) float64 {
+	if value.Len() == 0 {
+		return bound.Min
+	}
+	var ratio float64
+	if value.Len() <= 53 {
+		ratio = float64(value.Int().Uint64()) / float64(uint64(1)<<uint(value.Len())-1)
+	} else {
+		max := new(big.Int).Lsh(big.NewInt(1), uint(value.Len()))
+		max.Sub(max, big.NewInt(1))
+		f := new(big.Float).SetInt(value.Int())
+		ratio, _ = f.Quo(f, new(big.Float).SetInt(max)).Float64()
+	}
+	return bound.Min + (bound.Max-bound.Min)*ratio
+}
+
+// LengthsForPrecision returns the number of bits each variable needs so
+// that consecutive values within its bound are at most 10^-digits apart.
+func LengthsForPrecision(bounds []RealBound, digits int) []int {
+	result := make([]int, len(bounds))
+	for i, b := range bounds {
+		steps := (b.Max - b.Min) * math.Pow(10, float64(digits))
+		result[i] = 1
+		if steps > 1 {
+			result[i] = int(math.Ceil(math.Log2(steps + 1)))
+		}
+	}
+	return result
+}
+
 func (r *binaryIndividual) Copy(individual moea.Individual, start, end int) {
 	bi := individual.(*binaryIndividual)
 	r.representation.Copy(bi.representation, start, end)
@@ -209,6 +209,69 @@ func TestValueOverlapingWords(t *testing.T) {
 	}
 }
 
+func TestGray(t *testing.T) {
+	for i, code := range []string{"000", "001", "011", "010", "110", "111", "101", "100"} {
+		bi := newFromString([]string{code, "1"}, nil)
+		bi.gray = true
+		assertEqual(t, big.NewInt(int64(i)).Bytes(), bi.Value(0).(BinaryString).Int().Bytes())
+		assertEqual(t, big.NewInt(1), bi.Value(1).(BinaryString).Int())
+	}
+	// over several words, each bit is the parity of the gray bits up to it
+	rng := moea.NewXorshiftWithSeed(5)
+	for _, l := range []int{wordBitsize - 1, wordBitsize, wordBitsize + 1, 2 * wordBitsize, 3*wordBitsize + 7} {
+		code := make([]byte, l)
+		expected := make([]byte, l)
+		parity := byte(0)
+		for i := range code {
+			code[i] = '0'
+			if rng.FairFlip() {
+				code[i] = '1'
+			}
+			parity ^= code[i] - '0'
+			expected[i] = '0' + parity
+		}
+		bi := newFromString([]string{"1", string(code), string(code)}, nil)
+		assertEqual(t, string(code), bi.Value(1).(BinaryString).String())
+		bi.gray = true
+		bi.variablesInitialized = false
+		assertEqual(t, string(expected), bi.Value(1).(BinaryString).String())
+		assertEqual(t, string(expected), bi.Value(2).(BinaryString).String())
+	}
+	bi := newFromString([]string{"10"}, []Bound{{"0", "10"}})
+	bi.gray = true
+	assertEqual(t, "10", bi.Value(0).(BinaryString).String())
+}
+
+func TestRealBounds(t *testing.T) {
+	bounds := []RealBound{{-1, 1}, {0, 1e6}, {5, 5}}
+	lengths := LengthsForPrecision(bounds, 3)
+	assertEqual(t, []int{11, 30, 1}, lengths)
+	rng := moea.NewXorshiftWithSeed(9)
+	for _, gray := range []bool{false, true} {
+		population := NewRandomBinaryPopulationWithOptions(10, lengths, nil, rng, Options{Gray: gray, RealBounds: bounds})
+		clone := population.Clone()
+		for i := 0; i < population.Len(); i++ {
+			individual := population.Individual(i)
+			for j, b := range bounds {
+				v := individual.Value(j).(float64)
+				if v < b.Min || v > b.Max {
+					t.Errorf("value %v out of bounds %v", v, b)
+				}
+				assertEqual(t, v, clone.Individual(i).Value(j))
+				assertEqual(t, v, individual.Clone().Value(j))
+			}
+		}
+		individual := population.Individual(0)
+		individual.Copy(population.Individual(1), 0, individual.Len())
+		assertEqual(t, population.Individual(1).Value(0), individual.Value(0))
+	}
+	ones := newFromString([]string{strings.Repeat("1", 100)}, nil)
+	assertEqual(t, 3.0, ToFloat64(ones.Value(0).(BinaryString), RealBound{-2, 3}))
+	half := newFromString([]string{"1" + strings.Repeat("0", 99)}, nil)
+	assertEqual(t, 0.5, ToFloat64(half.Value(0).(BinaryString), RealBound{0, 1}))
+	assertEqual(t, 2.0, ToFloat64(newFromString([]string{"10"}, nil).Value(0).(BinaryString), RealBound{0, 3}))
+}
+
 func assertEqual(t *testing.T, expected, value interface{}) {
 	if !reflect.DeepEqual(expected, value) {
 		reportError(t, "", expected, value)
@@ -152,7 +152,7 @@ func (b *bs) Int() *big.Int {
 	}
 	if len(b.w) > 1 {
 		b.bigint.SetBits(b.bigbits[0 : len(b.w)-1])
-		b.bigint = b.bigint.Lsh(b.bigint, uint(b.l%wordBitsize))
+		b.bigint = b.bigint.Lsh(b.bigint, uint(lastWordBits(b.l)))
 		b.bigbits = b.bigint.Bits()
 	}
 	if len(b.w) > 0 && len(b.bigbits) > 0 {
@@ -162,6 +162,31 @@ func (b *bs) Int() *big.Int {
 	return b.bigint
 }
 
+// lastWordBits is the number of bits of a string of length l held by its
+// last word, which is right aligned.
+func lastWordBits(l int) int {
+	return (l-1)%wordBitsize + 1
+}
+
+// setInt is the inverse of Int: it stores bigint, which must fit in the
+// string, in its words. bigint is modified.
+func (b *bs) setInt(bigint *big.Int) {
+	rmd := uint(lastWordBits(b.l))
+	low := big.Word(0)
+	if bits := bigint.Bits(); len(bits) > 0 {
+		low = bits[0] & (1<<rmd - 1)
+	}
+	bits := bigint.Rsh(bigint, rmd).Bits()
+	for j := 0; j < len(b.w)-1; j++ {
+		if k := len(b.w) - 2 - j; k < len(bits) {
+			b.w[j] = bits[k]
+		} else {
+			b.w[j] = 0
+		}
+	}
+	b.w[len(b.w)-1] = low
+}
+
 func (b *bs) String() string {
 	rmd := b.l % wordBitsize
 	if rmd != 0 {
@@ -206,9 +231,9 @@ func (s *bs) Slice(i, j int, dest BinaryString) {
 		size = s.Len() % wordBitsize
 	}
 	// fill with zeroes the left of last word
-	destWords[lastWord-firstWord-1] >>= uint(size - (j-i)%wordBitsize)
+	destWords[lastWord-firstWord-1] >>= uint(size - lastWordBits(j-i))
 	if lastWord == len(s.w) && s.Len()%wordBitsize > 0 {
-		destWords[lastWord-firstWord-1] &= ^big.Word(0) >> uint(wordBitsize-(j-i)%wordBitsize)
+		destWords[lastWord-firstWord-1] &= ^big.Word(0) >> uint(wordBitsize-lastWordBits(j-i))
 	}
 	// discard unused words at the end
 	wordCount := howManyWords(j - i)
@@ -0,0 +1,43 @@
+package binary
+
+import "github.com/project-draco/moea"
+
+// HillClimbing is a first-improvement bit-flip hill climber. It flips the
+// bits in random order, keeping each flip that yields dominating objectives
+// and undoing the others, until a pass brings no improvement or
+// MaxEvaluations evaluations are spent (the length of the individual by
+// default).
+type HillClimbing struct{ MaxEvaluations int }
+
+func (h *HillClimbing) Search(config *moea.Config, individual moea.Individual, objective []float64,
+	evaluate moea.ObjectiveFunc) []float64 {
+	max := h.MaxEvaluations
+	if max <= 0 {
+		max = individual.Len()
+	}
+	flip := []int{0}
+	for evaluations := 0; evaluations < max; {
+		improved := false
+		for _, i := range config.RandomNumberGenerator.Perm(individual.Len()) {
+			if evaluations == max {
+				break
+			}
+			flip[0] = i
+			individual.Mutate(flip)
+			f := evaluate(individual)
+			evaluations++
+			if f != nil && moea.Dominates(f, objective) {
+				objective, improved = f, true
+				continue
+			}
+			individual.Mutate(flip)
+			if f == nil {
+				return objective
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return objective
+}
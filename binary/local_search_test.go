@@ -0,0 +1,41 @@
+package binary
+
+import (
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+func TestHillClimbing(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(1)
+	population := NewRandomBinaryPopulation(2, []int{100}, nil, rng)
+	config := &moea.Config{Population: population, RandomNumberGenerator: rng}
+	onemax := func(individual moea.Individual) []float64 {
+		result := 0.0
+		for i := 0; i < individual.Len(); i++ {
+			if individual.Value(0).(BinaryString).Test(i) {
+				result--
+			}
+		}
+		return []float64{result}
+	}
+	evaluations := 0
+	evaluate := func(individual moea.Individual) []float64 {
+		evaluations++
+		return onemax(individual)
+	}
+	individual := population.Individual(0)
+	f := (&HillClimbing{}).Search(config, individual, onemax(individual), evaluate)
+	if f[0] != -100 || onemax(individual)[0] != -100 {
+		t.Errorf("expected a single pass to reach the optimum but was %v", f)
+	}
+	if evaluations != 100 {
+		t.Errorf("expected 100 evaluations but were %v", evaluations)
+	}
+	individual = population.Individual(1)
+	before := onemax(individual)
+	f = (&HillClimbing{MaxEvaluations: 10}).Search(config, individual, before, func(moea.Individual) []float64 { return nil })
+	if f[0] != before[0] || onemax(individual)[0] != before[0] {
+		t.Error("expected no change without budget")
+	}
+}
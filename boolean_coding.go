@@ -10,13 +10,18 @@ func (p booleanPopulation) Individual(i int) Individual { return p[i] }
 
 func (p booleanPopulation) Clone() Population {
 	result := make(booleanPopulation, p.Len())
-	copy(result, p)
+	for i, individual := range p {
+		result[i] = individual.Clone()
+	}
 	return result
 }
 
 func NewRandomBooleanPopulation(size int, lengths []int) Population {
+	return NewRandomBooleanPopulationWithRNG(size, lengths, NewXorshift())
+}
+
+func NewRandomBooleanPopulationWithRNG(size int, lengths []int, rng RNG) Population {
 	result := make(booleanPopulation, size)
-	rng := NewXorshift()
 	for i := 0; i < size; i++ {
 		result[i] = newBooleanIndividual(lengths, rng)
 	}
@@ -60,14 +65,13 @@ func (bi booleanIndividual) Copy(individual Individual, start, end int) {
 }
 
 func (bi booleanIndividual) Mutate(mutations []int) {
-	k, m := 0, 0
-	for i, v := range bi {
-		for j := 0; j < len(v); j++ {
-			if k == mutations[m] {
-				bi[i][j] = !bi[i][j]
-				m++
+	for _, m := range mutations {
+		for i := range bi {
+			if m < len(bi[i]) {
+				bi[i][m] = !bi[i][m]
+				break
 			}
-			k++
+			m -= len(bi[i])
 		}
 	}
 }
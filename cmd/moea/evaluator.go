@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+type externalEvaluator struct {
+	cmd        *exec.Cmd
+	stdin      io.WriteCloser
+	stdout     *bufio.Reader
+	objectives int
+	line       []byte
+}
+
+func startEvaluator(command []string, objectives int) (*externalEvaluator, error) {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &externalEvaluator{cmd, stdin, bufio.NewReader(stdout), objectives, nil}, nil
+}
+
+func (e *externalEvaluator) evaluate(x []float64) ([]float64, error) {
+	e.line = e.line[:0]
+	for i, v := range x {
+		if i > 0 {
+			e.line = append(e.line, ' ')
+		}
+		e.line = strconv.AppendFloat(e.line, v, 'g', -1, 64)
+	}
+	e.line = append(e.line, '\n')
+	if _, err := e.stdin.Write(e.line); err != nil {
+		return nil, fmt.Errorf("evaluator: %v", err)
+	}
+	answer, err := e.stdout.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("evaluator: %v", err)
+	}
+	fields := strings.Fields(answer)
+	if len(fields) != e.objectives {
+		return nil, fmt.Errorf("evaluator: expected %d objectives but got %q", e.objectives, strings.TrimSpace(answer))
+	}
+	result := make([]float64, len(fields))
+	for i, f := range fields {
+		if result[i], err = strconv.ParseFloat(f, 64); err != nil {
+			return nil, fmt.Errorf("evaluator: %v", err)
+		}
+	}
+	return result, nil
+}
+
+func (e *externalEvaluator) close() error {
+	e.stdin.Close()
+	return e.cmd.Wait()
+}
@@ -0,0 +1,83 @@
+// Command moea runs one experiment described by a JSON or YAML spec file.
+// Only a subset of YAML is understood; run moea -h for its description.
+//
+// Usage:
+//
+//	moea [-list] spec.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/project-draco/moea/benchmark"
+	"github.com/project-draco/moea/plot"
+)
+
+func main() {
+	list := flag.Bool("list", false, "list the built-in problems and exit")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: moea [-list] spec.{json,yaml}")
+		flag.PrintDefaults()
+		fmt.Fprintf(flag.CommandLine.Output(), "\n%s\n", yamlSubset)
+	}
+	flag.Parse()
+	if *list {
+		for _, name := range benchmark.Names() {
+			fmt.Println(name)
+		}
+		return
+	}
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(path string) error {
+	spec, err := readSpec(path)
+	if err != nil {
+		return err
+	}
+	e, err := newExperiment(spec)
+	if err != nil {
+		return err
+	}
+	var history *plot.History
+	if spec.Output.Report != "" {
+		history = plot.NewHistory(nil)
+		e.config.OnGenerationFunc = history.Record
+	}
+	result, err := e.run()
+	if err != nil {
+		return err
+	}
+	var w io.Writer = os.Stdout
+	if spec.Output.File != "" {
+		f, err := os.Create(spec.Output.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := e.write(w, result); err != nil {
+		return err
+	}
+	if spec.Output.Report != "" {
+		f, err := os.Create(spec.Output.Report)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		report := &plot.Report{Title: path, Front: e.front(result), History: history}
+		return report.WriteHTML(f)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/project-draco/moea"
+)
+
+type solution struct {
+	Objectives []float64 `json:"objectives"`
+	Variables  []float64 `json:"variables"`
+}
+
+func (e *experiment) solutions(result *moea.Result, front bool) []solution {
+	// Objectives are reported as the problem defines them, which are negated
+	// for the maximising NsgaSelection.
+	unsigned := &moea.Result{Individuals: make([]moea.IndividualResult, len(result.Individuals))}
+	for i, individual := range result.Individuals {
+		unsigned.Individuals[i] = individual
+		unsigned.Individuals[i].Objective = make([]float64, len(individual.Objective))
+		for j, o := range individual.Objective {
+			unsigned.Individuals[i].Objective[j] = e.sign * o
+		}
+	}
+	individuals := unsigned.Individuals
+	if front {
+		individuals = unsigned.ParettoFrontier()
+	}
+	solutions := make([]solution, len(individuals))
+	for i, individual := range individuals {
+		solutions[i].Objectives = individual.Objective
+		solutions[i].Variables = make([]float64, len(individual.Values))
+		for j, v := range individual.Values {
+			solutions[i].Variables[j] = e.valueAsFloat(v, j)
+		}
+	}
+	return solutions
+}
+
+func (e *experiment) write(w io.Writer, result *moea.Result) error {
+	solutions := e.solutions(result, e.spec.Output.Front)
+	switch e.spec.Output.Format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(solutions)
+	case "csv":
+		writer := csv.NewWriter(w)
+		header := make([]string, 0, e.objectives+e.variables)
+		for i := 0; i < e.objectives; i++ {
+			header = append(header, fmt.Sprintf("f%d", i+1))
+		}
+		for i := 0; i < e.variables; i++ {
+			header = append(header, fmt.Sprintf("x%d", i+1))
+		}
+		writer.Write(header)
+		record := make([]string, len(header))
+		for _, s := range solutions {
+			record = record[:0]
+			for _, v := range append(s.Objectives, s.Variables...) {
+				record = append(record, strconv.FormatFloat(v, 'g', -1, 64))
+			}
+			writer.Write(record)
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+	for _, s := range solutions {
+		fmt.Fprintf(w, "[")
+		for _, o := range s.Objectives {
+			fmt.Fprintf(w, "%.4f ", o)
+		}
+		fmt.Fprintf(w, "]")
+		for _, v := range s.Variables {
+			fmt.Fprintf(w, " %.4f", v)
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *experiment) front(result *moea.Result) []moea.IndividualResult {
+	solutions := e.solutions(result, true)
+	individuals := make([]moea.IndividualResult, len(solutions))
+	for i, s := range solutions {
+		individuals[i].Objective = s.Objectives
+	}
+	return individuals
+}
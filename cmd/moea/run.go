@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/benchmark"
+	"github.com/project-draco/moea/binary"
+	"github.com/project-draco/moea/nsga"
+	"github.com/project-draco/moea/nsgaii"
+	"github.com/project-draco/moea/nsgaiii"
+)
+
+type experiment struct {
+	spec       *Spec
+	config     *moea.Config
+	variables  int
+	objectives int
+	bounds     func(int) (float64, float64)
+	evaluate   func([]float64) ([]float64, error)
+	evaluator  *externalEvaluator
+	sign       float64
+	err        error
+}
+
+func newExperiment(spec *Spec) (*experiment, error) {
+	e := &experiment{spec: spec, sign: 1}
+	if spec.Algorithm == "nsga" {
+		// NsgaSelection maximises, every other algorithm minimises.
+		e.sign = -1
+	}
+	if len(spec.Problem.Command) > 0 {
+		evaluator, err := startEvaluator(spec.Problem.Command, spec.Problem.Objectives)
+		if err != nil {
+			return nil, err
+		}
+		e.evaluator = evaluator
+		e.variables = spec.Problem.Variables
+		e.objectives = spec.Problem.Objectives
+		e.evaluate = evaluator.evaluate
+	} else {
+		problem, err := benchmark.Get(spec.Problem.Name, spec.Problem.Variables, spec.Problem.Objectives)
+		if err != nil {
+			return nil, err
+		}
+		e.variables = problem.NumberOfVariables
+		e.objectives = problem.NumberOfObjectives
+		e.bounds = problem.Bounds
+		e.evaluate = func(x []float64) ([]float64, error) { return problem.Evaluate(x), nil }
+	}
+	if len(spec.Problem.Bounds) > 0 {
+		bounds := spec.Problem.Bounds
+		if len(bounds) != 1 && len(bounds) != e.variables {
+			if e.evaluator != nil {
+				e.evaluator.close()
+			}
+			return nil, fmt.Errorf("problem needs one bound or one bound per variable (%d), got %d",
+				e.variables, len(bounds))
+		}
+		e.bounds = func(i int) (float64, float64) {
+			if len(bounds) == 1 {
+				return bounds[0][0], bounds[0][1]
+			}
+			return bounds[i][0], bounds[i][1]
+		}
+	}
+	e.config = e.newConfig()
+	return e, nil
+}
+
+func (e *experiment) newConfig() *moea.Config {
+	spec := e.spec
+	seed := spec.Seed
+	if seed == 0 {
+		seed = uint32(time.Now().UTC().UnixNano())
+	}
+	rng := moea.NewXorshiftWithSeed(seed)
+	bounds := make([]binary.RealBound, e.variables)
+	for i := range bounds {
+		bounds[i].Min, bounds[i].Max = e.bounds(i)
+	}
+	lengths := make([]int, e.variables)
+	totalLength := 0
+	for i := range lengths {
+		lengths[i] = spec.Encoding.Bits
+		if spec.Encoding.Precision > 0 {
+			lengths[i] = binary.LengthsForPrecision(bounds[i:i+1], spec.Encoding.Precision)[0]
+		}
+		totalLength += lengths[i]
+	}
+	mutationProbability := 1.0 / float64(totalLength)
+	if spec.Operators.MutationProbability != nil {
+		mutationProbability = *spec.Operators.MutationProbability
+	}
+	return &moea.Config{
+		Algorithm: moea.NewSimpleAlgorithm(e.selectionOperator(), e.mutationOperator()),
+		Population: binary.NewRandomBinaryPopulationWithOptions(spec.Population, lengths, nil, rng,
+			binary.Options{Gray: spec.Encoding.Type == "gray"}),
+		NumberOfValues:        e.variables,
+		NumberOfObjectives:    e.objectives,
+		ObjectiveFunc:         e.objectiveFunc,
+		MaxGenerations:        spec.Termination.Generations,
+		CrossoverProbability:  *spec.Operators.CrossoverProbability,
+		MutationProbability:   mutationProbability,
+		RandomNumberGenerator: rng,
+	}
+}
+
+func (e *experiment) selectionOperator() moea.SelectionOperator {
+	switch e.spec.Algorithm {
+	case "nsga":
+		lower := make([]float64, e.variables)
+		upper := make([]float64, e.variables)
+		for i := range lower {
+			lower[i], upper[i] = e.bounds(i)
+		}
+		return &nsga.NsgaSelection{
+			ValuesAsFloat: e.decode,
+			LowerBounds:   lower,
+			UpperBounds:   upper,
+			Dshare:        e.spec.Operators.Dshare,
+		}
+	case "nsgaii":
+		return &nsgaii.NsgaIISelection{}
+	case "nsgaiii":
+		return &nsgaiii.NsgaIIISelection{ReferencePointsDivision: e.spec.Operators.ReferencePointsDivision}
+	}
+	switch e.spec.Operators.Selection {
+	case "roulette":
+		return &moea.RouletteWheelSelection{}
+	case "sus":
+		return &moea.StochasticUniversalSampling{}
+	case "linear-ranking":
+		return &moea.LinearRankingSelection{}
+	case "exponential-ranking":
+		return &moea.ExponentialRankingSelection{}
+	case "boltzmann":
+		return &moea.BoltzmannSelection{}
+	case "truncation":
+		return &moea.TruncationSelection{}
+	}
+	return &moea.TournamentSelection{TournamentSize: e.spec.Operators.TournamentSize}
+}
+
+func (e *experiment) mutationOperator() moea.MutationOperator {
+	if e.spec.Operators.Mutation == "regular" {
+		return &moea.RegularMutation{}
+	}
+	return &moea.FastMutation{}
+}
+
+func (e *experiment) valueAsFloat(value interface{}, i int) float64 {
+	from, to := e.bounds(i)
+	return binary.ToFloat64(value.(binary.BinaryString), binary.RealBound{Min: from, Max: to})
+}
+
+func (e *experiment) decode(individual moea.Individual) []float64 {
+	x := make([]float64, e.variables)
+	for i := range x {
+		x[i] = e.valueAsFloat(individual.Value(i), i)
+	}
+	return x
+}
+
+// objectiveFunc keeps the first evaluation error and makes every following
+// individual maximally bad, so that the run can finish and report it.
+func (e *experiment) objectiveFunc(individual moea.Individual) []float64 {
+	if e.err == nil {
+		objectives, err := e.evaluate(e.decode(individual))
+		if err == nil {
+			for i := range objectives {
+				objectives[i] *= e.sign
+			}
+			return objectives
+		}
+		e.err = err
+	}
+	result := make([]float64, e.objectives)
+	for i := range result {
+		result[i] = e.sign * math.Inf(1)
+	}
+	return result
+}
+
+func (e *experiment) run() (*moea.Result, error) {
+	result, err := moea.Run(e.config)
+	if e.evaluator != nil {
+		if closeErr := e.evaluator.close(); err == nil && e.err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	return result, nil
+}
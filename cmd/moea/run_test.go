@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestBoundsPerVariable(t *testing.T) {
+	for _, test := range []struct {
+		bounds [][]float64
+		valid  bool
+	}{
+		{[][]float64{{-4, 4}}, true},
+		{[][]float64{{-4, 4}, {-3, 3}, {-2, 2}}, true},
+		{[][]float64{{-4, 4}, {-3, 3}}, false},
+	} {
+		spec := &Spec{Problem: ProblemSpec{Name: "fon", Bounds: test.bounds}}
+		spec.setDefaults()
+		if err := spec.validate(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := newExperiment(spec); (err == nil) != test.valid {
+			t.Errorf("%v: want valid %v, got %v", test.bounds, test.valid, err)
+		}
+	}
+}
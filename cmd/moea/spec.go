@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+type Spec struct {
+	Algorithm   string      `json:"algorithm"`
+	Problem     ProblemSpec `json:"problem"`
+	Encoding    Encoding    `json:"encoding"`
+	Population  int         `json:"population"`
+	Operators   Operators   `json:"operators"`
+	Termination Termination `json:"termination"`
+	Seed        uint32      `json:"seed"`
+	Output      Output      `json:"output"`
+}
+
+// ProblemSpec names a problem of the benchmark registry or, when Command is
+// set, describes an external evaluator. The evaluator is started once and
+// receives one line of space-separated variables per evaluation on its
+// standard input; it must answer with one line of space-separated objectives.
+type ProblemSpec struct {
+	Name       string      `json:"name"`
+	Variables  int         `json:"variables"`
+	Objectives int         `json:"objectives"`
+	Bounds     [][]float64 `json:"bounds"`
+	Command    []string    `json:"command"`
+}
+
+type Encoding struct {
+	Type string `json:"type"`
+	Bits int    `json:"bits"`
+	// Precision, when set, is the number of decimal places each variable
+	// is resolved to, and overrides Bits.
+	Precision int `json:"precision"`
+}
+
+type Operators struct {
+	Selection               string   `json:"selection"`
+	TournamentSize          int      `json:"tournamentSize"`
+	Mutation                string   `json:"mutation"`
+	CrossoverProbability    *float64 `json:"crossoverProbability"`
+	MutationProbability     *float64 `json:"mutationProbability"`
+	Dshare                  float64  `json:"dshare"`
+	ReferencePointsDivision int      `json:"referencePointsDivision"`
+}
+
+type Termination struct {
+	Generations int `json:"generations"`
+}
+
+type Output struct {
+	Format string `json:"format"`
+	File   string `json:"file"`
+	Front  bool   `json:"front"`
+	Report string `json:"report"`
+}
+
+func readSpec(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		tree, err := parseYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if data, err = json.Marshal(tree); err != nil {
+			return nil, err
+		}
+	}
+	spec := &Spec{}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(spec); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	spec.setDefaults()
+	return spec, spec.validate()
+}
+
+func (s *Spec) setDefaults() {
+	if s.Algorithm == "" {
+		s.Algorithm = "nsgaii"
+	}
+	if s.Encoding.Type == "" {
+		s.Encoding.Type = "binary"
+	}
+	if s.Encoding.Bits == 0 {
+		s.Encoding.Bits = 32
+	}
+	if s.Population == 0 {
+		s.Population = 100
+	}
+	if s.Operators.Selection == "" {
+		s.Operators.Selection = "tournament"
+	}
+	if s.Operators.TournamentSize == 0 {
+		s.Operators.TournamentSize = 2
+	}
+	if s.Operators.Mutation == "" {
+		s.Operators.Mutation = "fast"
+	}
+	if s.Operators.CrossoverProbability == nil {
+		p := 0.9
+		s.Operators.CrossoverProbability = &p
+	}
+	if s.Operators.ReferencePointsDivision == 0 {
+		s.Operators.ReferencePointsDivision = 12
+	}
+	if s.Termination.Generations == 0 {
+		s.Termination.Generations = 250
+	}
+	if s.Output.Format == "" {
+		s.Output.Format = "text"
+	}
+}
+
+func (s *Spec) validate() error {
+	switch s.Algorithm {
+	case "simple", "nsga", "nsgaii", "nsgaiii":
+	default:
+		return fmt.Errorf("unknown algorithm %q", s.Algorithm)
+	}
+	if s.Encoding.Type != "binary" && s.Encoding.Type != "gray" {
+		return fmt.Errorf("unknown encoding %q", s.Encoding.Type)
+	}
+	if s.Problem.Name == "" && len(s.Problem.Command) == 0 {
+		return fmt.Errorf("problem needs a name or a command")
+	}
+	if len(s.Problem.Command) > 0 {
+		if s.Problem.Variables <= 0 || s.Problem.Objectives <= 0 {
+			return fmt.Errorf("external problems need variables and objectives")
+		}
+		if len(s.Problem.Bounds) != 1 && len(s.Problem.Bounds) != s.Problem.Variables {
+			return fmt.Errorf("external problems need one bound or one bound per variable")
+		}
+	}
+	for _, b := range s.Problem.Bounds {
+		if len(b) != 2 || b[0] > b[1] {
+			return fmt.Errorf("bounds must be [min, max] pairs")
+		}
+	}
+	switch s.Operators.Selection {
+	case "tournament", "roulette", "sus", "linear-ranking", "exponential-ranking", "boltzmann", "truncation":
+	default:
+		return fmt.Errorf("unknown selection %q", s.Operators.Selection)
+	}
+	switch s.Operators.Mutation {
+	case "regular", "fast":
+	default:
+		return fmt.Errorf("unknown mutation %q", s.Operators.Mutation)
+	}
+	switch s.Output.Format {
+	case "text", "csv", "json":
+	default:
+		return fmt.Errorf("unknown output format %q", s.Output.Format)
+	}
+	if s.Population < 2 {
+		return fmt.Errorf("population must have at least two individuals")
+	}
+	return nil
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlSubset is the part of YAML that parseYAML understands, as told to
+// users.
+const yamlSubset = `YAML specs are limited to block mappings and sequences, flow mappings and
+sequences on a single line, comments and plain, single-quoted or
+double-quoted scalars on a single line. Anchors, aliases, tags, block and
+multi-line scalars, complex keys, directives and multiple documents are
+rejected.`
+
+// parseYAML understands the subset of YAML described by yamlSubset and
+// reports anything else as an error at its line.
+func parseYAML(data []byte) (interface{}, error) {
+	p := &yamlParser{}
+	for n, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimRight(stripComment(raw), " \t\r")
+		text := strings.TrimSpace(line)
+		if text == "" || text == "---" && len(p.lines) == 0 {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimLeft(line, " "), "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", n+1)
+		}
+		switch {
+		case text == "---" || text == "...":
+			return nil, fmt.Errorf("line %d: multiple documents are not supported", n+1)
+		case strings.HasPrefix(text, "%"):
+			return nil, fmt.Errorf("line %d: directives are not supported", n+1)
+		case text == "?" || strings.HasPrefix(text, "? "):
+			return nil, fmt.Errorf("line %d: complex keys are not supported", n+1)
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		p.lines = append(p.lines, yamlLine{n + 1, indent, strings.TrimSpace(line)})
+	}
+	if len(p.lines) == 0 {
+		return nil, nil
+	}
+	result, err := p.node(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].number)
+	}
+	return result, nil
+}
+
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) node(indent int) (interface{}, error) {
+	if isSequenceItem(p.lines[p.pos].text) {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent)
+}
+
+func (p *yamlParser) sequence(indent int) (interface{}, error) {
+	result := []interface{}{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isSequenceItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		content := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		if content == "" {
+			p.pos++
+			value, err := p.nested(indent, line.number)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+			continue
+		}
+		if _, _, ok := splitKey(content); ok || isSequenceItem(content) {
+			// "- key: value" opens a mapping whose first entry is on this line.
+			p.lines[p.pos] = yamlLine{line.number, indent + len(line.text) - len(content), content}
+			value, err := p.node(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+			continue
+		}
+		value, err := scalarOrFlow(content, line.number)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+		p.pos++
+	}
+	return result, nil
+}
+
+func (p *yamlParser) mapping(indent int) (interface{}, error) {
+	result := map[string]interface{}{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent {
+		line := p.lines[p.pos]
+		if isSequenceItem(line.text) {
+			break
+		}
+		key, value, ok := splitKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", line.number)
+		}
+		if _, exists := result[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.number, key)
+		}
+		p.pos++
+		if value == "" {
+			nested, err := p.nested(indent, line.number)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = nested
+			continue
+		}
+		parsed, err := scalarOrFlow(value, line.number)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = parsed
+	}
+	return result, nil
+}
+
+// nested parses the block that follows a key or a bare "-". Sequences may
+// start at the same indentation as their key.
+func (p *yamlParser) nested(indent, number int) (interface{}, error) {
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	next := p.lines[p.pos]
+	if next.indent > indent || (next.indent == indent && isSequenceItem(next.text)) {
+		return p.node(next.indent)
+	}
+	return nil, nil
+}
+
+func isSequenceItem(s string) bool {
+	return s == "-" || strings.HasPrefix(s, "- ")
+}
+
+func splitKey(s string) (string, string, bool) {
+	if strings.HasPrefix(s, "[") || strings.HasPrefix(s, "{") {
+		return "", "", false
+	}
+	quote := byte(0)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == ':' && (i == len(s)-1 || s[i+1] == ' '):
+			key := strings.TrimSpace(s[:i])
+			if unquoted, err := strconv.Unquote(key); err == nil {
+				key = unquoted
+			} else if len(key) >= 2 && key[0] == '\'' && key[len(key)-1] == '\'' {
+				key = key[1 : len(key)-1]
+			}
+			return key, strings.TrimSpace(s[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+func stripComment(s string) string {
+	quote := byte(0)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func scalarOrFlow(s string, number int) (interface{}, error) {
+	f := &flowParser{s: s}
+	value, err := f.value()
+	if err == nil {
+		f.skipSpaces()
+		if f.pos < len(f.s) {
+			err = fmt.Errorf("unexpected %q", f.s[f.pos:])
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %v", number, err)
+	}
+	return value, nil
+}
+
+type flowParser struct {
+	s   string
+	pos int
+}
+
+func (f *flowParser) skipSpaces() {
+	for f.pos < len(f.s) && f.s[f.pos] == ' ' {
+		f.pos++
+	}
+}
+
+func (f *flowParser) value() (interface{}, error) {
+	return f.valueUntil(",]}")
+}
+
+func (f *flowParser) valueUntil(stop string) (interface{}, error) {
+	f.skipSpaces()
+	if f.pos >= len(f.s) {
+		return nil, nil
+	}
+	switch f.s[f.pos] {
+	case '[':
+		return f.sequence()
+	case '{':
+		return f.mapping()
+	case '"', '\'':
+		return f.quoted()
+	case '&', '*', '!', '|', '>', '@', '`':
+		return nil, fmt.Errorf("%q is not supported", f.s[f.pos:])
+	}
+	start := f.pos
+	for f.pos < len(f.s) && !strings.ContainsRune(stop, rune(f.s[f.pos])) {
+		f.pos++
+	}
+	return plainScalar(strings.TrimSpace(f.s[start:f.pos])), nil
+}
+
+func (f *flowParser) sequence() (interface{}, error) {
+	f.pos++
+	result := []interface{}{}
+	for {
+		f.skipSpaces()
+		if f.pos >= len(f.s) {
+			return nil, fmt.Errorf("unterminated flow sequence")
+		}
+		if f.s[f.pos] == ']' {
+			f.pos++
+			return result, nil
+		}
+		value, err := f.value()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+		f.skipSpaces()
+		if f.pos < len(f.s) && f.s[f.pos] == ',' {
+			f.pos++
+		}
+	}
+}
+
+func (f *flowParser) mapping() (interface{}, error) {
+	f.pos++
+	result := map[string]interface{}{}
+	for {
+		f.skipSpaces()
+		if f.pos >= len(f.s) {
+			return nil, fmt.Errorf("unterminated flow mapping")
+		}
+		if f.s[f.pos] == '}' {
+			f.pos++
+			return result, nil
+		}
+		key, err := f.valueUntil(":,}")
+		if err != nil {
+			return nil, err
+		}
+		f.skipSpaces()
+		if f.pos >= len(f.s) || f.s[f.pos] != ':' {
+			return nil, fmt.Errorf("expected ':' in flow mapping")
+		}
+		f.pos++
+		value, err := f.value()
+		if err != nil {
+			return nil, err
+		}
+		result[fmt.Sprint(key)] = value
+		f.skipSpaces()
+		if f.pos < len(f.s) && f.s[f.pos] == ',' {
+			f.pos++
+		}
+	}
+}
+
+func (f *flowParser) quoted() (interface{}, error) {
+	quote := f.s[f.pos]
+	end := f.pos + 1
+	for ; end < len(f.s); end++ {
+		if quote == '"' && f.s[end] == '\\' {
+			end++
+			continue
+		}
+		if f.s[end] == quote {
+			break
+		}
+	}
+	if end >= len(f.s) {
+		return nil, fmt.Errorf("unterminated string")
+	}
+	raw := f.s[f.pos : end+1]
+	f.pos = end + 1
+	if quote == '\'' {
+		return raw[1 : len(raw)-1], nil
+	}
+	return strconv.Unquote(raw)
+}
+
+// plainScalar maps a plain scalar into the type encoding/json would produce
+// for the equivalent JSON literal.
+func plainScalar(s string) interface{} {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
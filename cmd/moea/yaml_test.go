@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseYAML(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		input  string
+		output interface{}
+	}{
+		{
+			name:   "scalars",
+			input:  "a: 1\nb: two # comment\nc: 'x: y'\nd: \"q\\n\"\ne: true\nf: ~",
+			output: map[string]interface{}{"a": 1.0, "b": "two", "c": "x: y", "d": "q\n", "e": true, "f": nil},
+		},
+		{
+			name:  "nested",
+			input: "problem:\n  name: zdt1\n  bounds:\n  - [0, 1]\n  - [-5, 5]\nseed: 3\n",
+			output: map[string]interface{}{
+				"problem": map[string]interface{}{
+					"name":   "zdt1",
+					"bounds": []interface{}{[]interface{}{0.0, 1.0}, []interface{}{-5.0, 5.0}},
+				},
+				"seed": 3.0,
+			},
+		},
+		{
+			name:  "sequence of mappings",
+			input: "- name: a\n  bits: 3\n- name: b\n-\n  - 1\n",
+			output: []interface{}{
+				map[string]interface{}{"name": "a", "bits": 3.0},
+				map[string]interface{}{"name": "b"},
+				[]interface{}{1.0},
+			},
+		},
+		{
+			name:   "flow mapping",
+			input:  "encoding: {type: binary, bits: 16}",
+			output: map[string]interface{}{"encoding": map[string]interface{}{"type": "binary", "bits": 16.0}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := parseYAML([]byte(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.output, result); diff != "" {
+				t.Errorf("diff: %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for _, input := range []string{"a: [1, 2", "a: 1\na: 2", "just text", "a:\n  b: 1\n c: 2",
+		"a: &x 1", "a: *x", "a: !!str 1", "a: |\n  text", "a: 1\n---\nb: 2", "%YAML 1.2\na: 1", "? a\n: 1",
+		"a: text\n  continued"} {
+		if _, err := parseYAML([]byte(input)); err == nil {
+			t.Error("Expected error for", input)
+		}
+	}
+}
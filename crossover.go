@@ -0,0 +1,71 @@
+package moea
+
+// CrossoverOperator fills child1 and child2 from parent1 and parent2,
+// recombining them with the given probability and copying them otherwise.
+// It returns the crossover site, or -1 when the parents were copied.
+type CrossoverOperator interface {
+	Crossover(config *Config, parent1, parent2, child1, child2 Individual, probability float64) int
+}
+
+// OnePointCrossover swaps the tails of the parents after a random site. It
+// is the default crossover of the simple algorithm.
+type OnePointCrossover struct{}
+
+// TwoPointCrossover swaps the segment between two distinct random sites.
+// The first site is returned.
+type TwoPointCrossover struct{}
+
+// UniformCrossover swaps each position with probability Swap (0.5 by
+// default), up to the length of the shorter parent. It returns 0 when the
+// parents were recombined.
+type UniformCrossover struct{ Swap float64 }
+
+func (c *OnePointCrossover) Crossover(config *Config, parent1, parent2, child1, child2 Individual, probability float64) int {
+	return crossover(config, probability, parent1, parent2, child1, child2)
+}
+
+func (c *TwoPointCrossover) Crossover(config *Config, parent1, parent2, child1, child2 Individual, probability float64) int {
+	n := shortest(parent1, parent2)
+	if !config.RandomNumberGenerator.Flip(probability) || n < 3 {
+		CopyIndividual(child1, parent1)
+		CopyIndividual(child2, parent2)
+		return -1
+	}
+	cross1 := 1 + config.RandomNumberGenerator.Intn(n-1)
+	cross2 := 1 + config.RandomNumberGenerator.Intn(n-2)
+	if cross2 >= cross1 {
+		cross2++
+	}
+	if cross1 > cross2 {
+		cross1, cross2 = cross2, cross1
+	}
+	resize(child1, parent1.Len())
+	resize(child2, parent2.Len())
+	child1.Copy(parent1, 0, cross1)
+	child1.Copy(parent2, cross1, cross2)
+	child1.Copy(parent1, cross2, parent1.Len())
+	child2.Copy(parent2, 0, cross1)
+	child2.Copy(parent1, cross1, cross2)
+	child2.Copy(parent2, cross2, parent2.Len())
+	return cross1
+}
+
+func (c *UniformCrossover) Crossover(config *Config, parent1, parent2, child1, child2 Individual, probability float64) int {
+	CopyIndividual(child1, parent1)
+	CopyIndividual(child2, parent2)
+	if !config.RandomNumberGenerator.Flip(probability) {
+		return -1
+	}
+	swap := c.Swap
+	if swap <= 0 || swap >= 1 {
+		swap = 0.5
+	}
+	n := shortest(parent1, parent2)
+	for i := 0; i < n; i++ {
+		if config.RandomNumberGenerator.Flip(swap) {
+			child1.Copy(parent2, i, i+1)
+			child2.Copy(parent1, i, i+1)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,459 @@
+// Package emoea implements the steady-state ε-MOEA of Deb, Mohan and
+// Mishra (2005), which keeps the best solutions found in an archive under
+// ε-box dominance, optionally with the auto-adaptive features of the Borg
+// MOEA (Hadka and Reed, 2013).
+package emoea
+
+import (
+	"math"
+
+	"github.com/project-draco/moea"
+)
+
+type Options struct {
+	// Epsilons is the resolution of every objective in the archive, which
+	// keeps at most one solution per box of those sides. It is required.
+	Epsilons []float64
+	// Crossovers defaults to OnePointCrossover. With more than one, every
+	// offspring is bred by a crossover chosen with probability proportional
+	// to the number of archive members it produced, plus one.
+	Crossovers []moea.CrossoverOperator
+	// Mutation defaults to RegularMutation.
+	Mutation moea.MutationOperator
+	// ReportInterval is the number of evaluations each call to Generation
+	// makes. It defaults to the initial population size.
+	ReportInterval int
+	// RestartWindow is the number of evaluations between checks for
+	// ε-progress, the archive gaining a box. The population is restarted
+	// when there was none or when its size strayed by more than 25% from
+	// PopulationRatio (4 by default) times the archive size. Zero never
+	// restarts.
+	RestartWindow   int
+	PopulationRatio float64
+}
+
+type member struct {
+	individual moea.Individual
+	objective  []float64
+	operator   int
+}
+
+type epsilonMOEA struct {
+	options     Options
+	config      *moea.Config
+	population  []member
+	archive     []member
+	boxes       [][]float64
+	produced    []int
+	tournament  int
+	generation  int
+	evaluations int
+	lastCheck   int
+	progress    bool
+	child       moea.Individual
+	scratch     moea.Individual
+	result      *moea.Result
+}
+
+// individuals is a population of archive or population members.
+type individuals []moea.Individual
+
+// NewEpsilonMOEA returns the ε-MOEA. Every evaluation breeds a child of a
+// population member, chosen by a dominance tournament, and of a random
+// archive member. The child replaces a population member it dominates or,
+// if none dominates it either, a random one, and enters the archive unless
+// an archive member ε-box dominates it.
+func NewEpsilonMOEA(options Options) moea.Algorithm {
+	if len(options.Epsilons) == 0 {
+		panic("ε-MOEA needs the epsilons of the objectives")
+	}
+	for _, e := range options.Epsilons {
+		if e <= 0 {
+			panic("epsilons must be positive")
+		}
+	}
+	if len(options.Crossovers) == 0 {
+		options.Crossovers = []moea.CrossoverOperator{&moea.OnePointCrossover{}}
+	}
+	if options.Mutation == nil {
+		options.Mutation = &moea.RegularMutation{}
+	}
+	if options.PopulationRatio <= 0 {
+		options.PopulationRatio = 4
+	}
+	return &epsilonMOEA{options: options}
+}
+
+func (a *epsilonMOEA) Initialize(config *moea.Config) {
+	type initializer interface {
+		Initialize(*moea.Config)
+	}
+	if len(a.options.Epsilons) != config.NumberOfObjectives {
+		panic("there must be one epsilon per objective")
+	}
+	a.config = config
+	n := config.Population.Len()
+	if a.options.ReportInterval <= 0 {
+		a.options.ReportInterval = n
+	}
+	for _, operator := range append([]interface{}{a.options.Mutation}, crossovers(a.options.Crossovers)...) {
+		if i, ok := operator.(initializer); ok {
+			i.Initialize(config)
+		}
+	}
+	a.population, a.archive, a.boxes = nil, nil, nil
+	a.produced = make([]int, len(a.options.Crossovers))
+	a.tournament = 2
+	a.generation, a.evaluations, a.lastCheck, a.progress = 0, 0, 0, false
+	clone := config.Population.Clone()
+	for i := 0; i < n; i++ {
+		m := member{clone.Individual(i), config.ObjectiveFunc(clone.Individual(i)), -1}
+		a.evaluations++
+		a.population = append(a.population, m)
+		a.addToArchive(m)
+	}
+	a.child = config.Population.Individual(0).Clone()
+	a.scratch = config.Population.Individual(0).Clone()
+	a.result = &moea.Result{
+		AverageObjective: make([]float64, config.NumberOfObjectives),
+		WorstObjective:   make([]float64, config.NumberOfObjectives),
+		BestObjective:    make([]float64, config.NumberOfObjectives),
+	}
+}
+
+func (a *epsilonMOEA) Generation() (*moea.Result, error) {
+	a.result.Crossovers, a.result.Evaluations = 0, 0
+	if a.generation == 0 {
+		a.result.Evaluations = a.evaluations
+	}
+	a.generation++
+	for i := 0; i < a.options.ReportInterval; i++ {
+		if a.config.MaxEvaluations > 0 && a.evaluations >= a.config.MaxEvaluations {
+			break
+		}
+		a.step()
+		if a.options.RestartWindow > 0 && a.evaluations-a.lastCheck >= a.options.RestartWindow {
+			a.check()
+		}
+	}
+	a.summarize()
+	return a.result, nil
+}
+
+func (a *epsilonMOEA) step() {
+	operator := a.chooseOperator()
+	parent1 := a.population[a.select1()].individual
+	parent2 := a.archive[a.config.RandomNumberGenerator.Intn(len(a.archive))].individual
+	if a.options.Crossovers[operator].Crossover(a.config, parent1, parent2, a.child, a.scratch,
+		a.config.CrossoverProbability) >= 0 {
+		a.result.Crossovers++
+	}
+	a.options.Mutation.Mutation(a.config, a.child, a.config.MutationProbability)
+	a.evaluate(a.child, operator)
+}
+
+// evaluate offers a copy of individual, bred by operator, to the population
+// and to the archive.
+func (a *epsilonMOEA) evaluate(individual moea.Individual, operator int) {
+	objective := a.config.ObjectiveFunc(individual)
+	a.evaluations++
+	a.result.Evaluations++
+	i := a.replacement(objective)
+	if i < 0 && !a.archiveAccepts(objective) {
+		return
+	}
+	m := member{individual.Clone(), objective, operator}
+	if i >= 0 {
+		a.population[i] = m
+	}
+	a.addToArchive(m)
+}
+
+// chooseOperator spins the crossovers with probabilities proportional to
+// the archive members they produced, plus one.
+func (a *epsilonMOEA) chooseOperator() int {
+	if len(a.produced) == 1 {
+		return 0
+	}
+	probabilities := a.probabilities()
+	r := a.config.RandomNumberGenerator.Float64()
+	for i, p := range probabilities {
+		if r < p {
+			return i
+		}
+		r -= p
+	}
+	return len(probabilities) - 1
+}
+
+func (a *epsilonMOEA) probabilities() []float64 {
+	for i := range a.produced {
+		a.produced[i] = 0
+	}
+	for _, m := range a.archive {
+		if m.operator >= 0 {
+			a.produced[m.operator]++
+		}
+	}
+	result := make([]float64, len(a.produced))
+	sum := 0.0
+	for i, c := range a.produced {
+		result[i] = float64(c) + 1
+		sum += result[i]
+	}
+	for i := range result {
+		result[i] /= sum
+	}
+	return result
+}
+
+// select1 runs a tournament in which a challenger wins if it dominates the
+// current winner, and with even odds if neither dominates the other.
+func (a *epsilonMOEA) select1() int {
+	rng := a.config.RandomNumberGenerator
+	winner := rng.Intn(len(a.population))
+	for i := 1; i < a.tournament; i++ {
+		challenger := rng.Intn(len(a.population))
+		w, c := a.population[winner].objective, a.population[challenger].objective
+		if moea.Dominates(c, w) || !moea.Dominates(w, c) && rng.Flip(0.5) {
+			winner = challenger
+		}
+	}
+	return winner
+}
+
+// replacement returns the population member the child replaces: a random
+// one among those it dominates, or -1 if a member dominates the child, or
+// otherwise a random one.
+func (a *epsilonMOEA) replacement(objective []float64) int {
+	var dominated []int
+	dominatedBy := false
+	for i, m := range a.population {
+		if moea.Dominates(objective, m.objective) {
+			dominated = append(dominated, i)
+		} else if moea.Dominates(m.objective, objective) {
+			dominatedBy = true
+		}
+	}
+	if len(dominated) > 0 {
+		return dominated[a.config.RandomNumberGenerator.Intn(len(dominated))]
+	} else if dominatedBy {
+		return -1
+	}
+	return a.config.RandomNumberGenerator.Intn(len(a.population))
+}
+
+// archiveAccepts reports whether addToArchive would keep objective.
+func (a *epsilonMOEA) archiveAccepts(objective []float64) bool {
+	box := a.box(objective)
+	for i, m := range a.archive {
+		switch boxDominance(box, a.boxes[i]) {
+		case -1:
+			return false
+		case 0:
+			return a.sameBoxWins(objective, m.objective, box)
+		}
+	}
+	return true
+}
+
+// addToArchive adds m to the archive unless an archive member ε-box
+// dominates it, removing the members it ε-box dominates. Within a box, the
+// member that dominates the other or, if none does, the one closer to the
+// corner of the box is kept. Occupying a new box is ε-progress. Since the
+// archived boxes do not dominate each other, a box that dominates some of
+// them is neither dominated by nor equal to any.
+func (a *epsilonMOEA) addToArchive(m member) {
+	box := a.box(m.objective)
+	k := 0
+	for i, other := range a.archive {
+		switch boxDominance(box, a.boxes[i]) {
+		case -1:
+			return
+		case 0:
+			if a.sameBoxWins(m.objective, other.objective, box) {
+				a.archive[i] = m
+			}
+			return
+		case 2:
+			a.archive[k], a.boxes[k] = other, a.boxes[i]
+			k++
+		}
+	}
+	a.archive, a.boxes = append(a.archive[:k], m), append(a.boxes[:k], box)
+	a.progress = true
+}
+
+func (a *epsilonMOEA) sameBoxWins(objective, other, box []float64) bool {
+	if moea.Dominates(objective, other) {
+		return true
+	} else if moea.Dominates(other, objective) {
+		return false
+	}
+	return a.cornerDistance(objective, box) < a.cornerDistance(other, box)
+}
+
+func (a *epsilonMOEA) box(objective []float64) []float64 {
+	result := make([]float64, len(objective))
+	for j, f := range objective {
+		result[j] = math.Floor(f / a.options.Epsilons[j])
+	}
+	return result
+}
+
+func (a *epsilonMOEA) cornerDistance(objective, box []float64) float64 {
+	sum := 0.0
+	for j, f := range objective {
+		d := (f - box[j]*a.options.Epsilons[j]) / a.options.Epsilons[j]
+		sum += d * d
+	}
+	return sum
+}
+
+// boxDominance returns 1 when box a dominates box b, -1 when b dominates a,
+// 0 when they are the same box and 2 otherwise.
+func boxDominance(a, b []float64) int {
+	better, worse := false, false
+	for j := range a {
+		if a[j] < b[j] {
+			better = true
+		} else if a[j] > b[j] {
+			worse = true
+		}
+	}
+	switch {
+	case better && !worse:
+		return 1
+	case worse && !better:
+		return -1
+	case !better && !worse:
+		return 0
+	}
+	return 2
+}
+
+// check restarts the population when the last window made no ε-progress or
+// the population size is off its ratio to the archive size.
+func (a *epsilonMOEA) check() {
+	target := a.options.PopulationRatio * float64(len(a.archive))
+	ratio := float64(len(a.population)) / target
+	if !a.progress || ratio < 0.75 || ratio > 1.25 {
+		a.restart()
+	}
+	a.lastCheck, a.progress = a.evaluations, false
+}
+
+// restart resizes the population to PopulationRatio times the archive, at
+// least 4, fills it with the archive and then with archive members mutated
+// with probability 1/length, and sizes the tournaments to 2% of it.
+func (a *epsilonMOEA) restart() {
+	size := int(a.options.PopulationRatio * float64(len(a.archive)))
+	if size < 4 {
+		size = 4
+	}
+	a.population = a.population[:0]
+	for i := 0; i < len(a.archive) && i < size; i++ {
+		a.population = append(a.population, member{a.archive[i].individual.Clone(), a.archive[i].objective, -1})
+	}
+	for len(a.population) < size {
+		if a.config.MaxEvaluations > 0 && a.evaluations >= a.config.MaxEvaluations {
+			for i := 0; len(a.population) < size; i++ {
+				a.population = append(a.population, a.population[i])
+			}
+			break
+		}
+		m := a.archive[a.config.RandomNumberGenerator.Intn(len(a.archive))]
+		individual := m.individual.Clone()
+		a.options.Mutation.Mutation(a.config, individual, 1/float64(individual.Len()))
+		objective := a.config.ObjectiveFunc(individual)
+		a.evaluations++
+		a.result.Evaluations++
+		m = member{individual, objective, -1}
+		a.population = append(a.population, m)
+		a.addToArchive(m)
+	}
+	a.tournament = int(0.02 * float64(size))
+	if a.tournament < 2 {
+		a.tournament = 2
+	}
+}
+
+func (a *epsilonMOEA) summarize() {
+	for j := 0; j < a.config.NumberOfObjectives; j++ {
+		a.result.BestObjective[j] = math.MaxFloat64
+		a.result.WorstObjective[j] = -math.MaxFloat64
+		a.result.AverageObjective[j] = 0
+	}
+	for i, m := range a.population {
+		if m.objective[0] < a.result.BestObjective[0] {
+			a.result.BestIndividual = m.individual
+			a.result.BestIndividualIndex = i
+		}
+		for j := 0; j < a.config.NumberOfObjectives; j++ {
+			a.result.BestObjective[j] = math.Min(a.result.BestObjective[j], m.objective[j])
+			a.result.WorstObjective[j] = math.Max(a.result.WorstObjective[j], m.objective[j])
+			a.result.AverageObjective[j] += m.objective[j] / float64(len(a.population))
+		}
+	}
+	a.result.Individuals = a.results(a.population)
+	a.result.Archive = a.results(a.archive)
+	a.result.CrossoverOperatorProbabilities = nil
+	if len(a.produced) > 1 {
+		a.result.CrossoverOperatorProbabilities = a.probabilities()
+	}
+}
+
+func (a *epsilonMOEA) results(members []member) []moea.IndividualResult {
+	result := make([]moea.IndividualResult, len(members))
+	for i, m := range members {
+		values := moea.RecordValues(a.config, m.individual, nil)
+		result[i] = moea.IndividualResult{Objective: m.objective, Parent1: -1, Parent2: -1, CrossSite: -1, Values: values}
+	}
+	return result
+}
+
+// Finalize reports the final archive and lets the operators add to the
+// result.
+func (a *epsilonMOEA) Finalize(result *moea.Result) {
+	type finalizer interface {
+		Finalize(*moea.Config, moea.Population, [][]float64, *moea.Result)
+	}
+	result.Archive = a.results(a.archive)
+	population, objectives := a.CurrentPopulation(a.config)
+	for _, operator := range append([]interface{}{a.options.Mutation}, crossovers(a.options.Crossovers)...) {
+		if f, ok := operator.(finalizer); ok {
+			f.Finalize(a.config, population, objectives, result)
+		}
+	}
+}
+
+// CurrentPopulation returns the archive, which holds the front found so
+// far.
+func (a *epsilonMOEA) CurrentPopulation(config *moea.Config) (moea.Population, [][]float64) {
+	population := make(individuals, len(a.archive))
+	objectives := make([][]float64, len(a.archive))
+	for i, m := range a.archive {
+		population[i], objectives[i] = m.individual, m.objective
+	}
+	return population, objectives
+}
+
+func crossovers(operators []moea.CrossoverOperator) []interface{} {
+	result := make([]interface{}, len(operators))
+	for i, c := range operators {
+		result[i] = c
+	}
+	return result
+}
+
+func (p individuals) Len() int { return len(p) }
+
+func (p individuals) Individual(i int) moea.Individual { return p[i] }
+
+func (p individuals) Clone() moea.Population {
+	result := make(individuals, len(p))
+	for i, individual := range p {
+		result[i] = individual.Clone()
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package emoea
+
+import (
+	"math"
+	"testing"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/binary"
+	"github.com/project-draco/moea/internal/fixture"
+)
+
+func schConfig(options Options, seed uint32) *moea.Config {
+	config := fixture.SCH(NewEpsilonMOEA(options), seed)
+	config.MaxGenerations, config.MaxEvaluations = 0, 4000
+	return config
+}
+
+func TestArchive(t *testing.T) {
+	epsilons := []float64{500, 500}
+	result, err := moea.Run(schConfig(Options{Epsilons: epsilons}, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Evaluations != 4000 {
+		t.Error("Expected 4000 evaluations but was", result.Evaluations)
+	}
+	if len(result.Archive) < 10 {
+		t.Fatal("Expected the archive to spread over the front, got", len(result.Archive), "members")
+	}
+	boxes := map[[2]float64]bool{}
+	for i, a := range result.Archive {
+		x := a.Values[0].(binary.BinaryString).Int().Int64()
+		if x > 100 {
+			t.Error("Expected a Pareto optimal archive, found", x)
+		}
+		box := [2]float64{math.Floor(a.Objective[0] / epsilons[0]), math.Floor(a.Objective[1] / epsilons[1])}
+		if boxes[box] {
+			t.Error("Expected one member per box, found two in", box)
+		}
+		boxes[box] = true
+		for _, b := range result.Archive[i+1:] {
+			if moea.Dominates(a.Objective, b.Objective) || moea.Dominates(b.Objective, a.Objective) {
+				t.Error("Expected a nondominated archive, found", a.Objective, b.Objective)
+			}
+		}
+	}
+}
+
+func TestBorg(t *testing.T) {
+	options := Options{
+		Epsilons:       []float64{500, 500},
+		Crossovers:     []moea.CrossoverOperator{&moea.OnePointCrossover{}, &moea.UniformCrossover{}},
+		RestartWindow:  200,
+		ReportInterval: 200,
+	}
+	config := schConfig(options, 5)
+	var sizes []int
+	config.OnGenerationFunc = func(generation int, result *moea.Result) {
+		sizes = append(sizes, len(result.Individuals))
+		sum := 0.0
+		for _, p := range result.CrossoverOperatorProbabilities {
+			sum += p
+		}
+		if len(result.CrossoverOperatorProbabilities) != 2 || math.Abs(sum-1) > 1e-9 {
+			t.Error("Expected the probabilities of both crossovers, got", result.CrossoverOperatorProbabilities)
+		}
+	}
+	result, err := moea.Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sizes[len(sizes)-1] == 20 {
+		t.Error("Expected the population resized by restarts, got sizes", sizes)
+	}
+	if ratio := float64(len(result.Individuals)) / float64(len(result.Archive)); ratio < 3 || ratio > 5 {
+		t.Error("Expected the population about 4 times the archive, got", len(result.Individuals), len(result.Archive))
+	}
+}
+
+func TestSameSeedSameResult(t *testing.T) {
+	fixture.SameSeedSameResult(t, func(seed uint32) *moea.Config {
+		return schConfig(Options{Epsilons: []float64{100, 100}, RestartWindow: 100}, seed)
+	}, 7)
+}
+
+func TestEpsilonsRequired(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic without epsilons")
+		}
+	}()
+	NewEpsilonMOEA(Options{})
+}
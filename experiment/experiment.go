@@ -0,0 +1,148 @@
+package experiment
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/indicator"
+)
+
+type Algorithm struct {
+	Name string
+	// Config returns a fresh configuration for one run. Every run gets its own
+	// seed, and the same seeds are used for every algorithm.
+	Config func(problem *Problem, seed uint32) *moea.Config
+}
+
+type Problem struct {
+	Name string
+	// ReferenceFront is used by the distance-based indicators.
+	ReferenceFront [][]float64
+	// ReferencePoint bounds the hypervolume.
+	ReferencePoint []float64
+}
+
+type Indicator struct {
+	Name string
+	// Maximize tells whether higher values are better.
+	Maximize bool
+	Compute  func(front [][]float64, problem *Problem) float64
+}
+
+var (
+	Hypervolume = Indicator{"HV", true, func(front [][]float64, p *Problem) float64 {
+		return indicator.Hypervolume(front, p.ReferencePoint)
+	}}
+	IGD = Indicator{"IGD", false, func(front [][]float64, p *Problem) float64 {
+		return indicator.InvertedGenerationalDistance(front, p.ReferenceFront)
+	}}
+	GD = Indicator{"GD", false, func(front [][]float64, p *Problem) float64 {
+		return indicator.GenerationalDistance(front, p.ReferenceFront)
+	}}
+	Epsilon = Indicator{"EPS", false, func(front [][]float64, p *Problem) float64 {
+		return indicator.AdditiveEpsilon(front, p.ReferenceFront)
+	}}
+	Spacing = Indicator{"SP", false, func(front [][]float64, _ *Problem) float64 {
+		return indicator.Spacing(front)
+	}}
+)
+
+type Experiment struct {
+	Algorithms []Algorithm
+	Problems   []Problem
+	Indicators []Indicator
+	Runs       int
+	// Seed is the seed of the first run; run i uses Seed+i.
+	Seed uint32
+	// Workers defaults to GOMAXPROCS.
+	Workers int
+	// Significance is passed on to the results, 0.05 by default.
+	Significance float64
+}
+
+type Results struct {
+	Algorithms []string
+	Problems   []string
+	Indicators []Indicator
+	// Values[problem][algorithm][indicator][run]
+	Values [][][][]float64
+	// Significance is the level, after Holm correction, at which
+	// comparisons are reported as significant, 0.05 by default.
+	Significance float64
+}
+
+type job struct {
+	problem, algorithm, run int
+}
+
+func (e *Experiment) Run() (*Results, error) {
+	if e.Runs < 1 {
+		return nil, fmt.Errorf("experiment needs at least one run")
+	}
+	results := &Results{Indicators: e.Indicators, Significance: e.Significance}
+	for _, a := range e.Algorithms {
+		results.Algorithms = append(results.Algorithms, a.Name)
+	}
+	results.Values = make([][][][]float64, len(e.Problems))
+	for p, problem := range e.Problems {
+		results.Problems = append(results.Problems, problem.Name)
+		results.Values[p] = make([][][]float64, len(e.Algorithms))
+		for a := range e.Algorithms {
+			results.Values[p][a] = make([][]float64, len(e.Indicators))
+			for i := range e.Indicators {
+				results.Values[p][a][i] = make([]float64, e.Runs)
+			}
+		}
+	}
+	workers := e.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if err := e.run(j, results); err != nil {
+					once.Do(func() { firstErr = err })
+				}
+			}
+		}()
+	}
+	for p := range e.Problems {
+		for a := range e.Algorithms {
+			for r := 0; r < e.Runs; r++ {
+				jobs <- job{p, a, r}
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+func (e *Experiment) run(j job, results *Results) error {
+	problem := &e.Problems[j.problem]
+	algorithm := e.Algorithms[j.algorithm]
+	result, err := moea.Run(algorithm.Config(problem, e.Seed+uint32(j.run)))
+	if err != nil {
+		return fmt.Errorf("%s on %s, run %d: %v", algorithm.Name, problem.Name, j.run, err)
+	}
+	var front [][]float64
+	for _, individual := range result.ParettoFrontier() {
+		front = append(front, individual.Objective)
+	}
+	for i, ind := range e.Indicators {
+		results.Values[j.problem][j.algorithm][i][j.run] = ind.Compute(front, problem)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package experiment
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/binary"
+	"github.com/project-draco/moea/nsgaii"
+)
+
+func zdt1(individual moea.Individual) []float64 {
+	x := make([]float64, 3)
+	for i := range x {
+		x[i] = float64(individual.Value(i).(binary.BinaryString).Int().Int64()) / 255
+	}
+	g := 1 + 9*(x[1]+x[2])/2
+	return []float64{x[0], g * (1 - math.Sqrt(x[0]/g))}
+}
+
+func config(mutation moea.MutationOperator) func(*Problem, uint32) *moea.Config {
+	return func(_ *Problem, seed uint32) *moea.Config {
+		rng := moea.NewXorshiftWithSeed(seed)
+		return &moea.Config{
+			Algorithm:             moea.NewSimpleAlgorithm(&nsgaii.NsgaIISelection{}, mutation),
+			Population:            binary.NewRandomBinaryPopulation(20, []int{8, 8, 8}, nil, rng),
+			NumberOfValues:        3,
+			NumberOfObjectives:    2,
+			ObjectiveFunc:         zdt1,
+			MaxGenerations:        10,
+			CrossoverProbability:  0.9,
+			MutationProbability:   1.0 / 24,
+			RandomNumberGenerator: rng,
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	e := &Experiment{
+		Algorithms: []Algorithm{
+			{"regular", func(p *Problem, seed uint32) *moea.Config { return config(&moea.RegularMutation{})(p, seed) }},
+			{"fast", func(p *Problem, seed uint32) *moea.Config { return config(&moea.FastMutation{})(p, seed) }},
+		},
+		Problems:   []Problem{{Name: "zdt1", ReferencePoint: []float64{1.1, 11}, ReferenceFront: [][]float64{{0, 1}, {1, 0}}}},
+		Indicators: []Indicator{Hypervolume, IGD},
+		Runs:       4,
+		Seed:       1,
+	}
+	results, err := e.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for a := range e.Algorithms {
+		for i := range e.Indicators {
+			for run, v := range results.Values[0][a][i] {
+				if v == 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+					t.Error("Unexpected value", v, "for run", run)
+				}
+			}
+		}
+	}
+	if c := results.Comparisons(); len(c) != 2 {
+		t.Error("Expected 2 comparisons but was", len(c))
+	}
+	var b bytes.Buffer
+	if err := results.WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(b.String(), "\n"); n != 1+2*2*4 {
+		t.Error("Expected 17 lines but was", n)
+	}
+}
+
+func TestComparisons(t *testing.T) {
+	results := &Results{
+		Algorithms: []string{"a", "b", "c"},
+		Problems:   []string{"p"},
+		Indicators: []Indicator{IGD, Hypervolume},
+		Values: [][][][]float64{{
+			{{1, 2, 3, 4, 5, 6, 7, 8}, {1, 2, 3, 4, 5, 6, 7, 8}},
+			{{11, 12, 13, 14, 15, 16, 17, 18}, {11, 12, 13, 14, 15, 16, 17, 18}},
+			{{1, 2, 3, 4, 5, 6, 7, 8.5}, {1, 2, 3, 4, 5, 6, 7, 8.5}},
+		}},
+	}
+	winners := map[string]string{}
+	for _, c := range results.Comparisons() {
+		winners[c.Indicator+c.A+c.B] = c.Winner
+	}
+	for key, winner := range map[string]string{
+		"IGDab": "a", "IGDac": "", "IGDbc": "c",
+		"HVab": "b", "HVac": "", "HVbc": "b",
+	} {
+		if winners[key] != winner {
+			t.Error("Expected winner", winner, "for", key, "but was", winners[key])
+		}
+	}
+	strict := *results
+	strict.Significance = 1e-6
+	for _, c := range strict.Comparisons() {
+		if c.Winner != "" {
+			t.Error("Expected no winner at a significance of 1e-6 but was", c.Winner, "for", c.Indicator+c.A+c.B)
+		}
+	}
+	var b bytes.Buffer
+	if err := results.WriteMarkdown(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "| p | **4.5 (3.5)** |") {
+		t.Error("Best median must be bold:", b.String())
+	}
+	b.Reset()
+	if err := results.WriteLaTeX(&b); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(b.String(), `\begin{tabular}`) != 2 {
+		t.Error("Expected one table per indicator")
+	}
+}
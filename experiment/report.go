@@ -0,0 +1,196 @@
+package experiment
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/project-draco/moea/stats"
+)
+
+type Summary struct {
+	Problem, Algorithm, Indicator string
+	Median, IQR                   float64
+}
+
+type Comparison struct {
+	Problem, Indicator string
+	A, B               string
+	Z                  float64
+	PValue             float64
+	AdjustedPValue     float64
+	// Winner is A, B or empty when the difference is not significant.
+	Winner string
+}
+
+func (r *Results) Summaries() []Summary {
+	var result []Summary
+	for p, problem := range r.Problems {
+		for a, algorithm := range r.Algorithms {
+			for i, ind := range r.Indicators {
+				v := r.Values[p][a][i]
+				result = append(result, Summary{problem, algorithm, ind.Name, stats.Median(v), stats.IQR(v)})
+			}
+		}
+	}
+	return result
+}
+
+// Comparisons runs a rank-sum test for every pair of algorithms on every
+// problem and indicator. P-values are Holm-corrected within each
+// problem and indicator.
+func (r *Results) Comparisons() []Comparison {
+	var result []Comparison
+	for p, problem := range r.Problems {
+		for i, ind := range r.Indicators {
+			start := len(result)
+			var pvalues []float64
+			for a := 0; a < len(r.Algorithms); a++ {
+				for b := a + 1; b < len(r.Algorithms); b++ {
+					z, pvalue := stats.RankSum(r.Values[p][a][i], r.Values[p][b][i])
+					result = append(result, Comparison{
+						Problem: problem, Indicator: ind.Name,
+						A: r.Algorithms[a], B: r.Algorithms[b],
+						Z: z, PValue: pvalue,
+					})
+					pvalues = append(pvalues, pvalue)
+				}
+			}
+			for k, adjusted := range stats.Holm(pvalues) {
+				c := &result[start+k]
+				c.AdjustedPValue = adjusted
+				if adjusted < r.significance() && c.Z != 0 {
+					if (c.Z > 0) == ind.Maximize {
+						c.Winner = c.A
+					} else {
+						c.Winner = c.B
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+func (r *Results) significance() float64 {
+	if r.Significance <= 0 {
+		return 0.05
+	}
+	return r.Significance
+}
+
+// best returns, for each problem, the index of the algorithm with the best
+// median of indicator i.
+func (r *Results) best(i int) []int {
+	result := make([]int, len(r.Problems))
+	for p := range r.Problems {
+		bestValue := math.NaN()
+		for a := range r.Algorithms {
+			m := stats.Median(r.Values[p][a][i])
+			if math.IsNaN(bestValue) || (r.Indicators[i].Maximize && m > bestValue) ||
+				(!r.Indicators[i].Maximize && m < bestValue) {
+				bestValue = m
+				result[p] = a
+			}
+		}
+	}
+	return result
+}
+
+func (r *Results) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	comparisons := r.Comparisons()
+	for i, ind := range r.Indicators {
+		direction := "lower is better"
+		if ind.Maximize {
+			direction = "higher is better"
+		}
+		fmt.Fprintf(&b, "## %s (%s)\n\nMedian (IQR); best median in bold.\n\n| Problem |", ind.Name, direction)
+		for _, a := range r.Algorithms {
+			fmt.Fprintf(&b, " %s |", a)
+		}
+		b.WriteString("\n|---|")
+		b.WriteString(strings.Repeat("---|", len(r.Algorithms)))
+		b.WriteString("\n")
+		best := r.best(i)
+		for p, problem := range r.Problems {
+			fmt.Fprintf(&b, "| %s |", problem)
+			for a := range r.Algorithms {
+				cell := fmt.Sprintf("%.4g (%.2g)", stats.Median(r.Values[p][a][i]), stats.IQR(r.Values[p][a][i]))
+				if a == best[p] {
+					cell = "**" + cell + "**"
+				}
+				fmt.Fprintf(&b, " %s |", cell)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n| Problem | A | B | z | p | p (Holm) | Winner |\n|---|---|---|---|---|---|---|\n")
+		for _, c := range comparisons {
+			if c.Indicator != ind.Name {
+				continue
+			}
+			winner := c.Winner
+			if winner == "" {
+				winner = "–"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %.3f | %.3g | %.3g | %s |\n",
+				c.Problem, c.A, c.B, c.Z, c.PValue, c.AdjustedPValue, winner)
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (r *Results) WriteLaTeX(w io.Writer) error {
+	var b strings.Builder
+	for i, ind := range r.Indicators {
+		fmt.Fprintf(&b, "\\begin{table}\n\\centering\n\\caption{%s: median and IQR}\n", latexEscape(ind.Name))
+		fmt.Fprintf(&b, "\\begin{tabular}{l%s}\n\\hline\nProblem", strings.Repeat("c", len(r.Algorithms)))
+		for _, a := range r.Algorithms {
+			fmt.Fprintf(&b, " & %s", latexEscape(a))
+		}
+		b.WriteString(" \\\\\n\\hline\n")
+		best := r.best(i)
+		for p, problem := range r.Problems {
+			b.WriteString(latexEscape(problem))
+			for a := range r.Algorithms {
+				cell := fmt.Sprintf("%.4g_{%.2g}", stats.Median(r.Values[p][a][i]), stats.IQR(r.Values[p][a][i]))
+				if a == best[p] {
+					cell = "\\mathbf{" + cell + "}"
+				}
+				fmt.Fprintf(&b, " & $%s$", cell)
+			}
+			b.WriteString(" \\\\\n")
+		}
+		b.WriteString("\\hline\n\\end{tabular}\n\\end{table}\n\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteCSV writes one line per run and indicator.
+func (r *Results) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"problem", "algorithm", "run", "indicator", "value"})
+	for p, problem := range r.Problems {
+		for a, algorithm := range r.Algorithms {
+			for i, ind := range r.Indicators {
+				for run, v := range r.Values[p][a][i] {
+					writer.Write([]string{problem, algorithm, strconv.Itoa(run), ind.Name,
+						strconv.FormatFloat(v, 'g', -1, 64)})
+				}
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func latexEscape(s string) string {
+	return strings.NewReplacer(`\`, `\textbackslash{}`, "_", `\_`, "%", `\%`, "&", `\&`, "#", `\#`,
+		"$", `\$`, "{", `\{`, "}", `\}`).Replace(s)
+}
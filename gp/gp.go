@@ -0,0 +1,138 @@
+// Package gp implements tree-based genetic programming. Individuals hold a
+// typed expression Tree: Len is its number of nodes, Value(i) the subtree
+// rooted at the i-th node in prefix order, so Value(0) is the whole tree,
+// and Mutate replaces the given nodes by random primitives of the same
+// signature (point mutation). Trees are immutable, so the values recorded
+// in results stay valid.
+//
+// Individuals are only copied whole; use SubtreeCrossover rather than the
+// position-based crossovers of moea.
+package gp
+
+import (
+	"fmt"
+
+	"github.com/project-draco/moea"
+)
+
+type Options struct {
+	// MinDepth and MaxDepth bound the depths of the initial trees, 2 and 6
+	// by default.
+	MinDepth, MaxDepth int
+	// DepthLimit and SizeLimit control bloat: offspring deeper or larger
+	// are discarded in favour of their parent. DepthLimit defaults to 17
+	// and SizeLimit to no limit.
+	DepthLimit, SizeLimit int
+}
+
+type population []*individual
+
+type individual struct {
+	tree    *Tree
+	set     *PrimitiveSet
+	options *Options
+	rng     moea.RNG
+}
+
+// NewRandomTreePopulation initialises size trees, rounded up to an even
+// number, by ramped half-and-half: the depths are spread evenly from
+// MinDepth to MaxDepth and, at each depth, half the trees are full and half
+// are grown.
+func NewRandomTreePopulation(size int, set *PrimitiveSet, options Options, rng moea.RNG) moea.Population {
+	set.validate()
+	if options.MinDepth <= 0 {
+		options.MinDepth = 2
+	}
+	if options.MaxDepth <= 0 {
+		options.MaxDepth = 6
+	}
+	if options.DepthLimit <= 0 {
+		options.DepthLimit = 17
+	}
+	if options.MinDepth > options.MaxDepth || options.MaxDepth > options.DepthLimit {
+		panic(fmt.Sprintf("Invalid depths [%d, %d] with limit %d", options.MinDepth, options.MaxDepth, options.DepthLimit))
+	}
+	if size%2 == 1 {
+		size++
+	}
+	depths := options.MaxDepth - options.MinDepth + 1
+	result := make(population, size)
+	for i := range result {
+		depth := options.MinDepth + i/2%depths
+		nodes := generate(set, set.Root, depth, i%2 == 0, rng, nil)
+		result[i] = &individual{&Tree{nodes}, set, &options, rng}
+	}
+	return result
+}
+
+// TreeOf returns the expression of a gp individual.
+func TreeOf(ind moea.Individual) *Tree {
+	return ind.(*individual).tree
+}
+
+func (p population) Len() int { return len(p) }
+
+func (p population) Individual(i int) moea.Individual { return p[i] }
+
+func (p population) Clone() moea.Population {
+	result := make(population, len(p))
+	for i, individual := range p {
+		clone := *individual
+		result[i] = &clone
+	}
+	return result
+}
+
+func (ind *individual) Len() int { return ind.tree.Size() }
+
+func (ind *individual) Value(i int) interface{} {
+	if i == 0 {
+		return ind.tree
+	}
+	return ind.tree.subtree(i)
+}
+
+func (ind *individual) Copy(other moea.Individual, start, end int) {
+	o := other.(*individual)
+	if start != 0 || end != o.Len() {
+		panic("Trees can only be copied whole")
+	}
+	ind.tree = o.tree
+}
+
+func (ind *individual) Mutate(mutations []int) {
+	if len(mutations) == 0 {
+		return
+	}
+	nodes := append([]node(nil), ind.tree.nodes...)
+	for _, m := range mutations {
+		p := nodes[m].primitive
+		primitives := ind.set.Terminals
+		if p.kind == function {
+			primitives = ind.set.Functions
+		}
+		var candidates []*Primitive
+		for _, c := range primitives {
+			if c != p && sameSignature(c, p) || c == p && c.kind == ephemeral {
+				candidates = append(candidates, c)
+			}
+		}
+		if len(candidates) > 0 {
+			nodes[m] = newNode(candidates[ind.rng.Intn(len(candidates))], ind.rng)
+		}
+	}
+	ind.tree = &Tree{nodes}
+}
+
+func (ind *individual) Clone() moea.Individual {
+	clone := *ind
+	return &clone
+}
+
+// withinLimits reports whether tree respects the bloat limits.
+func (ind *individual) withinLimits(tree *Tree) bool {
+	if ind.options.SizeLimit > 0 && tree.Size() > ind.options.SizeLimit {
+		return false
+	}
+	return tree.Depth() <= ind.options.DepthLimit
+}
@@ -0,0 +1,206 @@
+package gp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/nsgaii"
+)
+
+const Bool Type = "bool"
+
+// typedSet mixes float and bool expressions through comparisons and
+// conditionals.
+func typedSet() *PrimitiveSet {
+	set := ArithmeticSet(1)
+	set.Functions = append(set.Functions,
+		NewFunction("<", Bool, []Type{Float, Float}, func(a []interface{}) interface{} { return a[0].(float64) < a[1].(float64) }),
+		NewFunction("and", Bool, []Type{Bool, Bool}, func(a []interface{}) interface{} { return a[0].(bool) && a[1].(bool) }),
+		NewFunction("if", Float, []Type{Bool, Float, Float}, func(a []interface{}) interface{} {
+			if a[0].(bool) {
+				return a[1]
+			}
+			return a[2]
+		}))
+	set.Terminals = append(set.Terminals, NewConstant("true", Bool, true))
+	return set
+}
+
+// checkTypes returns the index following the well typed subtree at i.
+func checkTypes(t *testing.T, tree *Tree, i int, typ Type) int {
+	n := tree.nodes[i]
+	if n.primitive.Type != typ {
+		t.Fatalf("%v: %s returns %s instead of %s", tree, n.primitive.Name, n.primitive.Type, typ)
+	}
+	next := i + 1
+	for _, arg := range n.primitive.Args {
+		next = checkTypes(t, tree, next, arg)
+	}
+	return next
+}
+
+func checkTree(t *testing.T, tree *Tree, options Options) {
+	if checkTypes(t, tree, 0, Float) != tree.Size() {
+		t.Fatalf("%v has extra nodes", tree)
+	}
+	if tree.Depth() > options.DepthLimit || options.SizeLimit > 0 && tree.Size() > options.SizeLimit {
+		t.Fatalf("%v exceeds the limits", tree)
+	}
+}
+
+func TestTree(t *testing.T) {
+	set := typedSet()
+	plus, times, less, cond := set.Functions[0], set.Functions[2], set.Functions[4], set.Functions[6]
+	x := set.Terminals[0]
+	tree := &Tree{[]node{{primitive: cond}, {primitive: less}, {primitive: x}, {primitive: set.Terminals[1], value: 0.5},
+		{primitive: plus}, {primitive: x}, {primitive: x}, {primitive: times}, {primitive: x}, {primitive: x}}}
+	if tree.String() != "(if (< x0 0.5) (+ x0 x0) (* x0 x0))" {
+		t.Errorf("unexpected expression %v", tree)
+	}
+	if tree.Size() != 10 || tree.Depth() != 2 {
+		t.Errorf("expected size 10 and depth 2 but were %v and %v", tree.Size(), tree.Depth())
+	}
+	if tree.Eval(0.25) != 0.5 || tree.Eval(3.0) != 9.0 {
+		t.Errorf("unexpected values %v and %v", tree.Eval(0.25), tree.Eval(3.0))
+	}
+	if s := tree.subtree(4).String(); s != "(+ x0 x0)" {
+		t.Errorf("unexpected subtree %v", s)
+	}
+}
+
+func TestRampedHalfAndHalf(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(1)
+	options := Options{MinDepth: 2, MaxDepth: 5, DepthLimit: 17}
+	population := NewRandomTreePopulation(99, typedSet(), options, rng)
+	if population.Len() != 100 {
+		t.Errorf("expected an even population but was %v", population.Len())
+	}
+	depths := map[int]int{}
+	for i := 0; i < population.Len(); i++ {
+		tree := TreeOf(population.Individual(i))
+		checkTree(t, tree, options)
+		depths[tree.Depth()]++
+		if i%2 == 0 && tree.Depth() != options.MinDepth+i/2%4 {
+			t.Errorf("full tree %v is not %v deep", tree, options.MinDepth+i/2%4)
+		}
+	}
+	if depths[5] == 0 || depths[2] == 0 {
+		t.Errorf("expected depths from 2 to 5 but got %v", depths)
+	}
+}
+
+func TestVariation(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(2)
+	options := Options{MaxDepth: 4, DepthLimit: 8, SizeLimit: 60}
+	population := NewRandomTreePopulation(4, typedSet(), options, rng)
+	options = *population.Individual(0).(*individual).options
+	config := &moea.Config{Population: population, RandomNumberGenerator: rng}
+	crossover := &SubtreeCrossover{}
+	mutations := []moea.MutationOperator{&PointMutation{}, &SubtreeMutation{}, &HoistMutation{}}
+	mutations[0].(*PointMutation).Initialize(config)
+	p1, p2, c1, c2 := population.Individual(0), population.Individual(1), population.Individual(2), population.Individual(3)
+	for n := 0; n < 2000; n++ {
+		before1, before2 := TreeOf(p1).String(), TreeOf(p2).String()
+		crossover.Crossover(config, p1, p2, c1, c2, 0.9)
+		if TreeOf(p1).String() != before1 || TreeOf(p2).String() != before2 {
+			t.Fatal("crossover changed a parent")
+		}
+		for _, c := range []moea.Individual{c1, c2} {
+			mutations[n%3].Mutation(config, c, 0.3)
+			checkTree(t, TreeOf(c), options)
+		}
+		p1, c1 = c1, p1
+		p2, c2 = c2, p2
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(3)
+	population := NewRandomTreePopulation(2, ArithmeticSet(2), Options{}, rng)
+	clone := population.Clone()
+	before := TreeOf(population.Individual(0)).String()
+	value := population.Individual(0).Value(0)
+	for n := 0; n < 10; n++ {
+		clone.Individual(0).Mutate([]int{0})
+	}
+	population.Individual(0).Copy(population.Individual(1), 0, population.Individual(1).Len())
+	if value.(*Tree).String() != before {
+		t.Error("a recorded value changed")
+	}
+}
+
+// target is x^2 + x
+func regression(individual moea.Individual) []float64 {
+	tree := TreeOf(individual)
+	error := 0.0
+	for x := -1.0; x <= 1; x += 0.1 {
+		error += math.Abs(tree.Eval(x).(float64) - (x*x + x))
+	}
+	return []float64{error, float64(tree.Size())}
+}
+
+func TestSymbolicRegression(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(4)
+	config := &moea.Config{
+		Algorithm: moea.NewSimpleAlgorithmWithOperators(moea.SimpleAlgorithmOperators{
+			Selection:   &moea.TournamentSelection{TournamentSize: 4},
+			Mutation:    &SubtreeMutation{},
+			Crossover:   &SubtreeCrossover{},
+			Replacement: &moea.GenerationalReplacement{Elitism: 2},
+		}),
+		Population:     NewRandomTreePopulation(200, ArithmeticSet(1), Options{MaxDepth: 4}, rng),
+		NumberOfValues: 1,
+		ObjectiveFunc: Parsimony(func(individual moea.Individual) []float64 {
+			return regression(individual)[:1]
+		}, 0.001),
+		NumberOfObjectives:    1,
+		MaxGenerations:        50,
+		CrossoverProbability:  0.9,
+		MutationProbability:   0.1,
+		RandomNumberGenerator: rng,
+	}
+	result, err := moea.Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := regression(result.BestIndividual)[0]; e > 0.1 {
+		t.Errorf("expected x^2 + x but was %v with error %v", TreeOf(result.BestIndividual), e)
+	}
+}
+
+func TestAccuracyVersusSize(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(5)
+	config := &moea.Config{
+		Algorithm: moea.NewSimpleAlgorithmWithOperators(moea.SimpleAlgorithmOperators{
+			Selection: &nsgaii.NsgaIISelection{},
+			Mutation:  &SubtreeMutation{},
+			Crossover: &SubtreeCrossover{},
+		}),
+		Population:            NewRandomTreePopulation(100, ArithmeticSet(1), Options{MaxDepth: 4}, rng),
+		NumberOfValues:        1,
+		ObjectiveFunc:         regression,
+		NumberOfObjectives:    2,
+		MaxGenerations:        50,
+		CrossoverProbability:  0.9,
+		MutationProbability:   0.2,
+		RandomNumberGenerator: rng,
+	}
+	result, err := moea.Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.BestObjective[0] > 1 {
+		t.Errorf("expected an accurate expression but the error was %v", result.BestObjective[0])
+	}
+	sizes := map[float64]bool{}
+	for _, r := range result.Individuals {
+		sizes[r.Objective[1]] = true
+		if tree := r.Values[0].(*Tree); float64(tree.Size()) != r.Objective[1] {
+			t.Errorf("recorded tree %v does not match its size %v", tree, r.Objective[1])
+		}
+	}
+	if len(sizes) < 3 {
+		t.Errorf("expected trees of several sizes but got %v", sizes)
+	}
+}
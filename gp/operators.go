@@ -0,0 +1,129 @@
+package gp
+
+import (
+	"github.com/project-draco/moea"
+)
+
+// SubtreeCrossover swaps a random subtree of each parent with a subtree of
+// the same type of the other. Crossover points are functions with
+// probability InternalProbability (0.9 by default) and terminals otherwise.
+// A child exceeding the bloat limits is replaced by a copy of its parent.
+// It returns the crossover point in the first parent.
+type SubtreeCrossover struct{ InternalProbability float64 }
+
+// PointMutation replaces each node, with the mutation probability, by a
+// random primitive of the same signature. It is moea.RegularMutation over
+// the nodes of the tree.
+type PointMutation struct{ moea.RegularMutation }
+
+// SubtreeMutation replaces, with the mutation probability, a random subtree
+// by a grown tree of the same type and at most MaxDepth deep (4 by
+// default).
+type SubtreeMutation struct {
+	MaxDepth  int
+	mutations int
+}
+
+// HoistMutation replaces, with the mutation probability, the tree by one of
+// its subtrees of the root type, which shrinks it.
+type HoistMutation struct{ mutations int }
+
+func (c *SubtreeCrossover) Crossover(config *moea.Config, parent1, parent2, child1, child2 moea.Individual,
+	probability float64) int {
+	child1.Copy(parent1, 0, parent1.Len())
+	child2.Copy(parent2, 0, parent2.Len())
+	if !config.RandomNumberGenerator.Flip(probability) {
+		return -1
+	}
+	p1, p2 := parent1.(*individual), parent2.(*individual)
+	site1 := c.point(config.RandomNumberGenerator, p1.tree, p1.tree.nodes[0].primitive.Type, true)
+	typ := p1.tree.nodes[site1].primitive.Type
+	if len(p2.tree.indexesOfType(typ, false)) == 0 {
+		return -1
+	}
+	site2 := c.point(config.RandomNumberGenerator, p2.tree, typ, false)
+	tree1 := p1.tree.replace(site1, p2.tree.nodes[site2:p2.tree.end(site2)])
+	tree2 := p2.tree.replace(site2, p1.tree.nodes[site1:p1.tree.end(site1)])
+	if p1.withinLimits(tree1) {
+		child1.(*individual).tree = tree1
+	}
+	if p2.withinLimits(tree2) {
+		child2.(*individual).tree = tree2
+	}
+	return site1
+}
+
+// point picks a crossover point of tree, of any type when anyType is true.
+func (c *SubtreeCrossover) point(rng moea.RNG, tree *Tree, typ Type, anyType bool) int {
+	internal := c.InternalProbability
+	if internal <= 0 {
+		internal = 0.9
+	}
+	var candidates []int
+	for i, n := range tree.nodes {
+		if anyType || n.primitive.Type == typ {
+			candidates = append(candidates, i)
+		}
+	}
+	var functions, terminals []int
+	for _, i := range candidates {
+		if tree.nodes[i].primitive.kind == function {
+			functions = append(functions, i)
+		} else {
+			terminals = append(terminals, i)
+		}
+	}
+	if len(functions) > 0 && (len(terminals) == 0 || rng.Flip(internal)) {
+		return functions[rng.Intn(len(functions))]
+	}
+	return terminals[rng.Intn(len(terminals))]
+}
+
+func (m *SubtreeMutation) Mutation(config *moea.Config, mutated moea.Individual, probability float64) {
+	if !config.RandomNumberGenerator.Flip(probability) {
+		return
+	}
+	ind := mutated.(*individual)
+	maxDepth := m.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = 4
+	}
+	i := config.RandomNumberGenerator.Intn(ind.tree.Size())
+	nodes := generate(ind.set, ind.tree.nodes[i].primitive.Type, config.RandomNumberGenerator.Intn(maxDepth+1),
+		false, config.RandomNumberGenerator, nil)
+	if tree := ind.tree.replace(i, nodes); ind.withinLimits(tree) {
+		ind.tree = tree
+		m.mutations++
+	}
+}
+
+func (m *SubtreeMutation) Finalize(_ *moea.Config, _ moea.Population, _ [][]float64, result *moea.Result) {
+	result.Mutations = m.mutations
+}
+
+func (m *HoistMutation) Mutation(config *moea.Config, mutated moea.Individual, probability float64) {
+	if !config.RandomNumberGenerator.Flip(probability) {
+		return
+	}
+	ind := mutated.(*individual)
+	candidates := ind.tree.indexesOfType(ind.set.Root, false)
+	if i := candidates[config.RandomNumberGenerator.Intn(len(candidates))]; i > 0 {
+		ind.tree = ind.tree.subtree(i)
+		m.mutations++
+	}
+}
+
+func (m *HoistMutation) Finalize(_ *moea.Config, _ moea.Population, _ [][]float64, result *moea.Result) {
+	result.Mutations = m.mutations
+}
+
+// Parsimony adds coefficient times the tree size to the first objective of
+// f, penalising bloat in single-objective runs. In multi-objective runs the
+// size can be an objective of its own.
+func Parsimony(f moea.ObjectiveFunc, coefficient float64) moea.ObjectiveFunc {
+	return func(individual moea.Individual) []float64 {
+		result := f(individual)
+		result[0] += coefficient * float64(TreeOf(individual).Size())
+		return result
+	}
+}
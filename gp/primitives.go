@@ -0,0 +1,142 @@
+package gp
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/project-draco/moea"
+)
+
+// Type names the type of the values flowing between primitives. A function
+// argument only receives subtrees returning its type.
+type Type string
+
+const Float Type = "float"
+
+type primitiveKind int
+
+const (
+	function primitiveKind = iota
+	variable
+	constant
+	ephemeral
+)
+
+// Primitive is a function or a terminal of a primitive set.
+type Primitive struct {
+	Name string
+	Type Type
+	// Args are the types of the arguments of a function.
+	Args     []Type
+	kind     primitiveKind
+	eval     func(args []interface{}) interface{}
+	index    int
+	value    interface{}
+	generate func(rng moea.RNG) interface{}
+}
+
+type PrimitiveSet struct {
+	Functions []*Primitive
+	Terminals []*Primitive
+	// Root is the type of whole expressions.
+	Root Type
+}
+
+// NewFunction returns a function of the given argument types computing eval.
+func NewFunction(name string, result Type, args []Type, eval func(args []interface{}) interface{}) *Primitive {
+	if len(args) == 0 {
+		panic(fmt.Sprintf("Function %s without arguments", name))
+	}
+	return &Primitive{Name: name, Type: result, Args: args, kind: function, eval: eval}
+}
+
+// NewVariable returns a terminal reading the index-th input of Eval.
+func NewVariable(name string, t Type, index int) *Primitive {
+	return &Primitive{Name: name, Type: t, kind: variable, index: index}
+}
+
+func NewConstant(name string, t Type, value interface{}) *Primitive {
+	return &Primitive{Name: name, Type: t, kind: constant, value: value}
+}
+
+// NewEphemeral returns an ephemeral random constant: every node it
+// creates holds its own value from generate.
+func NewEphemeral(name string, t Type, generate func(rng moea.RNG) interface{}) *Primitive {
+	return &Primitive{Name: name, Type: t, kind: ephemeral, generate: generate}
+}
+
+// ArithmeticSet returns the usual primitive set of symbolic regression:
+// addition, subtraction, multiplication and protected division, which
+// returns 1 for divisors close to zero, the variables x0 to x<n-1> and
+// ephemeral constants in [-1, 1].
+func ArithmeticSet(variables int) *PrimitiveSet {
+	args := []Type{Float, Float}
+	set := &PrimitiveSet{
+		Functions: []*Primitive{
+			NewFunction("+", Float, args, func(a []interface{}) interface{} { return a[0].(float64) + a[1].(float64) }),
+			NewFunction("-", Float, args, func(a []interface{}) interface{} { return a[0].(float64) - a[1].(float64) }),
+			NewFunction("*", Float, args, func(a []interface{}) interface{} { return a[0].(float64) * a[1].(float64) }),
+			NewFunction("/", Float, args, func(a []interface{}) interface{} {
+				if math.Abs(a[1].(float64)) < 1e-9 {
+					return 1.0
+				}
+				return a[0].(float64) / a[1].(float64)
+			}),
+		},
+		Root: Float,
+	}
+	for i := 0; i < variables; i++ {
+		set.Terminals = append(set.Terminals, NewVariable(fmt.Sprintf("x%d", i), Float, i))
+	}
+	set.Terminals = append(set.Terminals, NewEphemeral("erc", Float, func(rng moea.RNG) interface{} {
+		return 2*rng.Float64() - 1
+	}))
+	return set
+}
+
+// validate panics unless every type an expression may need has a terminal,
+// so that trees of any depth can be completed.
+func (s *PrimitiveSet) validate() {
+	terminals := map[Type]bool{}
+	for _, t := range s.Terminals {
+		if t.kind == function {
+			panic(fmt.Sprintf("Function %s among the terminals", t.Name))
+		}
+		terminals[t.Type] = true
+	}
+	if !terminals[s.Root] {
+		panic(fmt.Sprintf("No terminal of type %s", s.Root))
+	}
+	for _, f := range s.Functions {
+		if f.kind != function {
+			panic(fmt.Sprintf("Terminal %s among the functions", f.Name))
+		}
+		for _, t := range f.Args {
+			if !terminals[t] {
+				panic(fmt.Sprintf("No terminal of type %s for %s", t, f.Name))
+			}
+		}
+	}
+}
+
+func ofType(primitives []*Primitive, t Type) []*Primitive {
+	var result []*Primitive
+	for _, p := range primitives {
+		if p.Type == t {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func sameSignature(a, b *Primitive) bool {
+	if a.Type != b.Type || len(a.Args) != len(b.Args) {
+		return false
+	}
+	for i := range a.Args {
+		if a.Args[i] != b.Args[i] {
+			return false
+		}
+	}
+	return true
+}
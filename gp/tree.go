@@ -0,0 +1,155 @@
+package gp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-draco/moea"
+)
+
+// Tree is an immutable expression, stored as its nodes in prefix order.
+type Tree struct {
+	nodes []node
+}
+
+type node struct {
+	primitive *Primitive
+	// value is the value of constants.
+	value interface{}
+}
+
+func (t *Tree) Size() int { return len(t.nodes) }
+
+// Depth is the number of edges on the longest path from the root, 0 for a
+// single terminal.
+func (t *Tree) Depth() int {
+	depth, _ := t.depth(0)
+	return depth
+}
+
+func (t *Tree) depth(i int) (int, int) {
+	result, next := 0, i+1
+	for range t.nodes[i].primitive.Args {
+		var d int
+		d, next = t.depth(next)
+		if d+1 > result {
+			result = d + 1
+		}
+	}
+	return result, next
+}
+
+// Eval computes the expression, with inputs giving the values of the
+// variables.
+func (t *Tree) Eval(inputs ...interface{}) interface{} {
+	result, _ := t.eval(0, inputs)
+	return result
+}
+
+func (t *Tree) eval(i int, inputs []interface{}) (interface{}, int) {
+	n := t.nodes[i]
+	switch n.primitive.kind {
+	case variable:
+		return inputs[n.primitive.index], i + 1
+	case constant, ephemeral:
+		return n.value, i + 1
+	}
+	args := make([]interface{}, len(n.primitive.Args))
+	next := i + 1
+	for j := range args {
+		args[j], next = t.eval(next, inputs)
+	}
+	return n.primitive.eval(args), next
+}
+
+// String returns the expression as an S-expression.
+func (t *Tree) String() string {
+	var b strings.Builder
+	t.write(&b, 0)
+	return b.String()
+}
+
+func (t *Tree) write(b *strings.Builder, i int) int {
+	n := t.nodes[i]
+	switch n.primitive.kind {
+	case variable:
+		b.WriteString(n.primitive.Name)
+		return i + 1
+	case constant, ephemeral:
+		fmt.Fprint(b, n.value)
+		return i + 1
+	}
+	b.WriteString("(" + n.primitive.Name)
+	next := i + 1
+	for range n.primitive.Args {
+		b.WriteString(" ")
+		next = t.write(b, next)
+	}
+	b.WriteString(")")
+	return next
+}
+
+// end returns the index following the subtree rooted at i.
+func (t *Tree) end(i int) int {
+	for pending := 1; pending > 0; i++ {
+		pending += len(t.nodes[i].primitive.Args) - 1
+	}
+	return i
+}
+
+func (t *Tree) subtree(i int) *Tree {
+	return &Tree{t.nodes[i:t.end(i)]}
+}
+
+// replace returns a new tree with the subtree rooted at i replaced by
+// nodes.
+func (t *Tree) replace(i int, nodes []node) *Tree {
+	end := t.end(i)
+	result := make([]node, 0, len(t.nodes)-(end-i)+len(nodes))
+	result = append(result, t.nodes[:i]...)
+	result = append(result, nodes...)
+	return &Tree{append(result, t.nodes[end:]...)}
+}
+
+// indexesOfType returns the nodes returning type t, only functions when
+// internal is true.
+func (t *Tree) indexesOfType(typ Type, internal bool) []int {
+	var result []int
+	for i, n := range t.nodes {
+		if n.primitive.Type == typ && (!internal || n.primitive.kind == function) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// generate appends a random expression of type t and at most the given
+// depth to nodes. Full trees only end in terminals at the maximum depth,
+// while grown trees pick among all primitives of the type at every level.
+func generate(set *PrimitiveSet, t Type, depth int, full bool, rng moea.RNG, nodes []node) []node {
+	candidates := ofType(set.Terminals, t)
+	if depth > 0 {
+		functions := ofType(set.Functions, t)
+		if full && len(functions) > 0 {
+			candidates = functions
+		} else if !full {
+			candidates = append(candidates, functions...)
+		}
+	}
+	p := candidates[rng.Intn(len(candidates))]
+	nodes = append(nodes, newNode(p, rng))
+	for _, arg := range p.Args {
+		nodes = generate(set, arg, depth-1, full, rng, nodes)
+	}
+	return nodes
+}
+
+func newNode(p *Primitive, rng moea.RNG) node {
+	switch p.kind {
+	case constant:
+		return node{p, p.value}
+	case ephemeral:
+		return node{p, p.generate(rng)}
+	}
+	return node{primitive: p}
+}
@@ -0,0 +1,242 @@
+// Package ibea implements the indicator-based evolutionary algorithm of
+// Zitzler and Künzli (2004) as a selection operator of the simple
+// algorithm.
+package ibea
+
+import (
+	"math"
+
+	"github.com/project-draco/moea"
+)
+
+// IbeaSelection selects parents by binary tournaments on the indicator
+// based fitness, and every generation keeps the best population-size
+// individuals of the parents and their offspring, removing the worst one
+// at a time and updating the fitness of the others.
+type IbeaSelection struct {
+	// Indicator compares individuals; AdditiveEpsilon by default.
+	Indicator Indicator
+	// Kappa scales the indicator values in the fitness, 0.05 by default.
+	Kappa              float64
+	Fitness            []float64
+	PreviousPopulation moea.Population
+	PreviousObjectives [][]float64
+	mixedPopulation    []moea.Individual
+	mixedObjectives    [][]float64
+	mixedFitness       []float64
+	normalized         [][]float64
+	indicator          [][]float64
+	alive              []bool
+}
+
+// Indicator is a binary quality indicator on normalized objectives, in
+// [0, 1] for the individuals compared: the smaller I(a, b), the better a
+// is than b.
+type Indicator interface {
+	Indicator(a, b []float64) float64
+}
+
+// AdditiveEpsilon is the smallest amount by which a must be improved in
+// every objective to weakly dominate b.
+type AdditiveEpsilon struct{}
+
+// Hypervolume is the volume dominated by b and not by a, minus that
+// dominated by a and not by b when a dominates b, with Reference in every
+// objective as reference point (2 by default).
+type Hypervolume struct{ Reference float64 }
+
+func (s *IbeaSelection) Initialize(config *moea.Config) {
+	if s.Indicator == nil {
+		s.Indicator = &AdditiveEpsilon{}
+	}
+	if s.Kappa <= 0 {
+		s.Kappa = 0.05
+	}
+	n := config.Population.Len()
+	s.Fitness = make([]float64, n)
+	s.PreviousPopulation = nil
+	s.PreviousObjectives = nil
+	s.mixedPopulation = make([]moea.Individual, 2*n)
+	clone1, clone2 := config.Population.Clone(), config.Population.Clone()
+	for i := 0; i < n; i++ {
+		s.mixedPopulation[i] = clone1.Individual(i)
+		s.mixedPopulation[i+n] = clone2.Individual(i)
+	}
+	s.mixedObjectives = make([][]float64, 2*n)
+	s.mixedFitness = make([]float64, 2*n)
+	s.normalized = make([][]float64, 2*n)
+	s.indicator = make([][]float64, 2*n)
+	for i := range s.indicator {
+		s.normalized[i] = make([]float64, config.NumberOfObjectives)
+		s.indicator[i] = make([]float64, 2*n)
+	}
+	s.alive = make([]bool, 2*n)
+}
+
+func (s *IbeaSelection) OnGeneration(config *moea.Config, population moea.Population, objectives [][]float64) {
+	if s.PreviousPopulation == nil {
+		copy(s.mixedObjectives, objectives)
+		s.assignFitness(len(objectives))
+		copy(s.Fitness, s.mixedFitness)
+	} else {
+		s.merge(population, objectives)
+		s.environmentalSelection(population, objectives)
+	}
+	s.PreviousPopulation = population
+	s.PreviousObjectives = objectives
+}
+
+// Selection is a binary tournament on fitness.
+func (s *IbeaSelection) Selection(config *moea.Config, objectives [][]float64) int {
+	a := config.RandomNumberGenerator.Intn(len(objectives))
+	b := config.RandomNumberGenerator.Intn(len(objectives))
+	if s.Fitness[b] > s.Fitness[a] {
+		return b
+	}
+	return a
+}
+
+func (s *IbeaSelection) Finalize(config *moea.Config, population moea.Population, objectives [][]float64, result *moea.Result) {
+	if s.PreviousPopulation != nil {
+		s.merge(population, objectives)
+		s.environmentalSelection(population, objectives)
+	}
+	for i := 0; i < population.Len(); i++ {
+		result.Individuals[i].Objective = objectives[i]
+		result.Individuals[i].Values = moea.RecordValues(config, population.Individual(i), result.Individuals[i].Values)
+		result.Individuals[i].Parent1 = -1
+		result.Individuals[i].Parent2 = -1
+		result.Individuals[i].CrossSite = -1
+		if result.BestObjective[0] > objectives[i][0] {
+			result.BestObjective[0] = objectives[i][0]
+			result.BestIndividual = population.Individual(i)
+			result.BestIndividualIndex = i
+		}
+	}
+}
+
+// CurrentPopulation returns the survivors of the last environmental
+// selection, nil before the first one.
+func (s *IbeaSelection) CurrentPopulation(config *moea.Config) (moea.Population, [][]float64) {
+	return s.PreviousPopulation, s.PreviousObjectives
+}
+
+func (s *IbeaSelection) merge(population moea.Population, objectives [][]float64) {
+	n := population.Len()
+	for i := 0; i < n; i++ {
+		previous, current := s.PreviousPopulation.Individual(i), population.Individual(i)
+		moea.CopyIndividual(s.mixedPopulation[i], previous)
+		moea.CopyIndividual(s.mixedPopulation[i+n], current)
+		s.mixedObjectives[i] = s.PreviousObjectives[i]
+		s.mixedObjectives[i+n] = objectives[i]
+	}
+}
+
+// environmentalSelection removes the individual of the lowest fitness
+// from the merged population, and its contribution from the fitness of the
+// others, until population-size individuals remain, which are copied into
+// population.
+func (s *IbeaSelection) environmentalSelection(population moea.Population, objectives [][]float64) {
+	size := 2 * population.Len()
+	c := s.assignFitness(size)
+	for remaining := size; remaining > population.Len(); remaining-- {
+		worst := -1
+		for i := 0; i < size; i++ {
+			if s.alive[i] && (worst < 0 || s.mixedFitness[i] < s.mixedFitness[worst]) {
+				worst = i
+			}
+		}
+		s.alive[worst] = false
+		for i := 0; i < size; i++ {
+			if s.alive[i] {
+				s.mixedFitness[i] += math.Exp(-s.indicator[worst][i] / (c * s.Kappa))
+			}
+		}
+	}
+	k := 0
+	for i := 0; i < size; i++ {
+		if s.alive[i] {
+			moea.CopyIndividual(population.Individual(k), s.mixedPopulation[i])
+			objectives[k] = s.mixedObjectives[i]
+			s.Fitness[k] = s.mixedFitness[i]
+			k++
+		}
+	}
+}
+
+// assignFitness normalizes the first size merged objectives, computes the
+// indicator values among them and assigns F(x) = sum over y of
+// -exp(-I(y, x) / (c kappa)), where c is the largest absolute indicator
+// value, which it returns.
+func (s *IbeaSelection) assignFitness(size int) float64 {
+	m := len(s.mixedObjectives[0])
+	for j := 0; j < m; j++ {
+		low, high := math.Inf(1), math.Inf(-1)
+		for i := 0; i < size; i++ {
+			low = math.Min(low, s.mixedObjectives[i][j])
+			high = math.Max(high, s.mixedObjectives[i][j])
+		}
+		for i := 0; i < size; i++ {
+			s.normalized[i][j] = 0
+			if high > low {
+				s.normalized[i][j] = (s.mixedObjectives[i][j] - low) / (high - low)
+			}
+		}
+	}
+	c := 0.0
+	for i := 0; i < size; i++ {
+		for k := 0; k < size; k++ {
+			if i != k {
+				s.indicator[i][k] = s.Indicator.Indicator(s.normalized[i][:m], s.normalized[k][:m])
+				c = math.Max(c, math.Abs(s.indicator[i][k]))
+			}
+		}
+	}
+	if c == 0 {
+		c = 1
+	}
+	for i := 0; i < size; i++ {
+		s.alive[i] = true
+		s.mixedFitness[i] = 0
+		for k := 0; k < size; k++ {
+			if k != i {
+				s.mixedFitness[i] -= math.Exp(-s.indicator[k][i] / (c * s.Kappa))
+			}
+		}
+	}
+	return c
+}
+
+func (*AdditiveEpsilon) Indicator(a, b []float64) float64 {
+	result := math.Inf(-1)
+	for j := range a {
+		result = math.Max(result, a[j]-b[j])
+	}
+	return result
+}
+
+func (h *Hypervolume) Indicator(a, b []float64) float64 {
+	reference := h.Reference
+	if reference <= 0 {
+		reference = 2
+	}
+	volume := func(p []float64) float64 {
+		result := 1.0
+		for _, x := range p {
+			result *= reference - x
+		}
+		return result
+	}
+	dominates := true
+	joint := make([]float64, len(a))
+	for j := range a {
+		if a[j] > b[j] {
+			dominates = false
+		}
+		joint[j] = math.Max(a[j], b[j])
+	}
+	if dominates {
+		return volume(b) - volume(a)
+	}
+	return volume(b) - volume(joint)
+}
@@ -0,0 +1,94 @@
+package ibea
+
+import (
+	"testing"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/internal/fixture"
+	"github.com/project-draco/moea/varlen"
+)
+
+func schConfig(selection *IbeaSelection, seed uint32) *moea.Config {
+	config := fixture.SCH(moea.NewSimpleAlgorithm(selection, &moea.FastMutation{}), seed)
+	config.MaxGenerations = 50
+	return config
+}
+
+func TestIndicators(t *testing.T) {
+	for _, f := range []struct {
+		indicator Indicator
+		a, b      []float64
+		out       float64
+	}{
+		{&AdditiveEpsilon{}, []float64{0, 0.5}, []float64{0.5, 0}, 0.5},
+		{&AdditiveEpsilon{}, []float64{0, 0}, []float64{0.5, 0.25}, -0.25},
+		{&Hypervolume{}, []float64{0, 0}, []float64{1, 1}, 1 - 4},
+		{&Hypervolume{}, []float64{0, 1}, []float64{1, 0}, 2 - 1},
+		{&Hypervolume{Reference: 1.5}, []float64{0.5, 0.5}, []float64{0.5, 0.5}, 0},
+	} {
+		if out := f.indicator.Indicator(f.a, f.b); out != f.out {
+			t.Error("Expected", f.out, "but was", out, "for", f.a, f.b)
+		}
+	}
+}
+
+func TestConvergence(t *testing.T) {
+	for _, indicator := range []Indicator{&AdditiveEpsilon{}, &Hypervolume{}} {
+		result, err := moea.Run(schConfig(&IbeaSelection{Indicator: indicator}, 3))
+		if err != nil {
+			t.Fatal(err)
+		}
+		low, high := 255.0, 0.0
+		for _, individual := range result.Individuals {
+			x := 100 - (individual.Objective[1]-individual.Objective[0]+10000)/200
+			if x > 100 {
+				t.Errorf("%T: expected a Pareto optimal population, found %v", indicator, x)
+			}
+			if x < low {
+				low = x
+			}
+			if x > high {
+				high = x
+			}
+		}
+		if low > 30 || high < 85 {
+			t.Errorf("%T: expected the population spread over the front, got [%v, %v]", indicator, low, high)
+		}
+	}
+}
+
+func TestSameSeedSameResult(t *testing.T) {
+	fixture.SameSeedSameResult(t, func(seed uint32) *moea.Config {
+		return schConfig(&IbeaSelection{}, seed)
+	}, 5)
+}
+
+func TestVariableLength(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(7)
+	options := varlen.Options{MinLength: 1, MaxLength: 6, Gene: func(rng moea.RNG) interface{} { return rng.Intn(10) }}
+	result, err := moea.Run(&moea.Config{
+		Algorithm:          moea.NewSimpleAlgorithm(&IbeaSelection{}, &moea.RegularMutation{}),
+		Population:         varlen.NewRandomVariableLengthPopulation(20, options, rng),
+		NumberOfValues:     options.MaxLength,
+		NumberOfObjectives: 2,
+		ObjectiveFunc: func(i moea.Individual) []float64 {
+			sum := 0.0
+			for _, g := range varlen.Genes(i) {
+				sum += float64(g.(int))
+			}
+			return []float64{float64(i.Len()), -sum}
+		},
+		MaxGenerations:        10,
+		CrossoverProbability:  0.9,
+		MutationProbability:   0.1,
+		RandomNumberGenerator: rng,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, individual := range result.Individuals {
+		if float64(len(individual.Values)) != individual.Objective[0] {
+			t.Error("Expected the values of", individual.Objective[0], "genes, got", individual.Values)
+		}
+	}
+}
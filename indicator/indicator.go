@@ -0,0 +1,224 @@
+package indicator
+
+import (
+	"math"
+	"sort"
+)
+
+// All indicators assume minimisation of every objective.
+
+// Nondominated returns the points of front that are not dominated by any
+// other point. Duplicates are kept once.
+func Nondominated(front [][]float64) [][]float64 {
+	var result [][]float64
+	for i, p := range front {
+		dominated := false
+		for j, q := range front {
+			if i != j && (dominates(q, p) || (j < i && equal(p, q))) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Hypervolume returns the volume of the objective space dominated by front
+// and bounded by reference. Points that do not strictly dominate reference
+// contribute nothing.
+func Hypervolume(front [][]float64, reference []float64) float64 {
+	var points [][]float64
+	for _, p := range front {
+		inside := true
+		for i := range reference {
+			if p[i] >= reference[i] {
+				inside = false
+				break
+			}
+		}
+		if inside {
+			points = append(points, p)
+		}
+	}
+	return hypervolume(Nondominated(points), reference, len(reference))
+}
+
+// hypervolume slices the space along the last of the d objectives taken
+// into account and sums the (d-1)-dimensional volumes of every slice.
+func hypervolume(points [][]float64, reference []float64, d int) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+	if d == 1 {
+		min := reference[0]
+		for _, p := range points {
+			min = math.Min(min, p[0])
+		}
+		return reference[0] - min
+	}
+	sorted := make([][]float64, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][d-1] < sorted[j][d-1] })
+	if d == 2 {
+		volume, bestX := 0.0, reference[0]
+		for i, p := range sorted {
+			bestX = math.Min(bestX, p[0])
+			next := reference[1]
+			if i+1 < len(sorted) {
+				next = sorted[i+1][1]
+			}
+			volume += (reference[0] - bestX) * (next - p[1])
+		}
+		return volume
+	}
+	volume := 0.0
+	for i, p := range sorted {
+		next := reference[d-1]
+		if i+1 < len(sorted) {
+			next = sorted[i+1][d-1]
+		}
+		if next == p[d-1] {
+			continue
+		}
+		slice := nondominatedIn(sorted[:i+1], d-1)
+		volume += hypervolume(slice, reference, d-1) * (next - p[d-1])
+	}
+	return volume
+}
+
+func nondominatedIn(points [][]float64, d int) [][]float64 {
+	var result [][]float64
+	for i, p := range points {
+		dominated := false
+		for j, q := range points {
+			if i == j {
+				continue
+			}
+			weakly, strictly := true, false
+			for k := 0; k < d; k++ {
+				if q[k] > p[k] {
+					weakly = false
+					break
+				}
+				if q[k] < p[k] {
+					strictly = true
+				}
+			}
+			if weakly && (strictly || j < i) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// GenerationalDistance is the average Euclidean distance from each point of
+// front to its nearest point in reference.
+func GenerationalDistance(front, reference [][]float64) float64 {
+	return averageDistance(front, reference)
+}
+
+// InvertedGenerationalDistance is the average Euclidean distance from each
+// point of reference to its nearest point in front.
+func InvertedGenerationalDistance(front, reference [][]float64) float64 {
+	return averageDistance(reference, front)
+}
+
+func averageDistance(from, to [][]float64) float64 {
+	if len(from) == 0 || len(to) == 0 {
+		return math.Inf(1)
+	}
+	sum := 0.0
+	for _, p := range from {
+		min := math.Inf(1)
+		for _, q := range to {
+			min = math.Min(min, distance(p, q))
+		}
+		sum += min
+	}
+	return sum / float64(len(from))
+}
+
+// AdditiveEpsilon is the smallest value that, added to every objective of
+// front, makes it weakly dominate reference.
+func AdditiveEpsilon(front, reference [][]float64) float64 {
+	result := math.Inf(-1)
+	for _, r := range reference {
+		min := math.Inf(1)
+		for _, p := range front {
+			max := math.Inf(-1)
+			for i := range r {
+				max = math.Max(max, p[i]-r[i])
+			}
+			min = math.Min(min, max)
+		}
+		result = math.Max(result, min)
+	}
+	return result
+}
+
+// Spacing is Schott's spacing metric: the standard deviation of the
+// Manhattan distance from each point to its nearest neighbour.
+func Spacing(front [][]float64) float64 {
+	if len(front) < 2 {
+		return 0
+	}
+	d := make([]float64, len(front))
+	mean := 0.0
+	for i, p := range front {
+		d[i] = math.Inf(1)
+		for j, q := range front {
+			if i != j {
+				sum := 0.0
+				for k := range p {
+					sum += math.Abs(p[k] - q[k])
+				}
+				d[i] = math.Min(d[i], sum)
+			}
+		}
+		mean += d[i]
+	}
+	mean /= float64(len(d))
+	sum := 0.0
+	for _, v := range d {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(d)-1))
+}
+
+func dominates(a, b []float64) bool {
+	strictly := false
+	for i := range a {
+		if a[i] > b[i] {
+			return false
+		}
+		if a[i] < b[i] {
+			strictly = true
+		}
+	}
+	return strictly
+}
+
+func equal(a, b []float64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func distance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,72 @@
+package indicator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHypervolume(t *testing.T) {
+	for _, f := range []struct {
+		front     [][]float64
+		reference []float64
+		out       float64
+	}{
+		{[][]float64{{0, 0}}, []float64{1, 1}, 1},
+		{[][]float64{{0, 1}, {1, 0}}, []float64{2, 2}, 3},
+		{[][]float64{{0, 1}, {1, 0}, {1, 1}, {3, 0}}, []float64{2, 2}, 3},
+		{[][]float64{{0.5, 0.5, 0.5}}, []float64{1, 1, 1}, 0.125},
+		{[][]float64{{0, 0, 1}, {0, 1, 0}, {1, 0, 0}}, []float64{2, 2, 2}, 8 - 1},
+		{[][]float64{{0, 0, 0, 0}, {1, 1, 1, 1}}, []float64{1, 1, 1, 1}, 1},
+		{[][]float64{{3, 3}}, []float64{2, 2}, 0},
+	} {
+		if hv := Hypervolume(f.front, f.reference); math.Abs(hv-f.out) > 1e-12 {
+			t.Error("Expected", f.out, "but was", hv, "for", f.front)
+		}
+	}
+}
+
+func TestHypervolumeAgainstMonteCarlo(t *testing.T) {
+	front := [][]float64{{0.1, 0.6, 0.5}, {0.4, 0.2, 0.7}, {0.7, 0.5, 0.1}, {0.3, 0.3, 0.3}}
+	reference := []float64{1, 1, 1}
+	inside, n := 0, 0
+	for a := 0.005; a < 1; a += 0.01 {
+		for b := 0.005; b < 1; b += 0.01 {
+			for c := 0.005; c < 1; c += 0.01 {
+				n++
+				for _, p := range front {
+					if p[0] <= a && p[1] <= b && p[2] <= c {
+						inside++
+						break
+					}
+				}
+			}
+		}
+	}
+	if hv := Hypervolume(front, reference); math.Abs(hv-float64(inside)/float64(n)) > 0.01 {
+		t.Error("Expected about", float64(inside)/float64(n), "but was", hv)
+	}
+}
+
+func TestDistances(t *testing.T) {
+	reference := [][]float64{{0, 1}, {1, 0}}
+	front := [][]float64{{0, 1}}
+	if gd := GenerationalDistance(front, reference); gd != 0 {
+		t.Error("Expected 0 but was", gd)
+	}
+	if igd := InvertedGenerationalDistance(front, reference); math.Abs(igd-math.Sqrt2/2) > 1e-12 {
+		t.Error("Expected", math.Sqrt2/2, "but was", igd)
+	}
+	if e := AdditiveEpsilon([][]float64{{0.5, 1.5}, {1.5, 0.5}}, reference); e != 0.5 {
+		t.Error("Expected 0.5 but was", e)
+	}
+	if s := Spacing([][]float64{{0, 2}, {1, 1}, {2, 0}}); s != 0 {
+		t.Error("Expected 0 but was", s)
+	}
+}
+
+func TestNondominated(t *testing.T) {
+	result := Nondominated([][]float64{{0, 1}, {1, 0}, {1, 1}, {0, 1}})
+	if len(result) != 2 {
+		t.Error("Expected 2 points but was", result)
+	}
+}
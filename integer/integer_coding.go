@@ -18,7 +18,9 @@ func (p integerPopulation) Individual(i int) moea.Individual { return p[i] }
 
 func (p integerPopulation) Clone() moea.Population {
 	result := make(integerPopulation, p.Len())
-	copy(result, p)
+	for i, individual := range p {
+		result[i] = individual.Clone()
+	}
 	return result
 }
 
@@ -48,7 +50,7 @@ func (ii integerIndividual) Value(i int) interface{} {
 
 func (ii integerIndividual) Copy(individual moea.Individual, start, end int) {
 	other := individual.(integerIndividual)
-	copy(other.arr[start:end], ii.arr[start:end])
+	copy(ii.arr[start:end], other.arr[start:end])
 }
 
 func (ii integerIndividual) Mutate(mutations []int) {
@@ -0,0 +1,59 @@
+package integer
+
+import (
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+func TestCopyAndClone(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(1)
+	bounds := []Bound{{0, 100}, {0, 100}, {0, 100}}
+	population := NewRandomIntegerPopulation(2, 3, bounds, rng)
+	a, b := population.Individual(0), population.Individual(1)
+	before := a.Value(0)
+	b.Copy(a, 0, 2)
+	if b.Value(0) != a.Value(0) || b.Value(1) != a.Value(1) || a.Value(0) != before {
+		t.Errorf("expected %v to copy the first two values of %v", b, a)
+	}
+	clone := population.Clone()
+	clone.Individual(0).Mutate([]int{0, 1, 2})
+	clone.Individual(0).Mutate([]int{0, 1, 2})
+	if a.Value(0) != before {
+		t.Error("mutating a clone changed the population")
+	}
+}
+
+func TestSimulatedAnnealing(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(2)
+	bounds := make([]Bound, 10)
+	for i := range bounds {
+		bounds[i] = Bound{-20, 20}
+	}
+	config := &moea.Config{
+		Algorithm:      moea.NewSimulatedAnnealing(moea.SimulatedAnnealingOptions{Moves: 50}),
+		Population:     NewRandomIntegerPopulation(1, 10, bounds, rng),
+		NumberOfValues: 10,
+		// the values should add up to 50
+		ObjectiveFunc: func(individual moea.Individual) []float64 {
+			sum := 0
+			for i := 0; i < individual.Len(); i++ {
+				sum += individual.Value(i).(int)
+			}
+			if sum > 50 {
+				return []float64{float64(sum - 50)}
+			}
+			return []float64{float64(50 - sum)}
+		},
+		NumberOfObjectives:    1,
+		MaxGenerations:        100,
+		RandomNumberGenerator: rng,
+	}
+	result, err := moea.Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.BestObjective[0] != 0 {
+		t.Errorf("expected a sum of 50 but was off by %v with %v", result.BestObjective[0], result.BestIndividual)
+	}
+}
@@ -0,0 +1,50 @@
+// Package fixture holds the problems the tests of the algorithm packages
+// share.
+package fixture
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/binary"
+)
+
+// SCH returns the configuration of 10 generations of algorithm on
+// Schaffer's problem, x^2 and (x-100)^2 for the 8 bit integers x, with 20
+// individuals and the random numbers of seed. Its Pareto optimal solutions
+// are x in [0, 100].
+func SCH(algorithm moea.Algorithm, seed uint32) *moea.Config {
+	rng := moea.NewXorshiftWithSeed(seed)
+	return &moea.Config{
+		Algorithm:          algorithm,
+		Population:         binary.NewRandomBinaryPopulation(20, []int{8}, nil, rng),
+		NumberOfValues:     1,
+		NumberOfObjectives: 2,
+		ObjectiveFunc: func(i moea.Individual) []float64 {
+			x := float64(i.Value(0).(binary.BinaryString).Int().Int64())
+			return []float64{x * x, (x - 100) * (x - 100)}
+		},
+		MaxGenerations:        10,
+		CrossoverProbability:  0.9,
+		MutationProbability:   1.0 / 8,
+		RandomNumberGenerator: rng,
+	}
+}
+
+// SameSeedSameResult runs the configuration config returns for seed twice
+// and fails t unless both runs give identical results.
+func SameSeedSameResult(t *testing.T, config func(seed uint32) *moea.Config, seed uint32) {
+	t.Helper()
+	r1, err := moea.Run(config(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := moea.Run(config(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r1, r2) {
+		t.Error("Runs with the same seed must give identical results")
+	}
+}
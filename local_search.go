@@ -0,0 +1,51 @@
+package moea
+
+// LocalSearch improves an evaluated offspring in place and returns its new
+// objectives. Candidates are evaluated through evaluate, which counts them
+// against the evaluation budget and returns nil once the budget is spent.
+// Implementations for binary, permutation and mixed individuals live in
+// their packages.
+type LocalSearch interface {
+	Search(config *Config, individual Individual, objective []float64, evaluate ObjectiveFunc) []float64
+}
+
+// Dominates reports whether a is no worse than b in every objective and
+// better in at least one. For a single objective it is a < b.
+func Dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] > b[i] {
+			return false
+		}
+		if a[i] < b[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+// localSearch applies the local search of the memetic operators to child
+// with the configured probability. Lamarckian search writes the improved
+// individual back; Baldwinian search works on a scratch copy, so only the
+// objectives of the child change.
+func (a *simpleAlgorithm) localSearch(child Individual, objective []float64) []float64 {
+	if a.search == nil || !a.config.RandomNumberGenerator.Flip(a.searchProbability) {
+		return objective
+	}
+	target := child
+	if a.baldwinian {
+		CopyIndividual(a.scratch, child)
+		target = a.scratch
+	}
+	return a.search.Search(a.config, target, objective, a.evaluate)
+}
+
+// evaluate counts the evaluations of the local search.
+func (a *simpleAlgorithm) evaluate(individual Individual) []float64 {
+	if a.config.MaxEvaluations > 0 && a.evaluations >= a.config.MaxEvaluations {
+		return nil
+	}
+	a.evaluations++
+	a.result.Evaluations++
+	return a.config.ObjectiveFunc(individual)
+}
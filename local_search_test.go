@@ -0,0 +1,102 @@
+package moea
+
+import "testing"
+
+func countOnes(individual Individual) []float64 {
+	result := 0.0
+	for _, b := range individual.Value(0).([]bool) {
+		if b {
+			result--
+		}
+	}
+	return []float64{result}
+}
+
+// setBit sets the first unset bit, counting the searched individuals.
+type setBit struct{ searched int }
+
+func (s *setBit) Search(config *Config, individual Individual, objective []float64, evaluate ObjectiveFunc) []float64 {
+	s.searched++
+	for i, b := range individual.Value(0).([]bool) {
+		if !b {
+			individual.Mutate([]int{i})
+			if f := evaluate(individual); f != nil {
+				return f
+			}
+			individual.Mutate([]int{i})
+			break
+		}
+	}
+	return objective
+}
+
+func memeticConfig(operators SimpleAlgorithmOperators, seed uint32) *Config {
+	rng := NewXorshiftWithSeed(seed)
+	return &Config{
+		Algorithm:             NewSimpleAlgorithmWithOperators(operators),
+		Population:            NewRandomBooleanPopulationWithRNG(20, []int{40}, rng),
+		NumberOfValues:        1,
+		ObjectiveFunc:         countOnes,
+		NumberOfObjectives:    1,
+		MaxGenerations:        10,
+		CrossoverProbability:  0,
+		MutationProbability:   0,
+		RandomNumberGenerator: rng,
+	}
+}
+
+func TestLamarckianSearch(t *testing.T) {
+	search := &setBit{}
+	config := memeticConfig(SimpleAlgorithmOperators{LocalSearch: search, LocalSearchProbability: 0.5}, 1)
+	result, err := Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if search.searched < 70 || search.searched > 130 {
+		t.Errorf("expected about half of 200 offspring searched but were %v", search.searched)
+	}
+	if result.Evaluations != 20+200+search.searched {
+		t.Errorf("expected %v evaluations but were %v", 20+200+search.searched, result.Evaluations)
+	}
+	for i, r := range result.Individuals {
+		if f := countOnes(config.Population.Individual(i)); f[0] != r.Objective[0] {
+			t.Errorf("individual %d evaluates to %v but its objective is %v", i, f, r.Objective)
+		}
+	}
+}
+
+func TestBaldwinianSearch(t *testing.T) {
+	config := memeticConfig(SimpleAlgorithmOperators{LocalSearch: &setBit{}, Baldwinian: true}, 2)
+	result, err := Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range result.Individuals {
+		if f := countOnes(config.Population.Individual(i)); f[0] != r.Objective[0]+1 {
+			t.Errorf("individual %d evaluates to %v but its learned objective is %v", i, f, r.Objective)
+		}
+	}
+}
+
+func TestMaxEvaluations(t *testing.T) {
+	config := memeticConfig(SimpleAlgorithmOperators{LocalSearch: &setBit{}}, 3)
+	config.MaxGenerations = 0
+	config.MaxEvaluations = 500
+	evaluations := 0
+	objectiveFunc := config.ObjectiveFunc
+	config.ObjectiveFunc = func(individual Individual) []float64 {
+		evaluations++
+		return objectiveFunc(individual)
+	}
+	result, err := Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Evaluations != evaluations {
+		t.Errorf("counted %v evaluations but made %v", result.Evaluations, evaluations)
+	}
+	// only offspring evaluations run past the budget, not local search
+	if evaluations < 500 || evaluations >= 500+config.Population.Len() {
+		t.Errorf("expected the run to stop after 500 evaluations but made %v", evaluations)
+	}
+}
@@ -0,0 +1,265 @@
+// Package mcdm helps choosing a final solution among the members of a
+// front, such as those returned by Result.ParettoFrontier, by scoring them
+// with multi-criteria decision making methods.
+package mcdm
+
+import (
+	"math"
+	"sort"
+
+	"github.com/project-draco/moea"
+)
+
+type Direction int
+
+const (
+	Minimize Direction = iota
+	Maximize
+)
+
+// Criteria tell how the objectives of the front are compared. Objectives
+// without a direction are minimized, and the weights, equal when nil, are
+// the relative importance of the objectives, scaled to sum 1.
+type Criteria struct {
+	Directions []Direction
+	Weights    []float64
+}
+
+// A Method scores every member of a front; lower scores are preferred.
+type Method func(front []moea.IndividualResult, criteria Criteria) []float64
+
+// Rank returns the indexes of the members of front, from the preferred one
+// on. Ties keep the order of the front.
+func Rank(front []moea.IndividualResult, criteria Criteria, method Method) []int {
+	scores := method(front, criteria)
+	result := make([]int, len(front))
+	for i := range result {
+		result[i] = i
+	}
+	sort.SliceStable(result, func(i, j int) bool { return scores[result[i]] < scores[result[j]] })
+	return result
+}
+
+// Choose returns the preferred member of front, which must not be empty.
+func Choose(front []moea.IndividualResult, criteria Criteria, method Method) moea.IndividualResult {
+	if len(front) == 0 {
+		panic("cannot choose from an empty front")
+	}
+	return front[Rank(front, criteria, method)[0]]
+}
+
+// WeightedSum scores the members by the weighted sum of their normalized
+// objectives.
+func WeightedSum(front []moea.IndividualResult, criteria Criteria) []float64 {
+	return CompromiseProgramming(1)(front, criteria)
+}
+
+// Tchebycheff scores the members by their largest weighted normalized
+// objective, augmented by a small fraction of the weighted sum so that
+// weakly dominated members lose ties.
+func Tchebycheff(front []moea.IndividualResult, criteria Criteria) []float64 {
+	scores := CompromiseProgramming(math.Inf(1))(front, criteria)
+	for i, sum := range WeightedSum(front, criteria) {
+		scores[i] += 1e-6 * sum
+	}
+	return scores
+}
+
+// CompromiseProgramming returns the method scoring the members by the
+// weighted L_p distance of their normalized objectives to the ideal point.
+// p is 1 for the weighted sum and infinite for Tchebycheff.
+func CompromiseProgramming(p float64) Method {
+	if p < 1 {
+		panic("compromise programming needs p >= 1")
+	}
+	return func(front []moea.IndividualResult, criteria Criteria) []float64 {
+		if len(front) == 0 {
+			return nil
+		}
+		normalized := normalize(front, criteria)
+		w := weights(criteria, len(normalized[0]))
+		scores := make([]float64, len(front))
+		for i, f := range normalized {
+			for j, x := range f {
+				if math.IsInf(p, 1) {
+					scores[i] = math.Max(scores[i], w[j]*x)
+				} else {
+					scores[i] += math.Pow(w[j]*x, p)
+				}
+			}
+			if !math.IsInf(p, 1) {
+				scores[i] = math.Pow(scores[i], 1/p)
+			}
+		}
+		return scores
+	}
+}
+
+// TOPSIS scores the members by their relative distance to the ideal
+// solution, d+ / (d+ + d-), where d+ and d- are the distances to the best
+// and worst values of every objective after vector normalization and
+// weighting. It is one minus the closeness coefficient.
+func TOPSIS(front []moea.IndividualResult, criteria Criteria) []float64 {
+	if len(front) == 0 {
+		return nil
+	}
+	m := len(front[0].Objective)
+	w := weights(criteria, m)
+	norms := make([]float64, m)
+	for _, member := range front {
+		for j, f := range member.Objective {
+			norms[j] += f * f
+		}
+	}
+	v := make([][]float64, len(front))
+	best, worst := make([]float64, m), make([]float64, m)
+	for i, member := range front {
+		v[i] = make([]float64, m)
+		for j, f := range member.Objective {
+			if norms[j] > 0 {
+				v[i][j] = w[j] * f / math.Sqrt(norms[j])
+			}
+			if direction(criteria, j) == Maximize {
+				v[i][j] = -v[i][j]
+			}
+			if i == 0 || v[i][j] < best[j] {
+				best[j] = v[i][j]
+			}
+			if i == 0 || v[i][j] > worst[j] {
+				worst[j] = v[i][j]
+			}
+		}
+	}
+	scores := make([]float64, len(front))
+	for i := range v {
+		plus, minus := 0.0, 0.0
+		for j := range v[i] {
+			plus += (v[i][j] - best[j]) * (v[i][j] - best[j])
+			minus += (v[i][j] - worst[j]) * (v[i][j] - worst[j])
+		}
+		if plus > 0 {
+			scores[i] = math.Sqrt(plus) / (math.Sqrt(plus) + math.Sqrt(minus))
+		}
+	}
+	return scores
+}
+
+// Knee scores the members by the trade-off they offer: moving from a knee
+// to any other member of the front costs, in the sum of the normalized
+// objectives made worse, much more than it gains in those made better.
+// The score is minus the smallest such ratio, so the knee comes first.
+// Weights are not used.
+func Knee(front []moea.IndividualResult, criteria Criteria) []float64 {
+	normalized := normalize(front, criteria)
+	scores := make([]float64, len(front))
+	for i, x := range normalized {
+		ratio := math.Inf(1)
+		for k, y := range normalized {
+			gain, loss := 0.0, 0.0
+			for j := range x {
+				if d := y[j] - x[j]; d > 0 {
+					loss += d
+				} else {
+					gain -= d
+				}
+			}
+			if k != i && gain > 0 {
+				ratio = math.Min(ratio, loss/gain)
+			}
+		}
+		scores[i] = -ratio
+	}
+	return scores
+}
+
+// PseudoWeights returns the pseudo-weights of the members (Deb, 2001): the
+// normalized distance of every objective to its worst value in the front,
+// scaled to sum 1, which tells how much each member favours each
+// objective.
+func PseudoWeights(front []moea.IndividualResult, criteria Criteria) [][]float64 {
+	normalized := normalize(front, criteria)
+	result := make([][]float64, len(front))
+	for i, f := range normalized {
+		result[i] = make([]float64, len(f))
+		sum := 0.0
+		for j, x := range f {
+			result[i][j] = 1 - x
+			sum += result[i][j]
+		}
+		for j := range result[i] {
+			if sum > 0 {
+				result[i][j] /= sum
+			} else {
+				result[i][j] = 1 / float64(len(f))
+			}
+		}
+	}
+	return result
+}
+
+// PseudoWeight scores the members by the distance, in the sum of absolute
+// differences, of their pseudo-weights to the weights of the criteria.
+func PseudoWeight(front []moea.IndividualResult, criteria Criteria) []float64 {
+	scores := make([]float64, len(front))
+	for i, p := range PseudoWeights(front, criteria) {
+		w := weights(criteria, len(p))
+		for j := range p {
+			scores[i] += math.Abs(p[j] - w[j])
+		}
+	}
+	return scores
+}
+
+// normalize maps the objectives of the front to [0, 1], 0 being the best
+// value of the front and 1 the worst, whatever the direction.
+func normalize(front []moea.IndividualResult, criteria Criteria) [][]float64 {
+	if len(front) == 0 {
+		return nil
+	}
+	m := len(front[0].Objective)
+	low, high := make([]float64, m), make([]float64, m)
+	for j := 0; j < m; j++ {
+		low[j], high[j] = math.Inf(1), math.Inf(-1)
+		for _, member := range front {
+			low[j] = math.Min(low[j], member.Objective[j])
+			high[j] = math.Max(high[j], member.Objective[j])
+		}
+	}
+	result := make([][]float64, len(front))
+	for i, member := range front {
+		result[i] = make([]float64, m)
+		for j, f := range member.Objective {
+			if high[j] == low[j] {
+				continue
+			}
+			result[i][j] = (f - low[j]) / (high[j] - low[j])
+			if direction(criteria, j) == Maximize {
+				result[i][j] = 1 - result[i][j]
+			}
+		}
+	}
+	return result
+}
+
+func direction(criteria Criteria, j int) Direction {
+	if j < len(criteria.Directions) {
+		return criteria.Directions[j]
+	}
+	return Minimize
+}
+
+func weights(criteria Criteria, m int) []float64 {
+	result := make([]float64, m)
+	sum := 0.0
+	for j := range result {
+		result[j] = 1
+		if criteria.Weights != nil {
+			result[j] = criteria.Weights[j]
+		}
+		sum += result[j]
+	}
+	for j := range result {
+		result[j] /= sum
+	}
+	return result
+}
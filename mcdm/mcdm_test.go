@@ -0,0 +1,70 @@
+package mcdm
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+func front(objectives ...[]float64) []moea.IndividualResult {
+	result := make([]moea.IndividualResult, len(objectives))
+	for i, o := range objectives {
+		result[i].Objective = o
+	}
+	return result
+}
+
+func TestRank(t *testing.T) {
+	f := front([]float64{0, 1}, []float64{0.2, 0.2}, []float64{1, 0}, []float64{0.6, 0.05})
+	for _, test := range []struct {
+		name     string
+		criteria Criteria
+		method   Method
+		out      []int
+	}{
+		{"weighted sum", Criteria{Weights: []float64{0.9, 0.1}}, WeightedSum, []int{0, 1, 3, 2}},
+		{"maximize", Criteria{Directions: []Direction{Minimize, Maximize}}, WeightedSum, []int{0, 1, 3, 2}},
+		{"tchebycheff", Criteria{}, Tchebycheff, []int{1, 3, 0, 2}},
+		{"knee", Criteria{}, Knee, []int{1, 3, 0, 2}},
+		{"pseudo-weight", Criteria{Weights: []float64{0.1, 0.9}}, PseudoWeight, []int{2, 3, 1, 0}},
+	} {
+		if out := Rank(f, test.criteria, test.method); !reflect.DeepEqual(out, test.out) {
+			t.Error(test.name, "expected", test.out, "but was", out)
+		}
+	}
+}
+
+func TestTOPSIS(t *testing.T) {
+	f := front([]float64{0, 1}, []float64{1, 0}, []float64{0.4, 0.4})
+	scores := TOPSIS(f, Criteria{})
+	if math.Abs(scores[0]-0.5) > 1e-12 || math.Abs(scores[1]-0.5) > 1e-12 || scores[2] >= 0.5 {
+		t.Error("Unexpected scores", scores)
+	}
+	if best := Choose(f, Criteria{}, TOPSIS); best.Objective[0] != 0.4 {
+		t.Error("Expected the balanced member, got", best.Objective)
+	}
+	scores = TOPSIS(f, Criteria{Directions: []Direction{Maximize, Minimize}})
+	if scores[1] != 0 {
+		t.Error("Expected the ideal member to score 0, got", scores)
+	}
+}
+
+func TestCompromiseProgramming(t *testing.T) {
+	f := front([]float64{0, 1}, []float64{0.3, 0.4}, []float64{1, 0})
+	if scores := CompromiseProgramming(2)(f, Criteria{}); math.Abs(scores[1]-0.25) > 1e-12 {
+		t.Error("Expected 0.25, got", scores)
+	}
+	infinite := CompromiseProgramming(math.Inf(1))(f, Criteria{})
+	if !reflect.DeepEqual(infinite, []float64{0.5, 0.2, 0.5}) {
+		t.Error("Expected the Tchebycheff distances, got", infinite)
+	}
+}
+
+func TestPseudoWeights(t *testing.T) {
+	f := front([]float64{0, 1}, []float64{0.25, 0.25}, []float64{1, 0})
+	if w := PseudoWeights(f, Criteria{}); !reflect.DeepEqual(w, [][]float64{{1, 0}, {0.5, 0.5}, {0, 1}}) {
+		t.Error("Unexpected pseudo-weights", w)
+	}
+}
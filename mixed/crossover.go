@@ -0,0 +1,57 @@
+package mixed
+
+import (
+	"math"
+
+	"github.com/project-draco/moea"
+)
+
+// Crossover recombines mixed individuals variable by variable: real and
+// integer variables with simulated binary crossover (SBX) of distribution
+// index Eta (15 by default), integers being rounded, and categorical and
+// boolean variables by swapping them with probability 1/2. It returns 0
+// when the parents were recombined and -1 when they were copied.
+type Crossover struct{ Eta float64 }
+
+func (c *Crossover) Crossover(config *moea.Config, parent1, parent2, child1, child2 moea.Individual, probability float64) int {
+	child1.Copy(parent1, 0, child1.Len())
+	child2.Copy(parent2, 0, child2.Len())
+	if !config.RandomNumberGenerator.Flip(probability) {
+		return -1
+	}
+	eta := c.Eta
+	if eta <= 0 {
+		eta = 15
+	}
+	rng := config.RandomNumberGenerator
+	c1, c2 := child1.(*individual), child2.(*individual)
+	for i, v := range c1.variables {
+		if !rng.FairFlip() {
+			continue
+		}
+		switch v.kind {
+		case Real, Integer:
+			x1, x2 := sbx(c1.values[i], c2.values[i], eta, rng)
+			if v.kind == Integer {
+				x1, x2 = math.Round(x1), math.Round(x2)
+			}
+			c1.values[i], c2.values[i] = v.clamp(x1), v.clamp(x2)
+		default:
+			c1.values[i], c2.values[i] = c2.values[i], c1.values[i]
+		}
+	}
+	return 0
+}
+
+// sbx is Deb and Agrawal's simulated binary crossover of two values. u is
+// kept below 1, which some generators return and which makes beta infinite.
+func sbx(x1, x2, eta float64, rng moea.RNG) (float64, float64) {
+	u := math.Min(rng.Float64(), math.Nextafter(1, 0))
+	var beta float64
+	if u <= 0.5 {
+		beta = math.Pow(2*u, 1/(eta+1))
+	} else {
+		beta = math.Pow(1/(2*(1-u)), 1/(eta+1))
+	}
+	return 0.5 * ((1+beta)*x1 + (1-beta)*x2), 0.5 * ((1-beta)*x1 + (1+beta)*x2)
+}
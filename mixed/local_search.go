@@ -0,0 +1,62 @@
+package mixed
+
+import "github.com/project-draco/moea"
+
+// PatternSearch is a compass search over the real variables. It moves each
+// one by plus and minus a step, Step times its range at first (0.1 by
+// default), keeping the moves that yield dominating objectives, and halves
+// the step after a sweep without improvement, until it falls below MinStep
+// times the range (0.001 by default) or MaxEvaluations evaluations are
+// spent (100 by default).
+type PatternSearch struct {
+	Step, MinStep  float64
+	MaxEvaluations int
+}
+
+func (s *PatternSearch) Search(config *moea.Config, mutated moea.Individual, objective []float64,
+	evaluate moea.ObjectiveFunc) []float64 {
+	ind := mutated.(*individual)
+	step, minStep, max := s.Step, s.MinStep, s.MaxEvaluations
+	if step <= 0 {
+		step = 0.1
+	}
+	if minStep <= 0 {
+		minStep = 0.001
+	}
+	if max <= 0 {
+		max = 100
+	}
+	evaluations := 0
+	for ; step >= minStep && evaluations < max; step /= 2 {
+		improved := true
+		for improved && evaluations < max {
+			improved = false
+			for j, v := range ind.variables {
+				if v.kind != Real || v.max == v.min {
+					continue
+				}
+				for _, direction := range []float64{1, -1} {
+					if evaluations == max {
+						break
+					}
+					old := ind.values[j]
+					ind.values[j] = v.clamp(old + direction*step*(v.max-v.min))
+					if ind.values[j] == old {
+						continue
+					}
+					f := evaluate(ind)
+					evaluations++
+					if f != nil && moea.Dominates(f, objective) {
+						objective, improved = f, true
+						break
+					}
+					ind.values[j] = old
+					if f == nil {
+						return objective
+					}
+				}
+			}
+		}
+	}
+	return objective
+}
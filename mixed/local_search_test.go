@@ -0,0 +1,31 @@
+package mixed
+
+import (
+	"math"
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+func TestPatternSearch(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(1)
+	population := NewRandomMixedPopulation(2, []Segment{RealSegment(3, -5, 5), BooleanSegment(1)}, rng)
+	config := &moea.Config{Population: population, RandomNumberGenerator: rng}
+	sphere := func(individual moea.Individual) []float64 {
+		result := 0.0
+		for i := 0; i < 3; i++ {
+			x := individual.Value(i).(float64) - 1
+			result += x * x
+		}
+		return []float64{result}
+	}
+	ind := population.Individual(0)
+	flag := ind.Value(3)
+	f := (&PatternSearch{MinStep: 1e-6, MaxEvaluations: 10000}).Search(config, ind, sphere(ind), sphere)
+	if f[0] > 1e-8 || math.Abs(sphere(ind)[0]-f[0]) > 1e-15 {
+		t.Errorf("expected the minimum of the sphere but was %v at %v", f, ind.(*individual).values)
+	}
+	if ind.Value(3) != flag {
+		t.Error("pattern search changed a boolean")
+	}
+}
@@ -0,0 +1,209 @@
+// Package mixed encodes chromosomes made of segments of real, integer,
+// categorical and boolean variables. Each variable is one position of the
+// individual, and Value returns a float64, int, string or bool according to
+// its segment.
+package mixed
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/project-draco/moea"
+)
+
+type Kind int
+
+const (
+	Real Kind = iota
+	Integer
+	Categorical
+	Boolean
+)
+
+type Segment struct {
+	Kind Kind
+	// Length is the number of variables of the segment, 1 by default.
+	Length int
+	// Min and Max bound Real and Integer variables, both inclusive.
+	Min, Max float64
+	// Categories are the values of Categorical variables.
+	Categories []string
+	// Scale is the standard deviation of the Gaussian mutation of Real and
+	// Integer variables relative to their range, 0.1 by default.
+	Scale float64
+}
+
+type population []*individual
+
+type individual struct {
+	values    []float64
+	variables []variable
+	rng       moea.RNG
+}
+
+// variable is the segment of one position.
+type variable struct {
+	kind       Kind
+	min, max   float64
+	categories []string
+	sigma      float64
+}
+
+func RealSegment(length int, min, max float64) Segment {
+	return Segment{Kind: Real, Length: length, Min: min, Max: max}
+}
+
+func IntegerSegment(length int, min, max int) Segment {
+	return Segment{Kind: Integer, Length: length, Min: float64(min), Max: float64(max)}
+}
+
+func CategoricalSegment(length int, categories ...string) Segment {
+	return Segment{Kind: Categorical, Length: length, Categories: categories}
+}
+
+func BooleanSegment(length int) Segment {
+	return Segment{Kind: Boolean, Length: length}
+}
+
+func NewRandomMixedPopulation(size int, segments []Segment, rng moea.RNG) moea.Population {
+	if size%2 == 1 {
+		size++
+	}
+	variables := layout(segments)
+	result := make(population, size)
+	for i := range result {
+		result[i] = &individual{make([]float64, len(variables)), variables, rng}
+		for j, v := range variables {
+			result[i].values[j] = v.random(rng)
+		}
+	}
+	return result
+}
+
+func layout(segments []Segment) []variable {
+	var result []variable
+	for i, s := range segments {
+		v := variable{kind: s.Kind, min: s.Min, max: s.Max, categories: s.Categories}
+		switch s.Kind {
+		case Real, Integer:
+			if s.Min > s.Max {
+				panic(fmt.Sprintf("Invalid bounds in segment %d", i))
+			}
+			if s.Kind == Integer {
+				v.min, v.max = math.Ceil(s.Min), math.Floor(s.Max)
+				if v.min > v.max {
+					panic(fmt.Sprintf("No integer within the bounds of segment %d", i))
+				}
+			}
+			scale := s.Scale
+			if scale <= 0 {
+				scale = 0.1
+			}
+			v.sigma = scale * (v.max - v.min)
+		case Categorical:
+			if len(s.Categories) == 0 {
+				panic(fmt.Sprintf("No categories in segment %d", i))
+			}
+			v.max = float64(len(s.Categories) - 1)
+		case Boolean:
+			v.max = 1
+		default:
+			panic(fmt.Sprintf("Invalid kind in segment %d", i))
+		}
+		length := s.Length
+		if length <= 0 {
+			length = 1
+		}
+		for j := 0; j < length; j++ {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func (v *variable) random(rng moea.RNG) float64 {
+	switch v.kind {
+	case Real:
+		return v.min + rng.Float64()*(v.max-v.min)
+	case Integer, Categorical:
+		return v.min + float64(rng.Intn(int(v.max-v.min)+1))
+	}
+	if rng.FairFlip() {
+		return 1
+	}
+	return 0
+}
+
+// mutate perturbs real and integer values with Gaussian noise, changes a
+// category to a different one and flips a boolean.
+func (v *variable) mutate(value float64, rng moea.RNG) float64 {
+	switch v.kind {
+	case Real:
+		return v.clamp(value + rng.NormFloat64()*v.sigma)
+	case Integer:
+		step := math.Max(1, math.Round(math.Abs(rng.NormFloat64()*v.sigma)))
+		if rng.FairFlip() {
+			step = -step
+		}
+		if value+step < v.min || value+step > v.max {
+			step = -step
+		}
+		return v.clamp(value + step)
+	case Categorical:
+		if v.max == 0 {
+			return value
+		}
+		other := float64(rng.Intn(int(v.max)))
+		if other >= value {
+			other++
+		}
+		return other
+	}
+	return 1 - value
+}
+
+func (v *variable) clamp(value float64) float64 {
+	return math.Max(v.min, math.Min(v.max, value))
+}
+
+func (p population) Len() int { return len(p) }
+
+func (p population) Individual(i int) moea.Individual { return p[i] }
+
+func (p population) Clone() moea.Population {
+	result := make(population, len(p))
+	for i, individual := range p {
+		result[i] = individual.clone()
+	}
+	return result
+}
+
+func (ind *individual) Len() int { return len(ind.values) }
+
+func (ind *individual) Value(i int) interface{} {
+	switch ind.variables[i].kind {
+	case Integer:
+		return int(ind.values[i])
+	case Categorical:
+		return ind.variables[i].categories[int(ind.values[i])]
+	case Boolean:
+		return ind.values[i] != 0
+	}
+	return ind.values[i]
+}
+
+func (ind *individual) Copy(other moea.Individual, start, end int) {
+	copy(ind.values[start:end], other.(*individual).values[start:end])
+}
+
+func (ind *individual) Mutate(mutations []int) {
+	for _, m := range mutations {
+		ind.values[m] = ind.variables[m].mutate(ind.values[m], ind.rng)
+	}
+}
+
+func (ind *individual) Clone() moea.Individual { return ind.clone() }
+
+func (ind *individual) clone() *individual {
+	return &individual{append([]float64(nil), ind.values...), ind.variables, ind.rng}
+}
@@ -0,0 +1,137 @@
+package mixed
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+var segments = []Segment{
+	RealSegment(2, -1, 1),
+	IntegerSegment(1, 3, 7),
+	CategoricalSegment(1, "red", "green", "blue"),
+	BooleanSegment(2),
+}
+
+func checkValues(t *testing.T, individual moea.Individual) {
+	if individual.Len() != 6 {
+		t.Fatalf("expected 6 variables but was %v", individual.Len())
+	}
+	for i := 0; i < 2; i++ {
+		if v := individual.Value(i).(float64); v < -1 || v > 1 {
+			t.Errorf("real %v out of bounds", v)
+		}
+	}
+	if v := individual.Value(2).(int); v < 3 || v > 7 {
+		t.Errorf("integer %v out of bounds", v)
+	}
+	if v := individual.Value(3).(string); v != "red" && v != "green" && v != "blue" {
+		t.Errorf("unexpected category %v", v)
+	}
+	_ = individual.Value(4).(bool)
+	_ = individual.Value(5).(bool)
+}
+
+func TestMutate(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(1)
+	population := NewRandomMixedPopulation(3, segments, rng)
+	if population.Len() != 4 {
+		t.Errorf("expected an even population but was %v", population.Len())
+	}
+	individual := population.Individual(0)
+	for n := 0; n < 1000; n++ {
+		before := individual.Clone()
+		individual.Mutate([]int{0, 1, 2, 3, 4, 5})
+		checkValues(t, individual)
+		for i := 2; i < 6; i++ {
+			if individual.Value(i) == before.Value(i) {
+				t.Errorf("variable %d did not change from %v", i, before.Value(i))
+			}
+		}
+	}
+}
+
+func TestCrossover(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(2)
+	population := NewRandomMixedPopulation(4, segments, rng)
+	config := &moea.Config{Population: population, RandomNumberGenerator: rng}
+	p1, p2, c1, c2 := population.Individual(0), population.Individual(1), population.Individual(2), population.Individual(3)
+	for n := 0; n < 1000; n++ {
+		if (&Crossover{}).Crossover(config, p1, p2, c1, c2, 1) != 0 {
+			t.Fatal("expected crossover")
+		}
+		checkValues(t, c1)
+		checkValues(t, c2)
+		for i := 3; i < 6; i++ {
+			v1, v2 := c1.Value(i), c2.Value(i)
+			if !(v1 == p1.Value(i) && v2 == p2.Value(i)) && !(v1 == p2.Value(i) && v2 == p1.Value(i)) {
+				t.Errorf("variable %d: %v and %v do not come from %v and %v", i, v1, v2, p1.Value(i), p2.Value(i))
+			}
+		}
+		// SBX preserves the mean of unclamped values
+		x1, x2, y1, y2 := p1.Value(0).(float64), p2.Value(0).(float64), c1.Value(0).(float64), c2.Value(0).(float64)
+		if y1 > -1 && y1 < 1 && y2 > -1 && y2 < 1 && math.Abs(x1+x2-y1-y2) > 1e-12 {
+			t.Errorf("mean changed from %v to %v", (x1+x2)/2, (y1+y2)/2)
+		}
+		p1, c1 = c1, p1
+	}
+}
+
+// maxRNG returns the largest value every time.
+type maxRNG struct{}
+
+func (maxRNG) Flip(probability float64) bool { return true }
+func (maxRNG) FairFlip() bool                { return true }
+func (maxRNG) Float64() float64              { return 1 }
+func (maxRNG) Intn(n int) int                { return n - 1 }
+func (maxRNG) NormFloat64() float64          { return 0 }
+func (maxRNG) Perm(n int) []int              { return rand.Perm(n) }
+
+func TestSBXUpperBound(t *testing.T) {
+	y1, y2 := sbx(0.25, 0.5, 15, maxRNG{})
+	if math.IsNaN(y1) || math.IsNaN(y2) || math.IsInf(y1, 0) || math.IsInf(y2, 0) {
+		t.Errorf("expected finite children but were %v and %v", y1, y2)
+	}
+}
+
+func TestRun(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(3)
+	config := &moea.Config{
+		Algorithm: moea.NewSimpleAlgorithmWithOperators(moea.SimpleAlgorithmOperators{
+			Selection:   &moea.TournamentSelection{TournamentSize: 2},
+			Crossover:   &Crossover{},
+			Replacement: &moea.GenerationalReplacement{Elitism: 1},
+		}),
+		Population:     NewRandomMixedPopulation(40, segments, rng),
+		NumberOfValues: 6,
+		// best at reals 0.5, integer 5, green and true, false
+		ObjectiveFunc: func(individual moea.Individual) []float64 {
+			f := math.Abs(individual.Value(0).(float64)-0.5) + math.Abs(individual.Value(1).(float64)-0.5)
+			f += math.Abs(float64(individual.Value(2).(int) - 5))
+			if individual.Value(3).(string) != "green" {
+				f++
+			}
+			if !individual.Value(4).(bool) {
+				f++
+			}
+			if individual.Value(5).(bool) {
+				f++
+			}
+			return []float64{f}
+		},
+		NumberOfObjectives:    1,
+		MaxGenerations:        100,
+		CrossoverProbability:  0.9,
+		MutationProbability:   1.0 / 6,
+		RandomNumberGenerator: rng,
+	}
+	result, err := moea.Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.BestObjective[0] > 0.1 {
+		t.Errorf("expected a near optimal solution but was %v %v", result.BestObjective, result.Individuals[result.BestIndividualIndex].Values)
+	}
+}
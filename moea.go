@@ -1,16 +1,20 @@
 package moea // import "github.com/project-draco/moea"
 import (
-	"math"
 	"runtime"
 )
 
 type Config struct {
-	Algorithm             Algorithm
-	Population            Population
-	NumberOfValues        int
-	NumberOfObjectives    int
-	ObjectiveFunc         ObjectiveFunc
-	MaxGenerations        int
+	Algorithm          Algorithm
+	Population         Population
+	NumberOfValues     int
+	NumberOfObjectives int
+	ObjectiveFunc      ObjectiveFunc
+	MaxGenerations     int
+	// MaxEvaluations stops the run once that many objective evaluations,
+	// local search included, have been made. With MaxGenerations at 0 the
+	// run only stops on evaluations, and with both at 0 no generation is
+	// run.
+	MaxEvaluations        int
 	CrossoverProbability  float64
 	MutationProbability   float64
 	RandomNumberGenerator RNG
@@ -48,7 +52,26 @@ type Result struct {
 	AverageObjective    []float64
 	Mutations           int
 	Crossovers          int
-	Individuals         []IndividualResult
+	// Evaluations counts the calls to ObjectiveFunc. The first generation
+	// includes the evaluation of the initial population.
+	Evaluations int
+	// CrossoverProbability and MutationProbability are the rates used in
+	// the generation, and CrossoverOperatorProbabilities and
+	// MutationOperatorProbabilities the probabilities of the crossover and
+	// mutation operators chosen by adaptive operator selection, if any, in
+	// the order they were given.
+	CrossoverProbability           float64
+	MutationProbability            float64
+	CrossoverOperatorProbabilities []float64
+	MutationOperatorProbabilities  []float64
+	// NicheCounts is, for reference point based selections such as
+	// NSGA-III, the number of members of the population associated with
+	// each reference point.
+	NicheCounts []int
+	Individuals []IndividualResult
+	// Archive holds, for algorithms that keep one apart from the
+	// population, such as ε-MOEA, the solutions archived so far.
+	Archive []IndividualResult
 }
 
 type IndividualResult struct {
@@ -60,41 +83,7 @@ type IndividualResult struct {
 }
 
 func Run(config *Config) (*Result, error) {
-	result := &Result{}
-	config.Algorithm.Initialize(config)
-	result.BestIndividual = config.Population.Individual(0).Clone()
-	result.BestObjective = make([]float64, config.NumberOfObjectives)
-	for i := 0; i < config.NumberOfObjectives; i++ {
-		result.BestObjective[i] = math.MaxFloat64
-	}
-	for i := 0; i < config.MaxGenerations; i++ {
-		generationResult, err := config.Algorithm.Generation()
-		if err != nil {
-			return nil, err
-		}
-		if config.OnGenerationFunc != nil {
-			config.OnGenerationFunc(i, generationResult)
-		}
-		for j := 0; j < config.NumberOfObjectives; j++ {
-			if generationResult.BestObjective[j] < result.BestObjective[j] {
-				if j == 0 {
-					result.BestIndividual.Copy(generationResult.BestIndividual, 0, result.BestIndividual.Len())
-					result.BestIndividualIndex = generationResult.BestIndividualIndex
-				}
-				result.BestObjective[j] = generationResult.BestObjective[j]
-			}
-		}
-		result.Mutations += generationResult.Mutations
-		result.Crossovers += generationResult.Crossovers
-		result.Individuals = generationResult.Individuals
-	}
-	type finalizer interface {
-		Finalize(*Result)
-	}
-	if f, ok := config.Algorithm.(finalizer); ok {
-		f.Finalize(result)
-	}
-	return result, nil
+	return NewSession(config).Resume()
 }
 
 func RunRepeatedly(configfunc func() *Config, repeat int) (*Result, error) {
@@ -131,6 +120,47 @@ func RunRepeatedly(configfunc func() *Config, repeat int) (*Result, error) {
 	return bestResult, nil
 }
 
+// RunRepeatedlyWithSeed is like RunRepeatedly, but hands every repetition its
+// own generator from NewXorshiftStreams(seed, repeat). The best result only
+// depends on seed, not on how repetitions are scheduled; ties go to the
+// earliest repetition.
+func RunRepeatedlyWithSeed(configfunc func(RNG) *Config, repeat int, seed uint64) (*Result, error) {
+	if repeat < 1 {
+		repeat = 1
+	}
+	streams := NewXorshiftStreams(seed, repeat)
+	results := make([]*Result, repeat)
+	errs := make([]error, repeat)
+	indexes := make(chan int, repeat)
+	for i := 0; i < repeat; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	var numCPU = runtime.GOMAXPROCS(0)
+	c := make(chan int, numCPU)
+	for i := 0; i < numCPU; i++ {
+		go func() {
+			for j := range indexes {
+				results[j], errs[j] = Run(configfunc(streams[j]))
+			}
+			c <- 1
+		}()
+	}
+	for i := 0; i < numCPU; i++ {
+		<-c
+	}
+	var bestResult *Result
+	for i := 0; i < repeat; i++ {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if bestResult == nil || bestResult.BestObjective[0] > results[i].BestObjective[0] {
+			bestResult = results[i]
+		}
+	}
+	return bestResult, nil
+}
+
 func (r *Result) ParettoFrontier() (front []IndividualResult) {
 	for i, ind1 := range r.Individuals {
 		dominatedBySomeOtherIndividual := false
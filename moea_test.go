@@ -1,6 +1,8 @@
 package moea
 
 import (
+	"reflect"
+	"sync/atomic"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -57,3 +59,211 @@ func TestParettoFrontier(t *testing.T) {
 		})
 	}
 }
+
+func onemaxConfig(rng RNG) *Config {
+	return &Config{
+		Algorithm:          NewSimpleAlgorithm(&TournamentSelection{TournamentSize: 3}, &FastMutation{}),
+		Population:         NewRandomBooleanPopulationWithRNG(20, []int{16, 16}, rng),
+		NumberOfValues:     2,
+		NumberOfObjectives: 1,
+		ObjectiveFunc: func(individual Individual) []float64 {
+			result := []float64{0}
+			for i := 0; i < 2; i++ {
+				for _, b := range individual.Value(i).([]bool) {
+					if b {
+						result[0]--
+					}
+				}
+			}
+			return result
+		},
+		MaxGenerations:        10,
+		CrossoverProbability:  0.9,
+		MutationProbability:   1.0 / 32,
+		RandomNumberGenerator: rng,
+	}
+}
+
+func TestSameSeedSameResult(t *testing.T) {
+	r1, err := Run(onemaxConfig(NewXorshiftWithSeed(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, _ := Run(onemaxConfig(NewXorshiftWithSeed(7)))
+	if !reflect.DeepEqual(r1, r2) {
+		t.Error("Runs with the same seed must give identical results")
+	}
+	r3, _ := Run(onemaxConfig(NewXorshiftWithSeed(8)))
+	if reflect.DeepEqual(r1.Individuals, r3.Individuals) {
+		t.Error("Runs with different seeds should differ")
+	}
+	r1, err = RunRepeatedlyWithSeed(onemaxConfig, 5, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, _ = RunRepeatedlyWithSeed(onemaxConfig, 5, 42)
+	if !reflect.DeepEqual(r1, r2) {
+		t.Error("Repeated runs with the same seed must give identical results")
+	}
+}
+
+func TestXorshift(t *testing.T) {
+	rng := NewXorshiftWithSeed(1)
+	counts := make([]int, 7)
+	for i := 0; i < 70000; i++ {
+		counts[rng.Intn(7)]++
+	}
+	for i, c := range counts {
+		if c < 9500 || c > 10500 {
+			t.Error("Intn is not uniform: count", c, "for", i)
+		}
+	}
+	sum, sumsq := 0.0, 0.0
+	for i := 0; i < 100000; i++ {
+		v := rng.NormFloat64()
+		sum += v
+		sumsq += v * v
+	}
+	if mean, variance := sum/100000, sumsq/100000; mean < -0.02 || mean > 0.02 || variance < 0.98 || variance > 1.02 {
+		t.Error("NormFloat64 has mean", mean, "and variance", variance)
+	}
+	p := rng.Perm(10)
+	seen := make([]bool, 10)
+	for _, v := range p {
+		seen[v] = true
+	}
+	for i, s := range seen {
+		if !s {
+			t.Error("Perm misses", i, p)
+		}
+	}
+	streams := NewXorshiftStreams(1, 2)
+	if streams[0].Float64() == streams[1].Float64() {
+		t.Error("Streams must differ")
+	}
+}
+
+func TestReplacementKeepsBest(t *testing.T) {
+	for _, replacement := range []ReplacementOperator{
+		&GenerationalReplacement{Elitism: 1},
+		&MuPlusLambdaReplacement{},
+		&WorstReplacement{Lambda: 4},
+	} {
+		config := onemaxConfig(NewXorshiftWithSeed(3))
+		config.Algorithm = NewSimpleAlgorithmWithOperators(SimpleAlgorithmOperators{
+			Selection:   &TournamentSelection{TournamentSize: 3},
+			Mutation:    &FastMutation{},
+			Replacement: replacement,
+		})
+		config.MutationProbability = 0.5
+		best := 0.0
+		config.OnGenerationFunc = func(generation int, result *Result) {
+			if result.BestObjective[0] > best {
+				t.Errorf("%T: best objective went from %v to %v", replacement, best, result.BestObjective[0])
+			}
+			best = result.BestObjective[0]
+			for _, individual := range result.Individuals {
+				if individual.Objective[0] < best {
+					t.Errorf("%T: individual %v better than best %v", replacement, individual.Objective[0], best)
+				}
+			}
+		}
+		if _, err := Run(config); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCrowdingReplacement(t *testing.T) {
+	for _, replacement := range []ReplacementOperator{&CrowdingReplacement{}, &DeterministicCrowding{}, &MuCommaLambdaReplacement{Lambda: 30}} {
+		config := onemaxConfig(NewXorshiftWithSeed(5))
+		config.Algorithm = NewSimpleAlgorithmWithOperators(SimpleAlgorithmOperators{Replacement: replacement})
+		result, err := Run(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Individuals) != 20 || result.BestObjective[0] > -16 {
+			t.Errorf("%T: unexpected result %v", replacement, result.BestObjective)
+		}
+	}
+}
+
+func TestSteadyState(t *testing.T) {
+	for _, replacement := range []SteadyStateReplacement{&ReplaceWorst{}, &ReplaceRandom{}, &ReplaceOldest{}} {
+		results := make([]*Result, 2)
+		for i := range results {
+			config := onemaxConfig(NewXorshiftWithSeed(11))
+			var evaluations int64
+			objectiveFunc := config.ObjectiveFunc
+			config.ObjectiveFunc = func(individual Individual) []float64 {
+				atomic.AddInt64(&evaluations, 1)
+				return objectiveFunc(individual)
+			}
+			config.Algorithm = NewSteadyStateAlgorithm(SteadyStateOperators{Replacement: replacement, Workers: 1, ReportInterval: 5})
+			config.MaxGenerations = 40
+			generations := 0
+			config.OnGenerationFunc = func(generation int, result *Result) {
+				generations++
+				// the next offspring may or may not be under evaluation already
+				if e := atomic.LoadInt64(&evaluations); e < int64(20+5*generations) || e > int64(20+5*generations+1) {
+					t.Errorf("%T: expected %v evaluations but was %v", replacement, 20+5*generations, e)
+				}
+			}
+			result, err := Run(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			results[i] = result
+		}
+		if !reflect.DeepEqual(results[0].BestObjective, results[1].BestObjective) ||
+			!reflect.DeepEqual(results[0].Individuals, results[1].Individuals) {
+			t.Errorf("%T: same seed gave different results", replacement)
+		}
+	}
+}
+
+func TestSteadyStateWorkers(t *testing.T) {
+	config := onemaxConfig(NewXorshiftWithSeed(13))
+	config.Algorithm = NewSteadyStateAlgorithm(SteadyStateOperators{Workers: 4})
+	config.MaxGenerations = 30
+	best := 0.0
+	config.OnGenerationFunc = func(generation int, result *Result) {
+		if result.BestObjective[0] > best {
+			t.Errorf("best objective went from %v to %v", best, result.BestObjective[0])
+		}
+		best = result.BestObjective[0]
+	}
+	result, err := Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.BestObjective[0] > -24 {
+		t.Errorf("expected progress but best was %v", result.BestObjective[0])
+	}
+}
+
+func TestSteadyStateOffspringListeners(t *testing.T) {
+	config := onemaxConfig(NewXorshiftWithSeed(17))
+	mutation := &AdaptiveMutation{Operators: []MutationOperator{&FastMutation{}, &RegularMutation{}}}
+	config.Algorithm = NewSteadyStateAlgorithm(SteadyStateOperators{
+		Mutation: mutation, Crossover: &TwoPointCrossover{}, Workers: 2,
+	})
+	config.MaxGenerations = 20
+	result, err := Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only the offspring still under evaluation await their reward
+	if len(mutation.pending) > 2 {
+		t.Errorf("expected at most 2 pending mutations but was %v", len(mutation.pending))
+	}
+	if len(result.MutationOperatorProbabilities) != 2 {
+		t.Errorf("expected the probabilities of 2 mutations but was %v", result.MutationOperatorProbabilities)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a crossover learning from offspring to be rejected")
+		}
+	}()
+	NewSteadyStateAlgorithm(SteadyStateOperators{Crossover: &AdaptiveCrossover{}})
+}
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/binary"
 	"github.com/project-draco/moea/integer"
+	"github.com/project-draco/moea/internal/fixture"
 )
 
 func TestNewFromString(t *testing.T) {
@@ -46,3 +48,39 @@ func (r MockRNG) FairFlip() bool {
 func (r MockRNG) Float64() float64 {
 	return float64(r)
 }
+
+func (r MockRNG) Intn(n int) int {
+	return int(float64(r) * float64(n))
+}
+
+func (r MockRNG) NormFloat64() float64 {
+	return 0
+}
+
+func (r MockRNG) Perm(n int) []int {
+	result := make([]int, n)
+	for i := range result {
+		result[i] = i
+	}
+	return result
+}
+
+func TestSameSeedSameResult(t *testing.T) {
+	fixture.SameSeedSameResult(t, func(seed uint32) *moea.Config {
+		selection := &NsgaSelection{
+			ValuesAsFloat: func(i moea.Individual) []float64 {
+				return []float64{float64(i.Value(0).(binary.BinaryString).Int().Int64())}
+			},
+			LowerBounds: []float64{0},
+			UpperBounds: []float64{255},
+		}
+		config := fixture.SCH(moea.NewSimpleAlgorithm(selection, &moea.FastMutation{}), seed)
+		// NSGA maximizes.
+		sch := config.ObjectiveFunc
+		config.ObjectiveFunc = func(i moea.Individual) []float64 {
+			f := sch(i)
+			return []float64{-f[0], -f[1]}
+		}
+		return config
+	}, 3)
+}
@@ -20,6 +20,16 @@ type NsgaIISelection struct {
 	Pool                  []int
 	Elite                 []int
 	sequence              []int
+	lowerBounds           []float64
+	upperBounds           []float64
+	// Dominance, when not nil, replaces Pareto dominance among feasible
+	// individuals, and Distance replaces the crowding distance within each
+	// front. Both are used for preference articulation.
+	Dominance Dominance
+	Distance  Distance
+	// Truncation, when not nil, chooses the members of the last front that
+	// fit in the population instead of the crowding distance.
+	Truncation Truncation
 }
 
 // crowddist.c: assign_crowding_distance, assign_crowding_distance_list, assign_crowding_distance_indices
@@ -119,11 +129,14 @@ func (n *NsgaIISelection) OnGeneration(config *moea.Config, population moea.Popu
 func (n *NsgaIISelection) Finalize(config *moea.Config, population moea.Population, objectives [][]float64, result *moea.Result) {
 	n.Merge(population, objectives)
 	n.fillNondominatedSort(population, objectives)
+	n.Summarize(config, population, objectives, result)
+}
+
+// Summarize reports the final population in result.
+func (n *NsgaIISelection) Summarize(config *moea.Config, population moea.Population, objectives [][]float64, result *moea.Result) {
 	for i := 0; i < population.Len(); i++ {
 		result.Individuals[i].Objective = objectives[i]
-		for j := 0; j < config.NumberOfValues; j++ {
-			result.Individuals[i].Values[j] = population.Individual(i).Value(j)
-		}
+		result.Individuals[i].Values = moea.RecordValues(config, population.Individual(i), result.Individuals[i].Values)
 		result.Individuals[i].Parent1 = -1
 		result.Individuals[i].Parent2 = -1
 		result.Individuals[i].CrossSite = -1
@@ -154,18 +167,21 @@ func (n *NsgaIISelection) Selection(config *moea.Config, objectives [][]float64)
 }
 
 func (n *NsgaIISelection) checkDominance(objectives [][]float64, a, b int) int {
-	if n.constraintsViolations[a] < 0 && n.constraintsViolations[b] < 0 {
-		if n.constraintsViolations[a] > n.constraintsViolations[b] {
+	va, vb := n.violation(objectives, a), n.violation(objectives, b)
+	if va < 0 && vb < 0 {
+		if va > vb {
 			return 1
-		} else if n.constraintsViolations[a] < n.constraintsViolations[b] {
+		} else if va < vb {
 			return -1
 		} else {
 			return 0
 		}
-	} else if n.constraintsViolations[a] < 0 && n.constraintsViolations[b] == 0 {
+	} else if va < 0 && vb == 0 {
 		return -1
-	} else if n.constraintsViolations[a] == 0 && n.constraintsViolations[b] < 0 {
+	} else if va == 0 && vb < 0 {
 		return 1
+	} else if n.Dominance != nil {
+		return n.Dominance.Compare(objectives[a], objectives[b])
 	} else {
 		flag1 := false
 		flag2 := false
@@ -186,7 +202,33 @@ func (n *NsgaIISelection) checkDominance(objectives [][]float64, a, b int) int {
 	}
 }
 
+// violation returns the constraint violation of the a-th individual,
+// negative when it is infeasible, including how far its objectives are
+// outside the objective bounds of the preferences.
+func (n *NsgaIISelection) violation(objectives [][]float64, a int) float64 {
+	v := n.constraintsViolations[a]
+	for j, o := range objectives[a] {
+		if n.lowerBounds != nil && o < n.lowerBounds[j] {
+			v -= n.lowerBounds[j] - o
+		}
+		if n.upperBounds != nil && o > n.upperBounds[j] {
+			v -= o - n.upperBounds[j]
+		}
+	}
+	return v
+}
+
 func (n *NsgaIISelection) AssignCrowdingDistance(objectives [][]float64, dist []int, crowdingDistance []float64) {
+	if n.Distance != nil && len(dist) > 0 {
+		n.Distance.AssignDistance(n, objectives, dist, crowdingDistance)
+		return
+	}
+	n.CrowdingDistance(objectives, dist, crowdingDistance)
+}
+
+// CrowdingDistance assigns the crowding distance of the members of the
+// front dist, regardless of Distance.
+func (n *NsgaIISelection) CrowdingDistance(objectives [][]float64, dist []int, crowdingDistance []float64) {
 	if len(objectives) == 0 || len(dist) == 0 {
 		return
 	}
@@ -226,13 +268,19 @@ func (n *NsgaIISelection) AssignCrowdingDistance(objectives [][]float64, dist []
 }
 
 func (n *NsgaIISelection) crowdingFill(newPopulation moea.Population, newObjectives [][]float64, elite []int, start int) {
+	n.AssignCrowdingDistance(n.MixedObjectives, elite, n.MixedCrowdingDistance)
 	for i, index := range elite {
 		n.indexes[0][i] = index
 	}
-	sort.Stable(byDistance{n.indexes[0][0:len(elite)], n.MixedCrowdingDistance})
+	if n.Truncation != nil {
+		kept := n.Truncation.Truncate(n.MixedObjectives, elite, newPopulation.Len()-start)
+		copy(n.indexes[0][len(elite)-len(kept):len(elite)], kept)
+	} else {
+		sort.Stable(byDistance{n.indexes[0][0:len(elite)], n.MixedCrowdingDistance})
+	}
 	for i, j := start, len(elite)-1; i < newPopulation.Len(); i, j = i+1, j-1 {
 		individual := n.MixedPopulation.Individual(n.indexes[0][j])
-		newPopulation.Individual(i).Copy(individual, 0, individual.Len())
+		moea.CopyIndividual(newPopulation.Individual(i), individual)
 		newObjectives[i] = n.MixedObjectives[n.indexes[0][j]]
 		n.crowdingDistance[i] = n.MixedCrowdingDistance[n.indexes[0][j]]
 	}
@@ -247,6 +295,7 @@ func (n *NsgaIISelection) fillNondominatedSort(newPopulation moea.Population, ne
 	remaining := newPopulation.Len()
 	for i := 0; i < newPopulation.Len(); {
 		elite := n.Elite[0:1]
+		elite[0] = pool[0]
 		n.RankDominance(&pool, &elite)
 		if i+len(elite) <= newPopulation.Len() {
 			n.SelectBestRank(&elite, &rank, &newPopulation, &newObjectives, &remaining, &i)
@@ -282,7 +331,7 @@ func (n *NsgaIISelection) SelectBestRank(elite *[]int, rank *int, newPopulation
 	j := *i
 	for _, index := range *elite {
 		individual := n.MixedPopulation.Individual(index)
-		(*newPopulation).Individual(*i).Copy(individual, 0, individual.Len())
+		moea.CopyIndividual((*newPopulation).Individual(*i), individual)
 		(*newObjectives)[*i] = n.MixedObjectives[index]
 		n.Rank[*i] = *rank
 		*i++
@@ -294,7 +343,6 @@ func (n *NsgaIISelection) SelectBestRank(elite *[]int, rank *int, newPopulation
 
 func (n *NsgaIISelection) SelectRemaining(remaining int, elite []int, newPopulation moea.Population, newObjectives [][]float64, rank int, index *int) {
 	n.crowdingFill(newPopulation, newObjectives, elite, *index)
-	n.AssignCrowdingDistance(n.MixedObjectives, elite, n.MixedCrowdingDistance)
 	for ; *index < newPopulation.Len(); *index++ {
 		n.Rank[*index] = rank
 	}
@@ -349,7 +397,7 @@ func (n *NsgaIISelection) Merge(population moea.Population, objectives [][]float
 		} else {
 			individual = population.Individual(i - n.PreviousPopulation.Len())
 		}
-		n.MixedPopulation[i].Copy(individual, 0, individual.Len())
+		moea.CopyIndividual(n.MixedPopulation[i], individual)
 	}
 	for i, o := range n.PreviousObjectives {
 		n.MixedObjectives[i] = o
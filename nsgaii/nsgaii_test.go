@@ -7,6 +7,7 @@ import (
 
 	"github.com/project-draco/moea"
 	"github.com/project-draco/moea/integer"
+	"github.com/project-draco/moea/internal/fixture"
 )
 
 var n *NsgaIISelection
@@ -149,3 +150,33 @@ func TestAssignRankAndCrowdingDistance(t *testing.T) {
 		}
 	}
 }
+
+func schConfig(selection moea.SelectionOperator, seed uint32) *moea.Config {
+	return fixture.SCH(moea.NewSimpleAlgorithm(selection, &moea.FastMutation{}), seed)
+}
+
+func TestSameSeedSameResult(t *testing.T) {
+	fixture.SameSeedSameResult(t, func(seed uint32) *moea.Config {
+		return schConfig(&NsgaIISelection{}, seed)
+	}, 5)
+}
+
+func TestFillNondominatedSortTruncatesLastFront(t *testing.T) {
+	c.NumberOfObjectives = 2
+	n.Initialize(c)
+	n.PreviousPopulation = integer.NewRandomIntegerPopulation(4, 1, []integer.Bound{{0, 10}}, rng)
+	n.Merge(integer.NewRandomIntegerPopulation(4, 1, []integer.Bound{{0, 10}}, rng), nil)
+	newPopulation := integer.NewRandomIntegerPopulation(4, 1, []integer.Bound{{0, 10}}, rng)
+	newObjectives := make([][]float64, 4)
+	// A single front, too large to fit, whose members at 4 and 7 are the
+	// least crowded after the extremes at 0 and 10.
+	n.MixedObjectives = [][]float64{{8, 2}, {0, 10}, {4, 6}, {1, 9}, {10, 0}, {2, 8}, {7, 3}, {9, 1}}
+	n.fillNondominatedSort(newPopulation, newObjectives)
+	selected := map[float64]bool{}
+	for _, f := range newObjectives {
+		selected[f[0]] = true
+	}
+	if !reflect.DeepEqual(selected, map[float64]bool{0: true, 4: true, 7: true, 10: true}) {
+		t.Error("Expected the members at 0, 4, 7 and 10 but was", newObjectives)
+	}
+}
@@ -0,0 +1,322 @@
+package nsgaii
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/project-draco/moea"
+)
+
+// Dominance compares the objectives a and b, returning 1 when a is
+// preferred, -1 when b is and 0 when neither.
+type Dominance interface {
+	Compare(a, b []float64) int
+}
+
+// Distance assigns to the members of a front a distance in which larger
+// is preferred, both in the tournaments and when the last front is
+// truncated. objectives are those of the whole population.
+type Distance interface {
+	AssignDistance(n *NsgaIISelection, objectives [][]float64, front []int, distance []float64)
+}
+
+// ReferencePointDistance is the preference distance of R-NSGA-II (Deb and
+// Sundar, 2006). Within a front, the members are ranked by their weighted
+// Euclidean distance, normalized by the objective ranges of the population,
+// to each of the reference Points, and keep their best rank. Then, in the
+// order of their ranks, each remaining member clears the members whose
+// normalized objectives differ from its own by at most Epsilon in sum;
+// cleared members are preferred only to the members of worse fronts.
+type ReferencePointDistance struct {
+	Points [][]float64
+	// Weights of the objectives in the distance, equal when nil.
+	Weights []float64
+	Epsilon float64
+}
+
+// GDominance is the g-dominance of Molina et al. (2009): the individuals
+// that satisfy all the aspiration levels of Point, or none of them, are
+// preferred to the others, and Pareto dominance decides among equally
+// preferred individuals.
+type GDominance struct{ Point []float64 }
+
+// LightBeam focuses each front on the neighbourhood of its middle point,
+// as in the light beam search of Jaszkiewicz and Słowiński (1999). The
+// middle point is the member closest, by the achievement scalarizing
+// function, to the beam from the Aspiration point towards the Reservation
+// point. The neighbourhood holds the members that outrank the middle
+// point: those worse than it by more than the Indifference threshold on
+// no more objectives than they are better, and by no more than the
+// Preference threshold on any objective. The neighbourhood is spread by
+// crowding distance, and the other members are preferred by their
+// closeness to the beam. The thresholds default to 5% and 20% of the
+// distance between the aspiration and the reservation levels.
+type LightBeam struct {
+	Aspiration   []float64
+	Reservation  []float64
+	Indifference []float64
+	Preference   []float64
+}
+
+// NewRNsgaIISelection returns the R-NSGA-II selection focused on the
+// reference points, with the given clearing epsilon.
+func NewRNsgaIISelection(points [][]float64, epsilon float64) *NsgaIISelection {
+	if len(points) == 0 {
+		panic("R-NSGA-II needs at least one reference point")
+	}
+	return &NsgaIISelection{Distance: &ReferencePointDistance{Points: points, Epsilon: epsilon}}
+}
+
+// NewGDominanceSelection returns the NSGA-II selection ranked by
+// g-dominance with respect to point.
+func NewGDominanceSelection(point []float64) *NsgaIISelection {
+	if len(point) == 0 {
+		panic("g-dominance needs a reference point")
+	}
+	return &NsgaIISelection{Dominance: &GDominance{point}}
+}
+
+// NewLightBeamSelection returns the NSGA-II selection focused by the light
+// beam from aspiration to reservation, which must be worse in every
+// objective.
+func NewLightBeamSelection(aspiration, reservation []float64) *NsgaIISelection {
+	if err := checkLightBeam(aspiration, reservation); err != nil {
+		panic(err)
+	}
+	return &NsgaIISelection{Distance: &LightBeam{Aspiration: aspiration, Reservation: reservation}}
+}
+
+var (
+	errLightBeamLength = errors.New("nsgaii: aspiration and reservation points must have the same length")
+	errLightBeamLevels = errors.New("nsgaii: reservation levels must be worse than aspiration levels")
+)
+
+func checkLightBeam(aspiration, reservation []float64) error {
+	if len(aspiration) != len(reservation) {
+		return errLightBeamLength
+	}
+	for i := range aspiration {
+		if reservation[i] <= aspiration[i] {
+			return errLightBeamLevels
+		}
+	}
+	return nil
+}
+
+func (r *ReferencePointDistance) AssignDistance(_ *NsgaIISelection, objectives [][]float64, front []int, distance []float64) {
+	m := len(objectives[front[0]])
+	low, high := objectiveRanges(objectives, m)
+	normalized := func(index, j int) float64 {
+		if high[j] == low[j] {
+			return 0
+		}
+		return (objectives[index][j] - low[j]) / (high[j] - low[j])
+	}
+	rank := make([]int, len(front))
+	for i := range rank {
+		rank[i] = len(front)
+	}
+	order := make([]int, len(front))
+	pointDistance := make([]float64, len(front))
+	for _, point := range r.Points {
+		for i, index := range front {
+			sum := 0.0
+			for j := 0; j < m; j++ {
+				w := 1.0 / float64(m)
+				if r.Weights != nil {
+					w = r.Weights[j]
+				}
+				if high[j] > low[j] {
+					d := (objectives[index][j] - point[j]) / (high[j] - low[j])
+					sum += w * d * d
+				}
+			}
+			pointDistance[i] = math.Sqrt(sum)
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool { return pointDistance[order[a]] < pointDistance[order[b]] })
+		for position, i := range order {
+			if position+1 < rank[i] {
+				rank[i] = position + 1
+			}
+		}
+	}
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return rank[order[a]] < rank[order[b]] })
+	cleared := make([]bool, len(front))
+	for position, i := range order {
+		if cleared[i] {
+			continue
+		}
+		for _, k := range order[position+1:] {
+			if cleared[k] {
+				continue
+			}
+			sum := 0.0
+			for j := 0; j < m; j++ {
+				sum += math.Abs(normalized(front[i], j) - normalized(front[k], j))
+			}
+			cleared[k] = sum <= r.Epsilon
+		}
+	}
+	for i, index := range front {
+		distance[index] = -float64(rank[i])
+		if cleared[i] {
+			distance[index] -= float64(len(front))
+		}
+	}
+}
+
+// objectiveRanges returns the minimum and maximum of each objective over
+// the population.
+func objectiveRanges(objectives [][]float64, m int) ([]float64, []float64) {
+	low, high := make([]float64, m), make([]float64, m)
+	for j := 0; j < m; j++ {
+		low[j], high[j] = math.Inf(1), math.Inf(-1)
+	}
+	for _, o := range objectives {
+		for j := 0; o != nil && j < m; j++ {
+			low[j] = math.Min(low[j], o[j])
+			high[j] = math.Max(high[j], o[j])
+		}
+	}
+	return low, high
+}
+
+func (g *GDominance) Compare(a, b []float64) int {
+	if fa, fb := g.flag(a), g.flag(b); fa != fb {
+		if fa {
+			return 1
+		}
+		return -1
+	}
+	better, worse := false, false
+	for i := range a {
+		if a[i] < b[i] {
+			better = true
+		} else if a[i] > b[i] {
+			worse = true
+		}
+	}
+	if better && !worse {
+		return 1
+	} else if worse && !better {
+		return -1
+	}
+	return 0
+}
+
+// flag reports whether objective satisfies all the aspiration levels of
+// the point or none of them.
+func (g *GDominance) flag(objective []float64) bool {
+	all, none := true, true
+	for i, level := range g.Point {
+		if objective[i] > level {
+			all = false
+		}
+		if objective[i] < level {
+			none = false
+		}
+	}
+	return all || none
+}
+
+func (l *LightBeam) AssignDistance(n *NsgaIISelection, objectives [][]float64, front []int, distance []float64) {
+	asf := func(index int) float64 {
+		result := math.Inf(-1)
+		for j, a := range l.Aspiration {
+			result = math.Max(result, (objectives[index][j]-a)/(l.Reservation[j]-a))
+		}
+		return result
+	}
+	middle := front[0]
+	for _, index := range front[1:] {
+		if asf(index) < asf(middle) {
+			middle = index
+		}
+	}
+	var neighbourhood []int
+	for _, index := range front {
+		if l.outranks(objectives[index], objectives[middle]) {
+			neighbourhood = append(neighbourhood, index)
+		} else {
+			distance[index] = -1 - (asf(index) - asf(middle))
+		}
+	}
+	n.CrowdingDistance(objectives, neighbourhood, distance)
+}
+
+// outranks reports whether a is at least as good as b.
+func (l *LightBeam) outranks(a, b []float64) bool {
+	better, worse := 0, 0
+	for j := range a {
+		scale := l.Reservation[j] - l.Aspiration[j]
+		indifference, preference := 0.05*scale, 0.2*scale
+		if l.Indifference != nil {
+			indifference = l.Indifference[j]
+		}
+		if l.Preference != nil {
+			preference = l.Preference[j]
+		}
+		if d := a[j] - b[j]; d > preference {
+			return false
+		} else if d > indifference {
+			worse++
+		} else if -d > indifference {
+			better++
+		}
+	}
+	return worse <= better
+}
+
+// UpdatePreferences takes the preferences of the decision maker into
+// account from the next environmental selection on. Reference points move
+// the g-dominance point, the light beam aspiration or the R-NSGA-II
+// points, and turn plain NSGA-II into R-NSGA-II; weights go to R-NSGA-II;
+// objective bounds make the individuals outside them infeasible. The ranks
+// and distances of the current population are reassigned. A light beam
+// aspiration must be better than the reservation point in every objective.
+func (n *NsgaIISelection) UpdatePreferences(config *moea.Config, preferences moea.Preferences) error {
+	if l, ok := n.Distance.(*LightBeam); ok && len(preferences.ReferencePoints) > 0 {
+		if err := checkLightBeam(preferences.ReferencePoints[0], l.Reservation); err != nil {
+			return err
+		}
+	}
+	if points := preferences.ReferencePoints; len(points) > 0 {
+		if d, ok := n.Dominance.(*GDominance); ok {
+			d.Point = points[0]
+		}
+		switch d := n.Distance.(type) {
+		case *ReferencePointDistance:
+			d.Points = points
+		case *LightBeam:
+			d.Aspiration = points[0]
+		case nil:
+			if n.Dominance == nil {
+				n.Distance = &ReferencePointDistance{Points: points, Epsilon: 0.001}
+			}
+		}
+	}
+	if d, ok := n.Distance.(*ReferencePointDistance); ok && preferences.Weights != nil {
+		d.Weights = preferences.Weights
+	}
+	if preferences.LowerBounds != nil {
+		n.lowerBounds = preferences.LowerBounds
+	}
+	if preferences.UpperBounds != nil {
+		n.upperBounds = preferences.UpperBounds
+	}
+	if n.PreviousObjectives != nil {
+		n.AssignRankAndCrowdingDistance(n.PreviousObjectives)
+	}
+	return nil
+}
+
+// CurrentPopulation returns the survivors of the last environmental
+// selection, nil before the first one.
+func (n *NsgaIISelection) CurrentPopulation(config *moea.Config) (moea.Population, [][]float64) {
+	return n.PreviousPopulation, n.PreviousObjectives
+}
@@ -0,0 +1,110 @@
+package nsgaii
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+func finalValues(t *testing.T, selection *NsgaIISelection) []float64 {
+	config := schConfig(selection, 7)
+	config.MaxGenerations = 50
+	result, err := moea.Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []float64
+	for _, individual := range result.Individuals {
+		values = append(values, 100-(individual.Objective[1]-individual.Objective[0]+10000)/200)
+	}
+	return values
+}
+
+func TestGDominanceCompare(t *testing.T) {
+	g := &GDominance{[]float64{1, 1}}
+	for _, f := range []struct {
+		a, b []float64
+		out  int
+	}{
+		{[]float64{0.5, 0.5}, []float64{0, 2}, 1},
+		{[]float64{2, 2}, []float64{0, 2}, 1},
+		{[]float64{0, 2}, []float64{2, 0}, 0},
+		{[]float64{0.5, 0.5}, []float64{0.2, 0.2}, -1},
+		{[]float64{0, 3}, []float64{0, 2}, -1},
+	} {
+		if out := g.Compare(f.a, f.b); out != f.out {
+			t.Error("Expected", f.out, "but was", out, "comparing", f.a, f.b)
+		}
+	}
+}
+
+func TestPreferenceFocus(t *testing.T) {
+	for _, f := range []struct {
+		name      string
+		selection *NsgaIISelection
+		min, max  float64
+	}{
+		{"R-NSGA-II", NewRNsgaIISelection([][]float64{{400, 6400}}, 0.001), 10, 30},
+		{"g-dominance", NewGDominanceSelection([]float64{3600, 3600}), 40, 60},
+		{"light beam", NewLightBeamSelection([]float64{0, 0}, []float64{10000, 2500}), 55, 85},
+	} {
+		for _, x := range finalValues(t, f.selection) {
+			if x < f.min || x > f.max {
+				t.Error(f.name, "expected the population within", f.min, f.max, "but found", x)
+			}
+		}
+	}
+}
+
+func TestSessionPreferences(t *testing.T) {
+	selection := &NsgaIISelection{}
+	config := schConfig(selection, 7)
+	config.MaxGenerations = 100
+	s := moea.NewSession(config)
+	s.Step(30)
+	if err := s.UpdatePreferences(moea.Preferences{ReferencePoints: [][]float64{{400, 6400}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := selection.Distance.(*ReferencePointDistance); !ok {
+		t.Fatal("Expected reference points to turn NSGA-II into R-NSGA-II")
+	}
+	s.Step(30)
+	for _, individual := range s.CurrentFront() {
+		if x := 100 - (individual.Objective[1]-individual.Objective[0]+10000)/200; x < 5 || x > 35 {
+			t.Error("Expected the front around the reference point, found", x)
+		}
+	}
+	s.UpdatePreferences(moea.Preferences{LowerBounds: []float64{3600, 0}, UpperBounds: []float64{4900, 10000}})
+	result, err := s.Resume()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, individual := range result.Individuals {
+		if individual.Objective[0] < 3600 || individual.Objective[0] > 4900 {
+			t.Error("Expected the population within the objective bounds, found", individual.Objective)
+		}
+	}
+}
+
+func TestLightBeamPreferences(t *testing.T) {
+	selection := NewLightBeamSelection([]float64{0, 0}, []float64{10000, 2500})
+	s := moea.NewSession(schConfig(selection, 3))
+	defer s.Close()
+	if _, err := s.Step(2); err != nil {
+		t.Fatal(err)
+	}
+	beam := selection.Distance.(*LightBeam)
+	if err := s.UpdatePreferences(moea.Preferences{ReferencePoints: [][]float64{{5000, 3000}}}); err != errLightBeamLevels {
+		t.Errorf("Expected %v, got %v", errLightBeamLevels, err)
+	}
+	if !reflect.DeepEqual(beam.Aspiration, []float64{0, 0}) {
+		t.Error("Expected the aspiration to be kept, got", beam.Aspiration)
+	}
+	if err := s.UpdatePreferences(moea.Preferences{ReferencePoints: [][]float64{{100, 100}}}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(beam.Aspiration, []float64{100, 100}) {
+		t.Error("Expected the new aspiration, got", beam.Aspiration)
+	}
+}
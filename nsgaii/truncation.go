@@ -0,0 +1,62 @@
+package nsgaii
+
+import (
+	"math"
+
+	"github.com/project-draco/moea/indicator"
+)
+
+// Truncation returns k members of front, the last front that does not
+// fit in the population, to complete it.
+type Truncation interface {
+	Truncate(objectives [][]float64, front []int, k int) []int
+}
+
+// HypervolumeTruncation removes from the last front, one at a time, the
+// member contributing least to its hypervolume, as in SMS-EMOA. The
+// objectives are normalized by the front to [0, 1] and the reference point
+// is Reference in every objective, 1.1 by default. Unlike the crowding
+// distance it keeps a good distribution beyond three objectives, but the
+// exact hypervolume gets expensive there; IBEA scales better.
+type HypervolumeTruncation struct{ Reference float64 }
+
+func (h *HypervolumeTruncation) Truncate(objectives [][]float64, front []int, k int) []int {
+	reference := make([]float64, len(objectives[front[0]]))
+	for j := range reference {
+		reference[j] = h.Reference
+		if reference[j] <= 0 {
+			reference[j] = 1.1
+		}
+	}
+	points := make([][]float64, len(front))
+	for i := range points {
+		points[i] = make([]float64, len(reference))
+	}
+	for j := range reference {
+		low, high := math.Inf(1), math.Inf(-1)
+		for _, index := range front {
+			low = math.Min(low, objectives[index][j])
+			high = math.Max(high, objectives[index][j])
+		}
+		for i, index := range front {
+			if high > low {
+				points[i][j] = (objectives[index][j] - low) / (high - low)
+			}
+		}
+	}
+	kept := append([]int(nil), front...)
+	others := make([][]float64, 0, len(points))
+	for len(kept) > k {
+		total := indicator.Hypervolume(points, reference)
+		least, worst := math.Inf(1), 0
+		for i := range points {
+			others = append(append(others[:0], points[:i]...), points[i+1:]...)
+			if contribution := total - indicator.Hypervolume(others, reference); contribution < least {
+				least, worst = contribution, i
+			}
+		}
+		kept = append(kept[:worst], kept[worst+1:]...)
+		points = append(points[:worst], points[worst+1:]...)
+	}
+	return kept
+}
@@ -0,0 +1,30 @@
+package nsgaii
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHypervolumeTruncation(t *testing.T) {
+	objectives := [][]float64{{0, 1}, {0.5, 0.5}, {0.51, 0.49}, {1, 0}, {0.3, 0.3}}
+	kept := (&HypervolumeTruncation{}).Truncate(objectives, []int{0, 1, 2, 3}, 3)
+	if !reflect.DeepEqual(kept, []int{0, 1, 3}) {
+		t.Error("Expected the smallest contributor removed, got", kept)
+	}
+	values := finalValues(t, &NsgaIISelection{Truncation: &HypervolumeTruncation{}})
+	low, high := 255.0, 0.0
+	for _, x := range values {
+		if x > 100 {
+			t.Error("Expected a Pareto optimal population, found", x)
+		}
+		if x < low {
+			low = x
+		}
+		if x > high {
+			high = x
+		}
+	}
+	if low > 10 || high < 90 {
+		t.Errorf("Expected the extremes of the front kept, got [%v, %v]", low, high)
+	}
+}
@@ -1,47 +1,92 @@
 package nsgaiii
 
 import (
+	"errors"
 	"math"
-	"math/rand"
-	"sort"
 
 	"github.com/project-draco/moea"
 	"github.com/project-draco/moea/nsgaii"
+	"github.com/project-draco/moea/reference"
 )
 
+// NsgaIIISelection is the reference point based selection of NSGA-III
+// (Deb and Jain, 2014). Parents are chosen at random; the next population
+// is filled by nondominated fronts and the last front that does not fit is
+// niched around the reference points, which are associated with the
+// normalized objectives of the population.
 type NsgaIIISelection struct {
+	// ReferencePointsDivision is the number of divisions of each objective
+	// of the structured reference points of Das and Dennis. When 0, it is
+	// the smallest one giving at least as many points as the population.
 	ReferencePointsDivision int
-	referencePointArray     []ReferencePoint
+	// InsideDivision, when positive, adds an inner layer of structured
+	// reference points with that many divisions, shrunk halfway towards the
+	// centre of the simplex. With many objectives a single layer of few
+	// divisions has points on the boundary only.
+	InsideDivision int
+	// ReferencePoints, when not nil, replace the structured reference
+	// points. They are given in the normalized objective space, where the
+	// ideal point is the origin and the intercepts of the hyperplane are 1.
+	ReferencePoints [][]float64
+	points          [][]float64
+	nicheCounts     []int
+	selected        []int
+	rng             moea.RNG
 	nsgaii.NsgaIISelection
 }
 
-type mixedPopulation []moea.Individual
-type ReferencePoint struct {
-	position         []float64
-	associationCount int
-	associations     []NormalizedIndividual
-}
-
-type NormalizedIndividual struct {
-	index          int
-	objectives     []float64
-	referencePoint ReferencePoint
-	distance       float64
+func (n *NsgaIIISelection) Initialize(config *moea.Config) {
+	n.NsgaIISelection.Initialize(config)
+	n.rng = config.RandomNumberGenerator
+	n.points = n.referencePoints(config.NumberOfObjectives, config.Population.Len())
+	n.nicheCounts = make([]int, len(n.points))
+	n.selected = make([]int, 0, config.Population.Len())
 }
 
-type Association struct {
-	point *ReferencePoint
-	dist  float64
+// referencePoints returns the user supplied reference points or the
+// structured ones, in one or two layers.
+func (n *NsgaIIISelection) referencePoints(numberOfObjectives, populationSize int) [][]float64 {
+	if n.ReferencePoints != nil {
+		result := make([][]float64, len(n.ReferencePoints))
+		for i, p := range n.ReferencePoints {
+			if len(p) != numberOfObjectives {
+				panic("reference points must have one coordinate per objective")
+			}
+			result[i] = append([]float64(nil), p...)
+		}
+		return result
+	}
+	divisions := n.ReferencePointsDivision
+	if divisions <= 0 {
+		for divisions = 1; reference.Size(divisions, numberOfObjectives) < populationSize; divisions++ {
+		}
+	}
+	result := reference.DasDennis(divisions, numberOfObjectives)
+	if n.InsideDivision > 0 {
+		for _, p := range reference.DasDennis(n.InsideDivision, numberOfObjectives) {
+			for j := range p {
+				p[j] = (p[j] + 1/float64(numberOfObjectives)) / 2
+			}
+			result = append(result, p)
+		}
+	}
+	return result
 }
 
-func (n *NsgaIIISelection) Initialize(config *moea.Config) {
-	n.NsgaIISelection.Initialize(config)
-	n.referencePointArray = generateReferencePoints(n.ReferencePointsDivision, config.NumberOfObjectives)
+// ReferenceSet returns the reference points in use, in the order of the
+// niche counts of the result.
+func (n *NsgaIIISelection) ReferenceSet() [][]float64 {
+	return n.points
 }
 
 func (n *NsgaIIISelection) OnGeneration(config *moea.Config, population moea.Population, objectives [][]float64) {
 	if n.PreviousPopulation == nil {
 		n.AssignRankAndCrowdingDistance(objectives)
+		n.selected = n.selected[:0]
+		for i := range objectives {
+			n.selected = append(n.selected, i)
+		}
+		n.niche(objectives, n.selected, nil, 0)
 	} else {
 		n.Merge(population, objectives)
 		n.fillNondominatedSort(population, objectives)
@@ -50,31 +95,52 @@ func (n *NsgaIIISelection) OnGeneration(config *moea.Config, population moea.Pop
 	n.PreviousObjectives = objectives
 }
 
-func generateReferencePoints(numberOfDivisions int, nroObjectives int) []ReferencePoint {
-	var referencePointArray []ReferencePoint
-	var referencePoint ReferencePoint
-	referencePoint.position = make([]float64, nroObjectives)
-	generateReferencePointsRecursive(&referencePointArray, referencePoint, nroObjectives, numberOfDivisions, numberOfDivisions, 0)
-	return referencePointArray
+// Selection picks a parent at random: the environmental selection alone
+// keeps the population diverse.
+func (n *NsgaIIISelection) Selection(config *moea.Config, objectives [][]float64) int {
+	return config.RandomNumberGenerator.Intn(len(objectives))
+}
+
+func (n *NsgaIIISelection) Report(result *moea.Result) {
+	result.NicheCounts = append([]int(nil), n.nicheCounts...)
 }
 
-func generateReferencePointsRecursive(referencePointArray *[]ReferencePoint, currentPoint ReferencePoint, numberOfObjectives int, left int, total int, element int) {
-	if element == (numberOfObjectives - 1) {
-		currentPoint.position[element] = float64(left) / float64(total)
-		var referencePoint = currentPoint
-		referencePoint.position = make([]float64, len(currentPoint.position))
-		for i := 0; i < len(referencePoint.position); i++ {
-			referencePoint.position[i] = currentPoint.position[i]
+func (n *NsgaIIISelection) Finalize(config *moea.Config, population moea.Population, objectives [][]float64, result *moea.Result) {
+	n.Merge(population, objectives)
+	n.fillNondominatedSort(population, objectives)
+	n.Summarize(config, population, objectives, result)
+	n.Report(result)
+}
+
+var errReferencePointDimension = errors.New("nsgaiii: reference points must have one coordinate per objective")
+
+// UpdatePreferences replaces the reference points by those of the
+// preferences, in the normalized objective space, and hands the other
+// preferences to NSGA-II. The niches of the current population are
+// recounted.
+func (n *NsgaIIISelection) UpdatePreferences(config *moea.Config, preferences moea.Preferences) error {
+	for _, p := range preferences.ReferencePoints {
+		if len(p) != config.NumberOfObjectives {
+			return errReferencePointDimension
 		}
-		referencePoint.associations = make([]NormalizedIndividual, 0)
-		referencePoint.associationCount = 0
-		*referencePointArray = append(*referencePointArray, referencePoint)
-	} else {
-		for i := 0; i <= left; i++ {
-			currentPoint.position[element] = float64(i) / float64(total)
-			generateReferencePointsRecursive(referencePointArray, currentPoint, numberOfObjectives, left-i, total, element+1)
+	}
+	if preferences.ReferencePoints != nil {
+		n.ReferencePoints = preferences.ReferencePoints
+		n.points = n.referencePoints(config.NumberOfObjectives, config.Population.Len())
+		n.nicheCounts = make([]int, len(n.points))
+		preferences.ReferencePoints = nil
+	}
+	if err := n.NsgaIISelection.UpdatePreferences(config, preferences); err != nil {
+		return err
+	}
+	if n.PreviousObjectives != nil {
+		n.selected = n.selected[:0]
+		for i := range n.PreviousObjectives {
+			n.selected = append(n.selected, i)
 		}
+		n.niche(n.PreviousObjectives, n.selected, nil, 0)
 	}
+	return nil
 }
 
 func (n *NsgaIIISelection) fillNondominatedSort(newPopulation moea.Population, newObjectives [][]float64) {
@@ -82,23 +148,29 @@ func (n *NsgaIIISelection) fillNondominatedSort(newPopulation moea.Population, n
 	for i := 0; i < n.MixedPopulation.Len(); i++ {
 		pool = append(pool, i)
 	}
+	n.selected = n.selected[:0]
 	rank := 1
 	remaining := newPopulation.Len()
 	for i := 0; i < newPopulation.Len(); {
 		elite := n.Elite[0:1]
+		elite[0] = pool[0]
 		n.RankDominance(&pool, &elite)
 		if i+len(elite) <= newPopulation.Len() {
+			n.selected = append(n.selected, elite...)
 			n.SelectBestRank(&elite, &rank, &newPopulation, &newObjectives, &remaining, &i)
 		} else {
 			n.SelectRemaining(remaining, elite, newPopulation, newObjectives, rank, &i)
 		}
 	}
+	if remaining == 0 {
+		n.niche(n.MixedObjectives, n.selected, nil, 0)
+	}
 }
 
 func (n *NsgaIIISelection) SelectBestRank(elite *[]int, rank *int, newPopulation *moea.Population, newObjectives *[][]float64, remaining *int, i *int) {
 	for _, index := range *elite {
 		individual := n.MixedPopulation.Individual(index)
-		(*newPopulation).Individual(*i).Copy(individual, 0, individual.Len())
+		moea.CopyIndividual((*newPopulation).Individual(*i), individual)
 		(*newObjectives)[*i] = n.MixedObjectives[index]
 		n.Rank[*i] = *rank
 		*i++
@@ -108,202 +180,195 @@ func (n *NsgaIIISelection) SelectBestRank(elite *[]int, rank *int, newPopulation
 }
 
 func (n *NsgaIIISelection) SelectRemaining(remaining int, elite []int, newPopulation moea.Population, newObjectives [][]float64, rank int, index *int) {
-	var selectedIndividualsIndexes = n.SelectIndividuals(remaining, elite)
-	for _, selectedIndividualIndex := range selectedIndividualsIndexes {
-		individual := n.MixedPopulation.Individual(selectedIndividualIndex)
-		newPopulation.Individual(*index).Copy(individual, 0, individual.Len())
-		newObjectives[*index] = n.MixedObjectives[selectedIndividualIndex]
+	for _, selected := range n.SelectIndividuals(remaining, elite) {
+		individual := n.MixedPopulation.Individual(selected)
+		moea.CopyIndividual(newPopulation.Individual(*index), individual)
+		newObjectives[*index] = n.MixedObjectives[selected]
 		n.Rank[*index] = rank
 		*index++
 	}
-	n.AssignCrowdingDistance(n.MixedObjectives, elite, n.MixedCrowdingDistance)
-	for ; *index < newPopulation.Len(); *index++ {
-		n.Rank[*index] = rank
-	}
 }
 
+// SelectIndividuals chooses remaining members of the last front, elite, to
+// complete the fronts already selected.
 func (n *NsgaIIISelection) SelectIndividuals(remaining int, elite []int) []int {
-	var nroObjectives = len(n.NsgaIISelection.MixedObjectives[0])
-	var idealPoint = n.findIdealPoint(elite, nroObjectives)
-	var extremes = n.findExtremePoints(elite, nroObjectives)
-	var intercepts = n.constructHyperplane(elite, extremes, nroObjectives)
-	var normalizedIndividuals = n.normalizeObjectives(elite, intercepts, idealPoint, nroObjectives)
-	n.associate(normalizedIndividuals, nroObjectives)
-	var result = make([]int, 0)
-	for len(result) < remaining {
-		var minAssocRps = getMinimalAssociationCountReferences(n.referencePointArray)
-		if len(minAssocRps) > 0 {
-			var chosenRPIndex = rand.Intn(len(minAssocRps))
-			var chosenRP = n.referencePointArray[minAssocRps[chosenRPIndex]]
-			if len(chosenRP.associations) != 0 {
-				var selected NormalizedIndividual
-				var selectedIndex int
-				if chosenRP.associationCount != 0 {
-					selectedIndex = getMinimalRefPointDistIndividual(chosenRP.associations)
-				} else {
-					selectedIndex = rand.Intn(len(chosenRP.associations))
-				}
-				selected = chosenRP.associations[selectedIndex]
-				result = append(result, selected.index)
-				chosenRP.associationCount++
-				chosenRP.associations = append(chosenRP.associations[:selectedIndex], chosenRP.associations[selectedIndex+1:]...)
-			} else {
-				n.referencePointArray = append(n.referencePointArray[:minAssocRps[chosenRPIndex]], n.referencePointArray[minAssocRps[chosenRPIndex]+1:]...)
-			}
-		}
-	}
-	for i := 0; i < len(n.referencePointArray); i++ {
-		n.referencePointArray[i].associationCount = 0
-		n.referencePointArray[i].associations = nil
-	}
-	return result
+	return n.niche(n.MixedObjectives, n.selected, elite, remaining)
 }
 
-func (n *NsgaIIISelection) normalizeObjectives(individualsIndexes []int, intercepts []float64, idealPoint []float64, nroObjectives int) []NormalizedIndividual {
-	var normalizedIndividuals = make([]NormalizedIndividual, 0)
-	for i := 0; i < len(individualsIndexes); i++ {
-		var normalizeObjectives = make([]float64, nroObjectives)
-		for j := 0; j < nroObjectives; j++ {
-			normalizeObjectives[j] = normalizeObjective(n.NsgaIISelection.MixedObjectives[individualsIndexes[i]], j, intercepts, idealPoint)
+// niche associates members and front with the reference points and
+// chooses k members of front, preferring the reference points with the
+// fewest associated members. The resulting niche counts are kept for the
+// report.
+func (n *NsgaIIISelection) niche(objectives [][]float64, members, front []int, k int) []int {
+	all := append(append(make([]int, 0, len(members)+len(front)), members...), front...)
+	ideal := idealPoint(objectives, all)
+	intercepts := findIntercepts(objectives, all, ideal)
+	point := make([]int, len(all))
+	distance := make([]float64, len(all))
+	normalized := make([]float64, len(ideal))
+	for i, index := range all {
+		for j := range normalized {
+			normalized[j] = (objectives[index][j] - ideal[j]) / intercepts[j]
+		}
+		distance[i] = math.Inf(1)
+		for p, position := range n.points {
+			if d := perpendicularDistance(normalized, position); d < distance[i] {
+				point[i], distance[i] = p, d
+			}
 		}
-		var normalizedIndividual NormalizedIndividual
-		normalizedIndividual.objectives = normalizeObjectives
-		normalizedIndividual.index = individualsIndexes[i]
-		normalizedIndividuals = append(normalizedIndividuals, normalizedIndividual)
 	}
-	return normalizedIndividuals
-}
-
-func normalizeObjective(individual []float64, objectiveIndex int, intercepts []float64, idealPoint []float64) float64 {
-	var epsilon = 1e-20
-	if math.Abs(intercepts[objectiveIndex]-idealPoint[objectiveIndex]) > epsilon {
-		return individual[objectiveIndex] / (intercepts[objectiveIndex] - idealPoint[objectiveIndex])
-	} else {
-		return individual[objectiveIndex] / epsilon
+	counts := n.nicheCounts[:len(n.points)]
+	for p := range counts {
+		counts[p] = 0
 	}
-}
-
-func minimunArray(a []float64, b []float64) []float64 {
-	var aux = make([]float64, len(a))
-	for i := 0; i < len(aux); i++ {
-		if a[i] < b[i] {
-			aux[i] = a[i]
+	for i := range members {
+		counts[point[i]]++
+	}
+	result := make([]int, 0, k)
+	excluded := make([]bool, len(n.points))
+	taken := make([]bool, len(front))
+	var minimal, candidates []int
+	for len(result) < k {
+		minimal = minimal[:0]
+		for p, count := range counts {
+			if excluded[p] {
+				continue
+			}
+			if len(minimal) > 0 && count < counts[minimal[0]] {
+				minimal = minimal[:0]
+			}
+			if len(minimal) == 0 || count == counts[minimal[0]] {
+				minimal = append(minimal, p)
+			}
+		}
+		p := minimal[n.rng.Intn(len(minimal))]
+		candidates = candidates[:0]
+		for i := range front {
+			if !taken[i] && point[len(members)+i] == p {
+				candidates = append(candidates, i)
+			}
+		}
+		if len(candidates) == 0 {
+			excluded[p] = true
+			continue
+		}
+		chosen := candidates[0]
+		if counts[p] == 0 {
+			for _, i := range candidates {
+				if distance[len(members)+i] < distance[len(members)+chosen] {
+					chosen = i
+				}
+			}
 		} else {
-			aux[i] = b[i]
+			chosen = candidates[n.rng.Intn(len(candidates))]
 		}
+		taken[chosen] = true
+		counts[p]++
+		result = append(result, front[chosen])
 	}
-	return aux
+	return result
 }
 
-func (n *NsgaIIISelection) findIdealPoint(elite []int, nroObjectives int) []float64 {
-	var currentIdeal = make([]float64, nroObjectives)
-	for i := 0; i < len(currentIdeal); i++ {
-		currentIdeal[i] = math.Inf(1)
-	}
-	for _, index := range elite {
-		var aux = make([]float64, nroObjectives)
-		for i := 0; i < len(aux); i++ {
-			aux[i] = n.NsgaIISelection.MixedObjectives[index][i] * -1
+func idealPoint(objectives [][]float64, members []int) []float64 {
+	result := make([]float64, len(objectives[members[0]]))
+	for j := range result {
+		result[j] = math.Inf(1)
+		for _, index := range members {
+			result[j] = math.Min(result[j], objectives[index][j])
 		}
-		currentIdeal = minimunArray(currentIdeal, aux)
 	}
-	return currentIdeal
+	return result
 }
 
-func (n *NsgaIIISelection) findExtremeIndividualForObjective(elite []int, objective int) int {
-	var maxValue = math.Inf(-1)
-	var maxValueIndex = 0
-	for i := 0; i < len(elite); i++ {
-		if n.NsgaIISelection.MixedObjectives[elite[i]][objective]*-1 > maxValue {
-			maxValue = n.NsgaIISelection.MixedObjectives[elite[i]][objective] * -1
-			maxValueIndex = elite[i]
+// findIntercepts returns the intercepts, measured from the ideal point, of
+// the hyperplane through the extreme points, those minimizing the
+// achievement scalarizing function along each objective axis. When the
+// extreme points do not span a hyperplane, or it gives tiny or negative
+// intercepts, the nadir point of the members is used instead.
+func findIntercepts(objectives [][]float64, members []int, ideal []float64) []float64 {
+	const epsilon = 1e-6
+	m := len(ideal)
+	extremes := make([][]float64, m)
+	for i := range extremes {
+		best := math.Inf(1)
+		for _, index := range members {
+			asf := math.Inf(-1)
+			for j := 0; j < m; j++ {
+				weight := epsilon
+				if j == i {
+					weight = 1
+				}
+				asf = math.Max(asf, (objectives[index][j]-ideal[j])/weight)
+			}
+			if asf < best {
+				best = asf
+				extremes[i] = objectives[index]
+			}
 		}
 	}
-	return maxValueIndex
-}
-
-func (n *NsgaIIISelection) findExtremePoints(elite []int, nroObjectives int) []int {
-	var extremePoints = make([]int, nroObjectives)
-	for i := 0; i < len(extremePoints); i++ {
-		var index = n.findExtremeIndividualForObjective(elite, i)
-		extremePoints[i] = index
-	}
-	return extremePoints
-}
-
-func (n *NsgaIIISelection) constructHyperplane(elite []int, extremes []int, nroObjectives int) []float64 {
-	var intercepts = make([]float64, nroObjectives)
-	if n.hasDuplicateIndividuals(elite) {
-		for i := 0; i < len(intercepts); i++ {
-			intercepts[i] = n.NsgaIISelection.MixedObjectives[extremes[i]][i]
-		}
-	} else {
-		var b = make([]float64, nroObjectives)
-		for i := 0; i < len(b); i++ {
-			b[i] = 1
+	a := make([][]float64, m)
+	b := make([]float64, m)
+	for i := range a {
+		a[i] = make([]float64, m)
+		for j := range a[i] {
+			a[i][j] = extremes[i][j] - ideal[j]
 		}
-		var a = make([][]float64, len(extremes))
-		for i := 0; i < len(a); i++ {
-			var aux = make([]float64, nroObjectives)
-			for j := 0; j < nroObjectives; j++ {
-				aux[j] = n.NsgaIISelection.MixedObjectives[extremes[i]][j]
-			}
-			a[i] = aux
+		b[i] = 1
+	}
+	result := make([]float64, m)
+	x, ok := gaussianElimination(a, b)
+	for j := 0; ok && j < m; j++ {
+		result[j] = 1 / x[j]
+		ok = result[j] > epsilon && !math.IsInf(result[j], 0)
+	}
+	if ok {
+		return result
+	}
+	for j := range result {
+		result[j] = 0
+		for _, index := range members {
+			result[j] = math.Max(result[j], objectives[index][j]-ideal[j])
 		}
-		var x = n.guassianElimination(a, b)
-		for i := 0; i < len(intercepts); i++ {
-			intercepts[i] = 1 / x[i]
+		if result[j] <= epsilon {
+			result[j] = 1
 		}
 	}
-	return intercepts
+	return result
 }
 
-func (n *NsgaIIISelection) guassianElimination(a [][]float64, b []float64) []float64 {
-	var N = len(a)
-	var x = make([]float64, N)
-	for i := 0; i < N; i++ {
-		a[i] = append(a[i], b[i])
-	}
-	for base := 0; base < N-1; base++ {
-		for target := base + 1; target < N; target++ {
-			var ratio = a[target][base] / a[base][base]
-			for term := 0; term < len(a[base]); term++ {
-				a[target][term] = a[target][term] - a[base][term]*ratio
+// gaussianElimination solves a x = b with partial pivoting, modifying a
+// and b. It reports false when a is singular.
+func gaussianElimination(a [][]float64, b []float64) ([]float64, bool) {
+	n := len(a)
+	for base := 0; base < n; base++ {
+		pivot := base
+		for row := base + 1; row < n; row++ {
+			if math.Abs(a[row][base]) > math.Abs(a[pivot][base]) {
+				pivot = row
 			}
 		}
-	}
-	for i := 0; i < len(x); i++ {
-		x[i] = 0
-	}
-	for i := N - 1; i >= 0; i-- {
-		for known := i + 1; known < N; known++ {
-			a[i][N] = a[i][N] - a[i][known]*x[known]
+		if math.Abs(a[pivot][base]) < 1e-12 {
+			return nil, false
 		}
-		x[i] = a[i][N] / a[i][i]
-	}
-	return x
-}
-
-func (n *NsgaIIISelection) hasDuplicateIndividuals(elite []int) bool {
-	for i := 0; i < len(elite); i++ {
-		for j := 0; j < len(elite); j++ {
-			if j != i {
-				if n.hasSameValuesForObjectives(n.NsgaIISelection.MixedObjectives[elite[i]], n.NsgaIISelection.MixedObjectives[elite[j]]) {
-					return true
-				}
+		a[base], a[pivot] = a[pivot], a[base]
+		b[base], b[pivot] = b[pivot], b[base]
+		for row := base + 1; row < n; row++ {
+			ratio := a[row][base] / a[base][base]
+			for column := base; column < n; column++ {
+				a[row][column] -= a[base][column] * ratio
 			}
+			b[row] -= b[base] * ratio
 		}
 	}
-	return false
-}
-
-func (n *NsgaIIISelection) hasSameValuesForObjectives(a []float64, b []float64) bool {
-	for i := 0; i < len(a); i++ {
-		if a[i] == b[i] {
-			return false
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		x[i] = b[i]
+		for known := i + 1; known < n; known++ {
+			x[i] -= a[i][known] * x[known]
 		}
+		x[i] /= a[i][i]
 	}
-	return true
+	return x, true
 }
 
 func perpendicularDistance(normalizedObjectives []float64, referencePoint []float64) float64 {
@@ -320,52 +385,3 @@ func perpendicularDistance(normalizedObjectives []float64, referencePoint []floa
 	}
 	return math.Sqrt(d)
 }
-
-func (n *NsgaIIISelection) associate(normalizedIndividuals []NormalizedIndividual, nroObjectives int) {
-	for index, individual := range normalizedIndividuals {
-		var rpDist = make([]Association, len(n.referencePointArray))
-		for i := 0; i < len(n.referencePointArray); i++ {
-			var association Association
-			association.point = &n.referencePointArray[i]
-			association.dist = perpendicularDistance(individual.objectives, n.referencePointArray[i].position)
-			rpDist[i] = association
-		}
-		sort.SliceStable(rpDist, func(i, j int) bool {
-			return rpDist[i].dist < rpDist[j].dist
-		})
-		var bestDist = rpDist[0].dist
-		var bestRp = rpDist[0].point
-		normalizedIndividuals[index].referencePoint = *bestRp
-		normalizedIndividuals[index].distance = bestDist
-		bestRp.associationCount++
-		bestRp.associations = append(bestRp.associations, individual)
-	}
-}
-
-func getMinimalRefPointDistIndividual(individuals []NormalizedIndividual) int {
-	var minDist = math.Inf(1)
-	var index int
-	for i := 0; i < len(individuals); i++ {
-		if individuals[i].distance < minDist {
-			index = i
-			minDist = individuals[i].distance
-		}
-	}
-	return index
-}
-
-func getMinimalAssociationCountReferences(referencePoints []ReferencePoint) []int {
-	var minCount = math.Inf(1)
-	var res = make([]int, 0)
-	for i := 0; i < len(referencePoints); i++ {
-		if float64(referencePoints[i].associationCount) < minCount {
-			minCount = float64(referencePoints[i].associationCount)
-		}
-	}
-	for i := 0; i < len(referencePoints); i++ {
-		if referencePoints[i].associationCount == int(minCount) {
-			res = append(res, i)
-		}
-	}
-	return res
-}
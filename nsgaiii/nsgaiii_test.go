@@ -0,0 +1,138 @@
+package nsgaiii
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/binary"
+	"github.com/project-draco/moea/internal/fixture"
+)
+
+func TestSameSeedSameResult(t *testing.T) {
+	fixture.SameSeedSameResult(t, func(seed uint32) *moea.Config {
+		config := simplexConfig(&NsgaIIISelection{ReferencePointsDivision: 4}, seed)
+		config.MaxGenerations = 10
+		return config
+	}, 9)
+}
+
+func simplexConfig(selection *NsgaIIISelection, seed uint32) *moea.Config {
+	rng := moea.NewXorshiftWithSeed(seed)
+	return &moea.Config{
+		Algorithm:          moea.NewSimpleAlgorithm(selection, &moea.FastMutation{}),
+		Population:         binary.NewRandomBinaryPopulation(20, []int{8, 8}, nil, rng),
+		NumberOfValues:     2,
+		NumberOfObjectives: 3,
+		ObjectiveFunc: func(i moea.Individual) []float64 {
+			x := float64(i.Value(0).(binary.BinaryString).Int().Int64()) / 255
+			y := float64(i.Value(1).(binary.BinaryString).Int().Int64()) / 255
+			return []float64{x * y, x * (1 - y), 1 - x}
+		},
+		MaxGenerations:        50,
+		CrossoverProbability:  0.9,
+		MutationProbability:   1.0 / 16,
+		RandomNumberGenerator: rng,
+	}
+}
+
+func TestReferencePoints(t *testing.T) {
+	n := &NsgaIIISelection{ReferencePointsDivision: 3, InsideDivision: 2}
+	points := n.referencePoints(8, 100)
+	if len(points) != 120+36 {
+		t.Fatalf("Expected 156 points, got %v", len(points))
+	}
+	for _, p := range points[120:] {
+		sum := 0.0
+		for _, x := range p {
+			if x < 1.0/16 {
+				t.Errorf("Inner point %v is on the boundary", p)
+			}
+			sum += x
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("Inner point %v is off the simplex", p)
+		}
+	}
+	if points := (&NsgaIIISelection{}).referencePoints(3, 20); len(points) != 21 {
+		t.Errorf("Expected 21 default points for 20 individuals, got %v", len(points))
+	}
+}
+
+func TestDegenerateHyperplane(t *testing.T) {
+	objectives := [][]float64{{0, 1, 1}, {1, 0, 1}, {1, 0, 1}}
+	members := []int{0, 1, 2}
+	intercepts := findIntercepts(objectives, members, idealPoint(objectives, members))
+	if !reflect.DeepEqual(intercepts, []float64{1, 1, 1}) {
+		t.Errorf("Expected the nadir fallback, got %v", intercepts)
+	}
+	objectives = [][]float64{{2, 0, 0}, {0, 4, 0}, {0, 0, 8}, {1, 1, 1}}
+	members = []int{0, 1, 2, 3}
+	intercepts = findIntercepts(objectives, members, idealPoint(objectives, members))
+	if !reflect.DeepEqual(intercepts, []float64{2, 4, 8}) {
+		t.Errorf("Expected the intercepts of the extreme points, got %v", intercepts)
+	}
+	if _, ok := gaussianElimination([][]float64{{1, 2}, {2, 4}}, []float64{1, 1}); ok {
+		t.Error("Expected a singular system")
+	}
+}
+
+func TestNicheCounts(t *testing.T) {
+	selection := &NsgaIIISelection{ReferencePointsDivision: 4}
+	config := simplexConfig(selection, 3)
+	config.OnGenerationFunc = func(_ int, result *moea.Result) {
+		sum := 0
+		for _, c := range result.NicheCounts {
+			sum += c
+		}
+		if len(result.NicheCounts) != 15 || sum != 20 {
+			t.Fatalf("Expected 20 individuals in 15 niches, got %v", result.NicheCounts)
+		}
+	}
+	result, err := moea.Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	occupied := 0
+	for _, c := range result.NicheCounts {
+		if c > 0 {
+			occupied++
+		}
+	}
+	if occupied < 12 {
+		t.Errorf("Expected the population spread over the simplex front, got %v", result.NicheCounts)
+	}
+}
+
+func TestUserReferencePoints(t *testing.T) {
+	selection := &NsgaIIISelection{ReferencePoints: [][]float64{{1, 0, 0}, {0, 0, 1}}}
+	result, err := moea.Run(simplexConfig(selection, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.NicheCounts) != 2 || len(selection.ReferenceSet()) != 2 {
+		t.Errorf("Expected two niches, got %v", result.NicheCounts)
+	}
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	selection := &NsgaIIISelection{ReferencePointsDivision: 4}
+	s := moea.NewSession(simplexConfig(selection, 5))
+	defer s.Close()
+	if _, err := s.Step(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdatePreferences(moea.Preferences{ReferencePoints: [][]float64{{1, 0}}}); err != errReferencePointDimension {
+		t.Errorf("Expected %v, got %v", errReferencePointDimension, err)
+	}
+	if len(selection.ReferenceSet()) != 15 {
+		t.Errorf("Expected the 15 structured points to be kept, got %v", selection.ReferenceSet())
+	}
+	if err := s.UpdatePreferences(moea.Preferences{ReferencePoints: [][]float64{{1, 0, 0}}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(selection.ReferenceSet()) != 1 {
+		t.Errorf("Expected a single reference point, got %v", selection.ReferenceSet())
+	}
+}
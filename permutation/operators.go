@@ -0,0 +1,87 @@
+package permutation
+
+import "github.com/project-draco/moea"
+
+// OrderCrossover (OX1) copies a random segment of one parent into each
+// child and fills the remaining positions, starting after the segment,
+// with the missing elements in the order they appear in the other parent.
+// It returns the start of the segment.
+type OrderCrossover struct{}
+
+// TwoOpt is a first-improvement 2-opt local search: it reverses the
+// segments between every pair of positions, keeping each reversal that
+// yields dominating objectives, until a pass brings no improvement or
+// MaxEvaluations evaluations are spent (n(n-1)/2, one pass, by default).
+type TwoOpt struct{ MaxEvaluations int }
+
+func (c *OrderCrossover) Crossover(config *moea.Config, parent1, parent2, child1, child2 moea.Individual,
+	probability float64) int {
+	child1.Copy(parent1, 0, parent1.Len())
+	child2.Copy(parent2, 0, parent2.Len())
+	n := parent1.Len()
+	if !config.RandomNumberGenerator.Flip(probability) || n < 2 {
+		return -1
+	}
+	start := config.RandomNumberGenerator.Intn(n)
+	end := start + 1 + config.RandomNumberGenerator.Intn(n-start)
+	order(Permutation(parent1), Permutation(parent2), Permutation(child1), start, end)
+	order(Permutation(parent2), Permutation(parent1), Permutation(child2), start, end)
+	return start
+}
+
+// order fills child with the segment [start, end) of segment and the rest
+// of other in order.
+func order(segment, other, child []int, start, end int) {
+	n := len(segment)
+	inSegment := make([]bool, n)
+	for i := start; i < end; i++ {
+		child[i] = segment[i]
+		inSegment[segment[i]] = true
+	}
+	j := end % n
+	for k := 0; k < n; k++ {
+		e := other[(end+k)%n]
+		if !inSegment[e] {
+			child[j] = e
+			j = (j + 1) % n
+		}
+	}
+}
+
+func (t *TwoOpt) Search(config *moea.Config, ind moea.Individual, objective []float64,
+	evaluate moea.ObjectiveFunc) []float64 {
+	p := Permutation(ind)
+	n := len(p)
+	max := t.MaxEvaluations
+	if max <= 0 {
+		max = n * (n - 1) / 2
+	}
+	for evaluations := 0; evaluations < max; {
+		improved := false
+		for i := 0; i < n-1 && evaluations < max; i++ {
+			for j := i + 1; j < n && evaluations < max; j++ {
+				reverse(p[i : j+1])
+				f := evaluate(ind)
+				evaluations++
+				if f != nil && moea.Dominates(f, objective) {
+					objective, improved = f, true
+					continue
+				}
+				reverse(p[i : j+1])
+				if f == nil {
+					return objective
+				}
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return objective
+}
+
+func reverse(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
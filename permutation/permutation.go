@@ -0,0 +1,73 @@
+// Package permutation encodes individuals as permutations of 0..n-1, for
+// ordering problems such as the travelling salesman. Mutate swaps each
+// given position with a random one; since position-wise copies do not
+// preserve permutations, recombine them with OrderCrossover.
+package permutation
+
+import (
+	"fmt"
+
+	"github.com/project-draco/moea"
+)
+
+type population []*individual
+
+type individual struct {
+	order []int
+	rng   moea.RNG
+}
+
+// NewRandomPermutationPopulation returns size random permutations of n
+// elements, rounded up to an even number of individuals.
+func NewRandomPermutationPopulation(size, n int, rng moea.RNG) moea.Population {
+	if n < 1 {
+		panic(fmt.Sprintf("Invalid permutation length %d", n))
+	}
+	if size%2 == 1 {
+		size++
+	}
+	result := make(population, size)
+	for i := range result {
+		result[i] = &individual{rng.Perm(n), rng}
+	}
+	return result
+}
+
+// Permutation returns the order of a permutation individual. The slice is
+// owned by the individual.
+func Permutation(ind moea.Individual) []int {
+	return ind.(*individual).order
+}
+
+func (p population) Len() int { return len(p) }
+
+func (p population) Individual(i int) moea.Individual { return p[i] }
+
+func (p population) Clone() moea.Population {
+	result := make(population, len(p))
+	for i, individual := range p {
+		result[i] = individual.clone()
+	}
+	return result
+}
+
+func (ind *individual) Len() int { return len(ind.order) }
+
+func (ind *individual) Value(i int) interface{} { return ind.order[i] }
+
+func (ind *individual) Copy(other moea.Individual, start, end int) {
+	copy(ind.order[start:end], other.(*individual).order[start:end])
+}
+
+func (ind *individual) Mutate(mutations []int) {
+	for _, m := range mutations {
+		j := ind.rng.Intn(len(ind.order))
+		ind.order[m], ind.order[j] = ind.order[j], ind.order[m]
+	}
+}
+
+func (ind *individual) Clone() moea.Individual { return ind.clone() }
+
+func (ind *individual) clone() *individual {
+	return &individual{append([]int(nil), ind.order...), ind.rng}
+}
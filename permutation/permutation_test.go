@@ -0,0 +1,114 @@
+package permutation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+func checkPermutation(t *testing.T, p []int) {
+	seen := make([]bool, len(p))
+	for _, e := range p {
+		if e < 0 || e >= len(p) || seen[e] {
+			t.Fatalf("%v is not a permutation", p)
+		}
+		seen[e] = true
+	}
+}
+
+func TestOrderCrossover(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(1)
+	population := NewRandomPermutationPopulation(4, 10, rng)
+	config := &moea.Config{Population: population, RandomNumberGenerator: rng}
+	p1, p2, c1, c2 := population.Individual(0), population.Individual(1), population.Individual(2), population.Individual(3)
+	for n := 0; n < 1000; n++ {
+		start := (&OrderCrossover{}).Crossover(config, p1, p2, c1, c2, 1)
+		checkPermutation(t, Permutation(c1))
+		checkPermutation(t, Permutation(c2))
+		if Permutation(c1)[start] != Permutation(p1)[start] || Permutation(c2)[start] != Permutation(p2)[start] {
+			t.Fatalf("segment at %d not kept", start)
+		}
+		c1.Mutate([]int{0, 5})
+		checkPermutation(t, Permutation(c1))
+		p1, c1 = c1, p1
+		p2, c2 = c2, p2
+	}
+	child := make([]int, 8)
+	order([]int{0, 1, 2, 3, 4, 5, 6, 7}, []int{2, 4, 6, 0, 7, 5, 3, 1}, child, 3, 6)
+	if want := []int{6, 0, 7, 3, 4, 5, 1, 2}; !equal(child, want) {
+		t.Errorf("expected %v but was %v", want, child)
+	}
+}
+
+func equal(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
+
+// circle returns the length of the tour through n cities evenly spaced on a
+// unit circle.
+func circle(n int) moea.ObjectiveFunc {
+	return func(individual moea.Individual) []float64 {
+		p := Permutation(individual)
+		result := 0.0
+		for i := range p {
+			a := 2 * math.Pi * float64(p[i]) / float64(n)
+			b := 2 * math.Pi * float64(p[(i+1)%n]) / float64(n)
+			result += math.Hypot(math.Cos(a)-math.Cos(b), math.Sin(a)-math.Sin(b))
+		}
+		return []float64{result}
+	}
+}
+
+func TestTwoOpt(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(2)
+	n := 20
+	population := NewRandomPermutationPopulation(2, n, rng)
+	config := &moea.Config{Population: population, RandomNumberGenerator: rng}
+	tour := circle(n)
+	optimum := 2 * float64(n) * math.Sin(math.Pi/float64(n))
+	individual := population.Individual(0)
+	f := (&TwoOpt{MaxEvaluations: 100000}).Search(config, individual, tour(individual), tour)
+	checkPermutation(t, Permutation(individual))
+	if math.Abs(f[0]-optimum) > 1e-9 || math.Abs(tour(individual)[0]-f[0]) > 1e-9 {
+		t.Errorf("expected the convex tour %v but was %v %v", optimum, f, Permutation(individual))
+	}
+}
+
+func TestMemeticTour(t *testing.T) {
+	n := 30
+	optimum := 2 * float64(n) * math.Sin(math.Pi/float64(n))
+	run := func(search moea.LocalSearch) float64 {
+		rng := moea.NewXorshiftWithSeed(3)
+		result, err := moea.Run(&moea.Config{
+			Algorithm: moea.NewSimpleAlgorithmWithOperators(moea.SimpleAlgorithmOperators{
+				Selection:              &moea.TournamentSelection{TournamentSize: 2},
+				Crossover:              &OrderCrossover{},
+				Replacement:            &moea.GenerationalReplacement{Elitism: 1},
+				LocalSearch:            search,
+				LocalSearchProbability: 0.1,
+			}),
+			Population:            NewRandomPermutationPopulation(40, n, rng),
+			NumberOfValues:        n,
+			ObjectiveFunc:         circle(n),
+			NumberOfObjectives:    1,
+			MaxEvaluations:        20000,
+			CrossoverProbability:  0.9,
+			MutationProbability:   1 / float64(n),
+			RandomNumberGenerator: rng,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.BestObjective[0]
+	}
+	ga, memetic := run(nil), run(&TwoOpt{})
+	if memetic >= ga || memetic > optimum*1.05 {
+		t.Errorf("expected 2-opt to improve on %v towards %v but was %v", ga, optimum, memetic)
+	}
+}
@@ -0,0 +1,37 @@
+package plot
+
+import "github.com/project-draco/moea"
+
+// History records per-generation statistics. Its Record method can be used
+// directly as a moea.OnGenerationFunc.
+type History struct {
+	Generations []int
+	Best        [][]float64
+	Average     [][]float64
+	Worst       [][]float64
+	next        moea.OnGenerationFunc
+}
+
+// NewHistory returns a History whose Record also calls next, if not nil.
+func NewHistory(next moea.OnGenerationFunc) *History {
+	return &History{next: next}
+}
+
+func (h *History) Record(generation int, result *moea.Result) {
+	h.Generations = append(h.Generations, generation)
+	h.Best = append(h.Best, clone(result.BestObjective))
+	h.Average = append(h.Average, clone(result.AverageObjective))
+	h.Worst = append(h.Worst, clone(result.WorstObjective))
+	if h.next != nil {
+		h.next(generation, result)
+	}
+}
+
+func clone(s []float64) []float64 {
+	if s == nil {
+		return nil
+	}
+	result := make([]float64, len(s))
+	copy(result, s)
+	return result
+}
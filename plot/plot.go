@@ -0,0 +1,164 @@
+package plot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/project-draco/moea"
+)
+
+// Scatter writes a 2-D scatter plot of the objectives x and y of front. When
+// reference is not nil its points are drawn as a line behind the front.
+func Scatter(w io.Writer, front []moea.IndividualResult, reference [][]float64, x, y int, options Options) error {
+	if len(front) == 0 && len(reference) == 0 {
+		return errors.New("nothing to plot")
+	}
+	points := objectives(front)
+	minX, maxX := bounds(x, points, reference)
+	minY, maxY := bounds(y, points, reference)
+	if options.XLabel == "" {
+		options.XLabel = fmt.Sprintf("f%d", x+1)
+	}
+	if options.YLabel == "" {
+		options.YLabel = fmt.Sprintf("f%d", y+1)
+	}
+	c := newCanvas(options)
+	ax, ay := c.xAxis(minX, maxX), c.yAxis(minY, maxY)
+	c.frame(ax, ay, options)
+	if len(reference) > 0 {
+		sorted := make([][2]float64, 0, len(reference))
+		for _, r := range sortedBy(reference, x) {
+			sorted = append(sorted, [2]float64{ax.scale(r[x]), ay.scale(r[y])})
+		}
+		c.polyline(sorted, palette[7], 1.5, 0.8)
+	}
+	for _, p := range points {
+		c.circle(ax.scale(p[x]), ay.scale(p[y]), 3, palette[0])
+	}
+	if len(reference) > 0 {
+		c.legend([]string{"front", "reference"}, []string{palette[0], palette[7]})
+	}
+	return c.writeTo(w)
+}
+
+// ParallelCoordinates writes one polyline per member of front, with one
+// vertical axis per objective. Every axis is normalised to its own range.
+func ParallelCoordinates(w io.Writer, front []moea.IndividualResult, options Options) error {
+	if len(front) == 0 {
+		return errors.New("nothing to plot")
+	}
+	points := objectives(front)
+	m := len(points[0])
+	if m < 2 {
+		return errors.New("parallel coordinates need at least two objectives")
+	}
+	if options.YLabel == "" {
+		options.YLabel = "normalised objective"
+	}
+	c := newCanvas(options)
+	ay := axis{0, 1, c.bottom(), c.top()}
+	min := make([]float64, m)
+	max := make([]float64, m)
+	for j := 0; j < m; j++ {
+		min[j], max[j] = bounds(j, points, nil)
+	}
+	xs := make([]float64, m)
+	for j := 0; j < m; j++ {
+		xs[j] = c.left() + float64(j)*(c.right()-c.left())/float64(m-1)
+		c.line(xs[j], c.top(), xs[j], c.bottom(), "black", 1)
+		c.text(xs[j], c.bottom()+18, "middle", 11, fmt.Sprintf("f%d", j+1))
+		c.text(xs[j], c.top()-6, "middle", 10, formatTick(max[j]))
+		c.text(xs[j], c.bottom()+32, "middle", 10, formatTick(min[j]))
+	}
+	line := make([][2]float64, m)
+	for _, p := range points {
+		for j := 0; j < m; j++ {
+			v := 0.5
+			if max[j] > min[j] {
+				v = (p[j] - min[j]) / (max[j] - min[j])
+			}
+			line[j] = [2]float64{xs[j], ay.scale(v)}
+		}
+		c.polyline(line, palette[0], 1, 0.4)
+	}
+	if options.XLabel != "" {
+		c.text((c.left()+c.right())/2, c.height-6, "middle", 12, options.XLabel)
+	}
+	return c.writeTo(w)
+}
+
+// Convergence writes the best, average and worst value of the given objective
+// recorded in history, one curve each, against the generation number.
+func Convergence(w io.Writer, history *History, objective int, options Options) error {
+	if history == nil || len(history.Generations) == 0 {
+		return errors.New("empty history")
+	}
+	series := [][][]float64{history.Best, history.Average, history.Worst}
+	names := []string{"best", "average", "worst"}
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, o := range s {
+			if objective < len(o) && !math.IsInf(o[objective], 0) {
+				minY = math.Min(minY, o[objective])
+				maxY = math.Max(maxY, o[objective])
+			}
+		}
+	}
+	if options.XLabel == "" {
+		options.XLabel = "generation"
+	}
+	if options.YLabel == "" {
+		options.YLabel = fmt.Sprintf("f%d", objective+1)
+	}
+	c := newCanvas(options)
+	ax := c.xAxis(float64(history.Generations[0]), float64(history.Generations[len(history.Generations)-1]))
+	ay := c.yAxis(minY, maxY)
+	c.frame(ax, ay, options)
+	for i, s := range series {
+		line := make([][2]float64, 0, len(s))
+		for g, o := range s {
+			if objective < len(o) && !math.IsInf(o[objective], 0) {
+				line = append(line, [2]float64{ax.scale(float64(history.Generations[g])), ay.scale(o[objective])})
+			}
+		}
+		c.polyline(line, palette[i], 2, 1)
+	}
+	c.legend(names, palette[:len(names)])
+	return c.writeTo(w)
+}
+
+func objectives(front []moea.IndividualResult) [][]float64 {
+	result := make([][]float64, 0, len(front))
+	for _, ind := range front {
+		if ind.Objective != nil {
+			result = append(result, ind.Objective)
+		}
+	}
+	return result
+}
+
+func bounds(j int, sets ...[][]float64) (float64, float64) {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, set := range sets {
+		for _, p := range set {
+			if j < len(p) {
+				min = math.Min(min, p[j])
+				max = math.Max(max, p[j])
+			}
+		}
+	}
+	return min, max
+}
+
+func sortedBy(points [][]float64, j int) [][]float64 {
+	result := make([][]float64, len(points))
+	copy(result, points)
+	for i := 1; i < len(result); i++ {
+		for k := i; k > 0 && result[k][j] < result[k-1][j]; k-- {
+			result[k], result[k-1] = result[k-1], result[k]
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package plot
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+func wellFormed(t *testing.T, s string) {
+	d := xml.NewDecoder(strings.NewReader(s))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatal("Malformed output:", err)
+		}
+	}
+}
+
+var front = []moea.IndividualResult{
+	{Objective: []float64{0, 1, 2}},
+	{Objective: []float64{0.5, 0.5, 1}},
+	{Objective: []float64{1, 0, 0}},
+}
+
+func TestScatter(t *testing.T) {
+	var b bytes.Buffer
+	reference := [][]float64{{1, 0}, {0, 1}, {0.5, 0.5}}
+	if err := Scatter(&b, front, reference, 0, 1, Options{Title: "a < b"}); err != nil {
+		t.Fatal(err)
+	}
+	wellFormed(t, b.String())
+	if n := strings.Count(b.String(), "<circle"); n != len(front) {
+		t.Error("Expected", len(front), "circles but was", n)
+	}
+	if !strings.Contains(b.String(), "a &lt; b") {
+		t.Error("Title must be escaped")
+	}
+}
+
+func TestParallelCoordinates(t *testing.T) {
+	var b bytes.Buffer
+	if err := ParallelCoordinates(&b, front, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	wellFormed(t, b.String())
+	if n := strings.Count(b.String(), "<polyline"); n != len(front) {
+		t.Error("Expected", len(front), "polylines but was", n)
+	}
+}
+
+func TestReport(t *testing.T) {
+	h := NewHistory(nil)
+	for i := 0; i < 3; i++ {
+		h.Record(i, &moea.Result{
+			BestObjective:    []float64{float64(3 - i), 1, 1},
+			AverageObjective: []float64{float64(4 - i), 2, 2},
+			WorstObjective:   []float64{float64(5 - i), 3, 3},
+		})
+	}
+	var b bytes.Buffer
+	r := &Report{Title: "test", Front: front, History: h}
+	if err := r.WriteHTML(&b); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(b.String(), "<svg"); n != 3+1+3 {
+		t.Error("Expected 7 plots but was", n)
+	}
+}
+
+func TestTicks(t *testing.T) {
+	for _, f := range []struct {
+		min, max float64
+		out      []float64
+	}{
+		{0, 1, []float64{0, 0.2, 0.4, 0.6, 0.8, 1}},
+		{-3, 7, []float64{-2, 0, 2, 4, 6}},
+	} {
+		result := ticks(f.min, f.max, 6)
+		if len(result) != len(f.out) {
+			t.Fatal("Expected", f.out, "but was", result)
+		}
+		for i := range result {
+			if result[i]-f.out[i] > 1e-9 || f.out[i]-result[i] > 1e-9 {
+				t.Error("Expected", f.out, "but was", result)
+			}
+		}
+	}
+}
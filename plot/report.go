@@ -0,0 +1,80 @@
+package plot
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/project-draco/moea"
+)
+
+// Report is a standalone HTML page with every plot that applies to its
+// contents: a scatter plot per pair of objectives (up to three objectives),
+// parallel coordinates (three or more objectives) and one convergence curve
+// per objective when History is set.
+type Report struct {
+	Title     string
+	Front     []moea.IndividualResult
+	Reference [][]float64
+	History   *History
+}
+
+func (r *Report) WriteHTML(w io.Writer) error {
+	var b strings.Builder
+	title := r.Title
+	if title == "" {
+		title = "moea report"
+	}
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(title))
+	b.WriteString("<style>body{font-family:sans-serif;margin:2em}figure{display:inline-block;margin:1em}</style>\n")
+	fmt.Fprintf(&b, "</head>\n<body>\n<h1>%s</h1>\n", html.EscapeString(title))
+	m := 0
+	if len(r.Front) > 0 {
+		m = len(r.Front[0].Objective)
+	}
+	if m >= 2 {
+		fmt.Fprintf(&b, "<h2>Front (%d solutions)</h2>\n", len(r.Front))
+		if m <= 3 {
+			for i := 0; i < m; i++ {
+				for j := i + 1; j < m; j++ {
+					if err := figure(&b, func(w io.Writer) error {
+						return Scatter(w, r.Front, r.Reference, i, j, Options{})
+					}); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if m >= 3 {
+			if err := figure(&b, func(w io.Writer) error {
+				return ParallelCoordinates(w, r.Front, Options{Width: 800})
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	if r.History != nil && len(r.History.Generations) > 0 {
+		b.WriteString("<h2>Convergence</h2>\n")
+		n := len(r.History.Best[0])
+		for i := 0; i < n; i++ {
+			if err := figure(&b, func(w io.Writer) error {
+				return Convergence(w, r.History, i, Options{})
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	b.WriteString("</body>\n</html>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func figure(b *strings.Builder, f func(io.Writer) error) error {
+	b.WriteString("<figure>\n")
+	if err := f(b); err != nil {
+		return err
+	}
+	b.WriteString("</figure>\n")
+	return nil
+}
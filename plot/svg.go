@@ -0,0 +1,211 @@
+package plot
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+)
+
+type Options struct {
+	Title  string
+	XLabel string
+	YLabel string
+	Width  int
+	Height int
+}
+
+var palette = []string{
+	"#1f77b4", "#d62728", "#2ca02c", "#ff7f0e", "#9467bd",
+	"#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf",
+}
+
+const (
+	marginLeft   = 70.0
+	marginRight  = 20.0
+	marginTop    = 40.0
+	marginBottom = 50.0
+)
+
+type canvas struct {
+	b      strings.Builder
+	width  float64
+	height float64
+}
+
+type axis struct {
+	min, max float64
+	from, to float64
+}
+
+func (o Options) size() (float64, float64) {
+	w, h := o.Width, o.Height
+	if w <= 0 {
+		w = 640
+	}
+	if h <= 0 {
+		h = 480
+	}
+	return float64(w), float64(h)
+}
+
+func newCanvas(options Options) *canvas {
+	c := &canvas{}
+	c.width, c.height = options.size()
+	fmt.Fprintf(&c.b, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g" font-family="sans-serif" font-size="12">`+"\n",
+		c.width, c.height, c.width, c.height)
+	fmt.Fprintf(&c.b, `<rect width="%g" height="%g" fill="white"/>`+"\n", c.width, c.height)
+	if options.Title != "" {
+		c.text(c.width/2, marginTop/2+5, "middle", 14, options.Title)
+	}
+	return c
+}
+
+func (c *canvas) left() float64   { return marginLeft }
+func (c *canvas) right() float64  { return c.width - marginRight }
+func (c *canvas) top() float64    { return marginTop }
+func (c *canvas) bottom() float64 { return c.height - marginBottom }
+
+func (c *canvas) xAxis(min, max float64) axis {
+	min, max = widen(min, max)
+	return axis{min, max, c.left(), c.right()}
+}
+
+func (c *canvas) yAxis(min, max float64) axis {
+	min, max = widen(min, max)
+	return axis{min, max, c.bottom(), c.top()}
+}
+
+func (a axis) scale(v float64) float64 {
+	return a.from + (v-a.min)/(a.max-a.min)*(a.to-a.from)
+}
+
+func (c *canvas) frame(x, y axis, options Options) {
+	fmt.Fprintf(&c.b, `<rect x="%g" y="%g" width="%g" height="%g" fill="none" stroke="black"/>`+"\n",
+		c.left(), c.top(), c.right()-c.left(), c.bottom()-c.top())
+	for _, t := range ticks(x.min, x.max, 6) {
+		px := x.scale(t)
+		c.line(px, c.bottom(), px, c.bottom()+5, "black", 1)
+		c.text(px, c.bottom()+18, "middle", 11, formatTick(t))
+	}
+	for _, t := range ticks(y.min, y.max, 6) {
+		py := y.scale(t)
+		c.line(c.left()-5, py, c.left(), py, "black", 1)
+		c.text(c.left()-8, py+4, "end", 11, formatTick(t))
+	}
+	if options.XLabel != "" {
+		c.text((c.left()+c.right())/2, c.height-12, "middle", 12, options.XLabel)
+	}
+	if options.YLabel != "" {
+		fmt.Fprintf(&c.b, `<text x="%g" y="%g" text-anchor="middle" transform="rotate(-90 %g %g)">%s</text>`+"\n",
+			16.0, (c.top()+c.bottom())/2, 16.0, (c.top()+c.bottom())/2, html.EscapeString(options.YLabel))
+	}
+}
+
+func (c *canvas) line(x1, y1, x2, y2 float64, color string, width float64) {
+	fmt.Fprintf(&c.b, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="%s" stroke-width="%g"/>`+"\n",
+		x1, y1, x2, y2, color, width)
+}
+
+func (c *canvas) circle(x, y, r float64, color string) {
+	fmt.Fprintf(&c.b, `<circle cx="%.2f" cy="%.2f" r="%g" fill="%s" fill-opacity="0.8"/>`+"\n", x, y, r, color)
+}
+
+func (c *canvas) polyline(points [][2]float64, color string, width, opacity float64) {
+	if len(points) == 0 {
+		return
+	}
+	c.b.WriteString(`<polyline fill="none" points="`)
+	for i, p := range points {
+		if i > 0 {
+			c.b.WriteByte(' ')
+		}
+		fmt.Fprintf(&c.b, "%.2f,%.2f", p[0], p[1])
+	}
+	fmt.Fprintf(&c.b, `" stroke="%s" stroke-width="%g" stroke-opacity="%g"/>`+"\n", color, width, opacity)
+}
+
+func (c *canvas) text(x, y float64, anchor string, size int, s string) {
+	fmt.Fprintf(&c.b, `<text x="%.2f" y="%.2f" text-anchor="%s" font-size="%d">%s</text>`+"\n",
+		x, y, anchor, size, html.EscapeString(s))
+}
+
+func (c *canvas) legend(names []string, colors []string) {
+	x, y := c.right()-10, c.top()+15
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+		c.line(x-150, y-4, x-130, y-4, colors[i], 3)
+		fmt.Fprintf(&c.b, `<text x="%.2f" y="%.2f" font-size="11">%s</text>`+"\n", x-125, y, html.EscapeString(name))
+		y += 15
+	}
+}
+
+func (c *canvas) writeTo(w io.Writer) error {
+	c.b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, c.b.String())
+	return err
+}
+
+func widen(min, max float64) (float64, float64) {
+	if math.IsInf(min, 0) || math.IsInf(max, 0) || math.IsNaN(min) || math.IsNaN(max) {
+		return 0, 1
+	}
+	if min == max {
+		d := math.Abs(min) * 0.05
+		if d == 0 {
+			d = 1
+		}
+		return min - d, max + d
+	}
+	pad := (max - min) * 0.05
+	return min - pad, max + pad
+}
+
+// ticks returns "nice" tick positions (1, 2 or 5 times a power of ten) inside [min, max].
+func ticks(min, max float64, n int) []float64 {
+	span := niceNumber(max-min, false)
+	step := niceNumber(span/float64(n-1), true)
+	var result []float64
+	start := math.Ceil(min / step)
+	for k := 0.0; (start+k)*step <= max+step*1e-9; k++ {
+		result = append(result, (start+k)*step)
+	}
+	return result
+}
+
+func niceNumber(x float64, round bool) float64 {
+	exp := math.Floor(math.Log10(x))
+	f := x / math.Pow(10, exp)
+	var nf float64
+	if round {
+		switch {
+		case f < 1.5:
+			nf = 1
+		case f < 3:
+			nf = 2
+		case f < 7:
+			nf = 5
+		default:
+			nf = 10
+		}
+	} else {
+		switch {
+		case f <= 1:
+			nf = 1
+		case f <= 2:
+			nf = 2
+		case f <= 5:
+			nf = 5
+		default:
+			nf = 10
+		}
+	}
+	return nf * math.Pow(10, exp)
+}
+
+func formatTick(v float64) string {
+	return fmt.Sprintf("%.4g", v)
+}
@@ -1,9 +1,14 @@
 package moea
 
+import "math"
+
 type RNG interface {
 	Flip(probability float64) bool
 	FairFlip() bool
 	Float64() float64
+	Intn(n int) int
+	NormFloat64() float64
+	Perm(n int) []int
 }
 
 const (
@@ -25,12 +30,34 @@ func NewXorshiftWithSeed(seed uint32) RNG {
 	}
 }
 
+// NewXorshiftStreams returns n generators for parallel runs. Their states
+// are consecutive outputs of a single SplitMix64 generator seeded with seed,
+// so the streams are reproducible, do not depend on how runs are scheduled
+// and share no state word.
+func NewXorshiftStreams(seed uint64, n int) []RNG {
+	result := make([]RNG, n)
+	s := seed
+	for i := range result {
+		a, b := splitMix64(&s), splitMix64(&s)
+		x := &Xorshift{x: uint32(a), y: uint32(a >> 32), z: uint32(b), w: uint32(b >> 32)}
+		if x.x|x.y|x.z|x.w == 0 {
+			x.w = 88675123
+		}
+		result[i] = x
+	}
+	return result
+}
+
 type Xorshift struct {
 	x, y, z, w, t uint32
+	cache         normalCache
 }
 
+// Flip compares the next value with probability scaled to 2^32 in floating
+// point, so that Flip(1) always succeeds and probabilities above 1 do not
+// overflow. It draws a single value whatever the probability.
 func (s *Xorshift) Flip(probability float64) bool {
-	return s.xorshift() < uint32(probability*MaxUint32AsFloat)
+	return float64(s.xorshift()) < probability*(MaxUint32AsFloat+1)
 }
 
 func (s *Xorshift) FairFlip() bool {
@@ -41,6 +68,53 @@ func (s *Xorshift) Float64() float64 {
 	return float64(s.xorshift()) / MaxUint32AsFloat
 }
 
+// Intn returns a uniformly distributed int in [0, n) using rejection
+// sampling, so that it has no modulo bias. It panics if n <= 0.
+func (s *Xorshift) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	if uint64(n) <= uint64(MaxUint32) {
+		bound := uint32(n)
+		threshold := -bound % bound
+		for {
+			if r := s.xorshift(); r >= threshold {
+				return int(r % bound)
+			}
+		}
+	}
+	bound := uint64(n)
+	threshold := -bound % bound
+	for {
+		if r := uint64(s.xorshift())<<32 | uint64(s.xorshift()); r >= threshold {
+			return int(r % bound)
+		}
+	}
+}
+
+// NormFloat64 returns a standard normally distributed float64 using
+// Marsaglia's polar method.
+func (s *Xorshift) NormFloat64() float64 {
+	return polarNormal(s, &s.cache.spare, &s.cache.hasSpare)
+}
+
+func (s *Xorshift) Perm(n int) []int {
+	return perm(s, n)
+}
+
+func (s *Xorshift) MarshalBinary() ([]byte, error) {
+	return marshalWords("xs32", &s.cache, uint64(s.x)<<32|uint64(s.y), uint64(s.z)<<32|uint64(s.w)), nil
+}
+
+func (s *Xorshift) UnmarshalBinary(data []byte) error {
+	var xy, zw uint64
+	if err := unmarshalWords(data, "xs32", &s.cache, &xy, &zw); err != nil {
+		return err
+	}
+	s.x, s.y, s.z, s.w = uint32(xy>>32), uint32(xy), uint32(zw>>32), uint32(zw)
+	return nil
+}
+
 func (s *Xorshift) xorshift() uint32 {
 	s.t = s.x ^ (s.x << 11)
 	s.x = s.y
@@ -49,3 +123,38 @@ func (s *Xorshift) xorshift() uint32 {
 	s.w = s.w ^ (s.w >> 19) ^ (s.t ^ (s.t >> 8))
 	return s.w
 }
+
+func polarNormal(rng RNG, spare *float64, hasSpare *bool) float64 {
+	if *hasSpare {
+		*hasSpare = false
+		return *spare
+	}
+	for {
+		u := 2*rng.Float64() - 1
+		v := 2*rng.Float64() - 1
+		q := u*u + v*v
+		if q > 0 && q < 1 {
+			f := math.Sqrt(-2 * math.Log(q) / q)
+			*spare, *hasSpare = v*f, true
+			return u * f
+		}
+	}
+}
+
+func perm(rng RNG, n int) []int {
+	result := make([]int, n)
+	for i := range result {
+		j := rng.Intn(i + 1)
+		result[i] = result[j]
+		result[j] = i
+	}
+	return result
+}
+
+func splitMix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
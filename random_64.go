@@ -0,0 +1,134 @@
+package moea
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// The generators in this file and in random_pcg.go and random_xoshiro.go
+// produce 64 bits per step. Float64 uses the 53 high bits, so it returns every
+// multiple of 2^-53 in [0, 1), and Flip compares against it, which keeps the
+// precision of small probabilities.
+
+type uint64Source interface {
+	Uint64() uint64
+}
+
+func float64From(s uint64Source) float64 {
+	return float64(s.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// intnFrom uses Lemire's multiply-and-reject method.
+func intnFrom(s uint64Source, n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	bound := uint64(n)
+	hi, lo := bits.Mul64(s.Uint64(), bound)
+	if lo < bound {
+		threshold := -bound % bound
+		for lo < threshold {
+			hi, lo = bits.Mul64(s.Uint64(), bound)
+		}
+	}
+	return int(hi)
+}
+
+type normalCache struct {
+	spare    float64
+	hasSpare bool
+}
+
+func (c *normalCache) marshal(b []byte) []byte {
+	if c.hasSpare {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	return appendUint64(b, math.Float64bits(c.spare))
+}
+
+func (c *normalCache) unmarshal(b []byte) {
+	c.hasSpare = b[0] == 1
+	c.spare = math.Float64frombits(binary.BigEndian.Uint64(b[1:]))
+}
+
+const normalCacheSize = 9
+
+var errInvalidState = errors.New("moea: invalid generator state")
+
+func marshalWords(tag string, cache *normalCache, words ...uint64) []byte {
+	b := make([]byte, 0, len(tag)+8*len(words)+normalCacheSize)
+	b = append(b, tag...)
+	for _, w := range words {
+		b = appendUint64(b, w)
+	}
+	return cache.marshal(b)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func unmarshalWords(data []byte, tag string, cache *normalCache, words ...*uint64) error {
+	if len(data) != len(tag)+8*len(words)+normalCacheSize || string(data[:len(tag)]) != tag {
+		return errInvalidState
+	}
+	data = data[len(tag):]
+	for i, w := range words {
+		*w = binary.BigEndian.Uint64(data[8*i:])
+	}
+	cache.unmarshal(data[8*len(words):])
+	return nil
+}
+
+// SplitMix64 is Steele, Lea and Flood's generator. It is mostly used to
+// expand a single seed into the state of the other generators.
+type SplitMix64 struct {
+	state uint64
+	cache normalCache
+}
+
+func NewSplitMix64(seed uint64) *SplitMix64 {
+	return &SplitMix64{state: seed}
+}
+
+func (s *SplitMix64) Uint64() uint64 {
+	return splitMix64(&s.state)
+}
+
+func (s *SplitMix64) Flip(probability float64) bool {
+	return s.Float64() < probability
+}
+
+func (s *SplitMix64) FairFlip() bool {
+	return s.Uint64()>>63 == 1
+}
+
+func (s *SplitMix64) Float64() float64 {
+	return float64From(s)
+}
+
+func (s *SplitMix64) Intn(n int) int {
+	return intnFrom(s, n)
+}
+
+func (s *SplitMix64) NormFloat64() float64 {
+	return polarNormal(s, &s.cache.spare, &s.cache.hasSpare)
+}
+
+func (s *SplitMix64) Perm(n int) []int {
+	return perm(s, n)
+}
+
+func (s *SplitMix64) MarshalBinary() ([]byte, error) {
+	return marshalWords("sm64", &s.cache, s.state), nil
+}
+
+func (s *SplitMix64) UnmarshalBinary(data []byte) error {
+	return unmarshalWords(data, "sm64", &s.cache, &s.state)
+}
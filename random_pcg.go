@@ -0,0 +1,115 @@
+package moea
+
+import "math/bits"
+
+// PCG64 is O'Neill's PCG XSL RR 128/64 generator: a 128-bit linear
+// congruential generator with a 64-bit permuted output. Different streams
+// select different increments; Advance jumps ahead in logarithmic time.
+type PCG64 struct {
+	state, increment uint128
+	cache            normalCache
+}
+
+type uint128 struct{ hi, lo uint64 }
+
+var pcgMultiplier = uint128{0x2360ed051fc65da4, 0x4385df649fccf645}
+
+func (a uint128) add(b uint128) uint128 {
+	lo, carry := bits.Add64(a.lo, b.lo, 0)
+	hi, _ := bits.Add64(a.hi, b.hi, carry)
+	return uint128{hi, lo}
+}
+
+func (a uint128) mul(b uint128) uint128 {
+	hi, lo := bits.Mul64(a.lo, b.lo)
+	hi += a.hi*b.lo + a.lo*b.hi
+	return uint128{hi, lo}
+}
+
+// NewPCG64 seeds the generator like pcg64_srandom_r in the reference C
+// implementation, so NewPCG64(42, 54) reproduces its published outputs.
+func NewPCG64(seed, stream uint64) *PCG64 {
+	p := &PCG64{increment: uint128{stream >> 63, stream<<1 | 1}}
+	p.step()
+	p.state = p.state.add(uint128{0, seed})
+	p.step()
+	return p
+}
+
+func (p *PCG64) step() {
+	p.state = p.state.mul(pcgMultiplier).add(p.increment)
+}
+
+func (p *PCG64) Uint64() uint64 {
+	p.step()
+	return bits.RotateLeft64(p.state.hi^p.state.lo, -int(p.state.hi>>58))
+}
+
+// Advance moves the generator delta steps forward (or backward, modulo
+// 2^128) using Brown's algorithm.
+func (p *PCG64) Advance(deltaHi, deltaLo uint64) {
+	accMult, accPlus := uint128{0, 1}, uint128{}
+	curMult, curPlus := pcgMultiplier, p.increment
+	for deltaHi != 0 || deltaLo != 0 {
+		if deltaLo&1 == 1 {
+			accMult = accMult.mul(curMult)
+			accPlus = accPlus.mul(curMult).add(curPlus)
+		}
+		curPlus = curMult.add(uint128{0, 1}).mul(curPlus)
+		curMult = curMult.mul(curMult)
+		deltaLo = deltaLo>>1 | deltaHi<<63
+		deltaHi >>= 1
+	}
+	p.state = accMult.mul(p.state).add(accPlus)
+	p.cache = normalCache{}
+}
+
+// Jump advances the generator by 2^64 steps.
+func (p *PCG64) Jump() {
+	p.Advance(1, 0)
+}
+
+// LongJump advances the generator by 2^96 steps.
+func (p *PCG64) LongJump() {
+	p.Advance(1<<32, 0)
+}
+
+func (p *PCG64) Flip(probability float64) bool {
+	return p.Float64() < probability
+}
+
+func (p *PCG64) FairFlip() bool {
+	return p.Uint64()>>63 == 1
+}
+
+func (p *PCG64) Float64() float64 {
+	return float64From(p)
+}
+
+func (p *PCG64) Intn(n int) int {
+	return intnFrom(p, n)
+}
+
+func (p *PCG64) NormFloat64() float64 {
+	return polarNormal(p, &p.cache.spare, &p.cache.hasSpare)
+}
+
+func (p *PCG64) Perm(n int) []int {
+	return perm(p, n)
+}
+
+func (p *PCG64) MarshalBinary() ([]byte, error) {
+	return marshalWords("pcg6", &p.cache, p.state.hi, p.state.lo, p.increment.hi, p.increment.lo), nil
+}
+
+func (p *PCG64) UnmarshalBinary(data []byte) error {
+	var state, increment uint128
+	if err := unmarshalWords(data, "pcg6", &p.cache, &state.hi, &state.lo, &increment.hi, &increment.lo); err != nil {
+		return err
+	}
+	if increment.lo&1 == 0 {
+		return errInvalidState
+	}
+	p.state, p.increment = state, increment
+	return nil
+}
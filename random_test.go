@@ -0,0 +1,209 @@
+package moea
+
+import (
+	"encoding"
+	"math"
+	"testing"
+)
+
+type namedRNG struct {
+	name string
+	new  func(seed uint64) RNG
+}
+
+var generators = []namedRNG{
+	{"Xorshift", func(seed uint64) RNG { return NewXorshiftWithSeed(uint32(seed)) }},
+	{"SplitMix64", func(seed uint64) RNG { return NewSplitMix64(seed) }},
+	{"Xoshiro256", func(seed uint64) RNG { return NewXoshiro256(seed) }},
+	{"PCG64", func(seed uint64) RNG { return NewPCG64(seed, 0) }},
+}
+
+func TestReferenceOutputs(t *testing.T) {
+	sm := NewSplitMix64(1234567)
+	for _, expected := range []uint64{6457827717110365317, 3203168211198807973, 9817491932198370423} {
+		if v := sm.Uint64(); v != expected {
+			t.Error("SplitMix64: expected", expected, "but was", v)
+		}
+	}
+	x := &Xoshiro256{s: [4]uint64{1, 2, 3, 4}}
+	for _, expected := range []uint64{11520, 0, 1509978240, 1215971899390074240} {
+		if v := x.Uint64(); v != expected {
+			t.Error("Xoshiro256: expected", expected, "but was", v)
+		}
+	}
+	x = &Xoshiro256{s: [4]uint64{1, 2, 3, 4}}
+	x.Jump()
+	if v := x.Uint64(); v != 13534147089533256664 {
+		t.Error("Xoshiro256 after jump: expected 13534147089533256664 but was", v)
+	}
+	p := NewPCG64(42, 54)
+	for _, expected := range []uint64{0x86b1da1d72062b68, 0x1304aa46c9853d39, 0xa3670e9e0dd50358, 0xf9090e529a7dae00} {
+		if v := p.Uint64(); v != expected {
+			t.Errorf("PCG64: expected %#x but was %#x", expected, v)
+		}
+	}
+}
+
+func TestPCG64Advance(t *testing.T) {
+	p1, p2 := NewPCG64(1, 2), NewPCG64(1, 2)
+	for i := 0; i < 1000; i++ {
+		p1.Uint64()
+	}
+	p2.Advance(0, 1000)
+	if p1.Uint64() != p2.Uint64() {
+		t.Error("Advance(1000) must equal 1000 steps")
+	}
+	p2.Advance(^uint64(0), ^uint64(0)-1000)
+	p3 := NewPCG64(1, 2)
+	if p2.Uint64() != p3.Uint64() {
+		t.Error("Advancing by -1001 must go back")
+	}
+}
+
+func TestStateSerialisation(t *testing.T) {
+	for _, g := range generators {
+		rng := g.new(11)
+		if _, ok := rng.(*RandV2); ok {
+			// math/rand/v2 keeps no normal spare, so only the source's state matters.
+			rng.Float64()
+		} else {
+			rng.NormFloat64()
+		}
+		data, err := rng.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatal(g.name, err)
+		}
+		restored := g.new(99)
+		if err := restored.(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err != nil {
+			t.Fatal(g.name, err)
+		}
+		for i := 0; i < 10; i++ {
+			if a, b := rng.NormFloat64(), restored.NormFloat64(); a != b {
+				t.Error(g.name, "diverged after restore:", a, b)
+			}
+		}
+		if err := restored.(encoding.BinaryUnmarshaler).UnmarshalBinary(data[1:]); err == nil {
+			t.Error(g.name, "must reject truncated state")
+		}
+	}
+}
+
+func TestStreams(t *testing.T) {
+	streams := NewXoshiro256(5).Streams(3)
+	first := make(map[float64]bool)
+	for _, s := range streams {
+		first[s.Float64()] = true
+	}
+	if len(first) != 3 {
+		t.Error("Jumped streams must differ")
+	}
+	words := make(map[uint32]bool)
+	for _, s := range NewXorshiftStreams(5, 100) {
+		x := s.(*Xorshift)
+		for _, w := range []uint32{x.x, x.y, x.z, x.w} {
+			if words[w] {
+				t.Fatal("Xorshift streams must not share state words")
+			}
+			words[w] = true
+		}
+	}
+	p1, p2 := NewPCG64(5, 0), NewPCG64(5, 0)
+	p2.LongJump()
+	if p1.Uint64() == p2.Uint64() {
+		t.Error("LongJump must change the stream")
+	}
+}
+
+// TestStatistics compares the generators with chi-square tests on Float64
+// and Intn, the moments of NormFloat64, lag-1 serial correlation and the
+// frequency of Flip. Thresholds are set at a significance of about 0.001.
+func TestStatistics(t *testing.T) {
+	const n = 200000
+	for _, g := range generators {
+		rng := g.new(2024)
+		buckets := make([]float64, 50)
+		previous, sxy, sx, sxx := rng.Float64(), 0.0, 0.0, 0.0
+		for i := 0; i < n; i++ {
+			v := rng.Float64()
+			buckets[int(v*50)%50]++
+			sxy += previous * v
+			sx += v
+			sxx += v * v
+			previous = v
+		}
+		chi := chiSquare(buckets, n)
+		mean := sx / n
+		correlation := (sxy/n - mean*mean) / (sxx/n - mean*mean)
+		ints := make([]float64, 7)
+		for i := 0; i < n; i++ {
+			ints[rng.Intn(7)]++
+		}
+		chiInt := chiSquare(ints, n)
+		sum, sumsq := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			v := rng.NormFloat64()
+			sum += v
+			sumsq += v * v
+		}
+		flips := 0
+		for i := 0; i < n; i++ {
+			if rng.Flip(0.01) {
+				flips++
+			}
+		}
+		t.Logf("%-10s chi2(Float64)=%6.2f chi2(Intn)=%5.2f corr=%+.5f normal mean=%+.4f var=%.4f flips=%d",
+			g.name, chi, chiInt, correlation, sum/n, sumsq/n, flips)
+		if chi > 85.4 {
+			t.Error(g.name, "Float64 is not uniform: chi2 =", chi)
+		}
+		if chiInt > 22.5 {
+			t.Error(g.name, "Intn is not uniform: chi2 =", chiInt)
+		}
+		if math.Abs(correlation) > 0.008 {
+			t.Error(g.name, "serial correlation", correlation)
+		}
+		if math.Abs(sum/n) > 0.008 || math.Abs(sumsq/n-1) > 0.012 {
+			t.Error(g.name, "NormFloat64 has mean", sum/n, "and variance", sumsq/n)
+		}
+		if math.Abs(float64(flips)-0.01*n) > 4*math.Sqrt(0.01*0.99*n) {
+			t.Error(g.name, "Flip(0.01) succeeded", flips, "times")
+		}
+	}
+}
+
+func TestFlipPrecision(t *testing.T) {
+	for i, g := range generators {
+		rng := g.new(1)
+		for j := 0; j < 1000; j++ {
+			if rng.Flip(0) || !rng.Flip(1) || rng.Flip(-1) || !rng.Flip(2) {
+				t.Fatal(g.name, "Flip(0) must fail and Flip(1) must succeed")
+			}
+			if i == 0 {
+				continue
+			}
+			if v := rng.Float64(); v < 0 || v >= 1 || v != math.Ldexp(math.Floor(math.Ldexp(v, 53)), -53) {
+				t.Fatal(g.name, "Float64 must be a multiple of 2^-53 in [0, 1):", v)
+			}
+		}
+	}
+}
+
+func chiSquare(counts []float64, n int) float64 {
+	expected := float64(n) / float64(len(counts))
+	result := 0.0
+	for _, c := range counts {
+		result += (c - expected) * (c - expected) / expected
+	}
+	return result
+}
+
+func BenchmarkGenerators(b *testing.B) {
+	for _, g := range generators {
+		rng := g.new(1)
+		b.Run(g.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				rng.Float64()
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+//go:build go1.22
+// +build go1.22
+
+package moea
+
+import (
+	"encoding"
+	"errors"
+	"math/rand/v2"
+)
+
+// RandV2 adapts any math/rand/v2 source, such as rand.NewPCG or
+// rand.NewChaCha8, to RNG. Its state can be serialised when the source's
+// can.
+type RandV2 struct {
+	*rand.Rand
+	source rand.Source
+}
+
+func NewRandV2(source rand.Source) *RandV2 {
+	return &RandV2{rand.New(source), source}
+}
+
+func (r *RandV2) Flip(probability float64) bool {
+	return r.Float64() < probability
+}
+
+func (r *RandV2) FairFlip() bool {
+	return r.Uint64()>>63 == 1
+}
+
+func (r *RandV2) Intn(n int) int {
+	return r.IntN(n)
+}
+
+func (r *RandV2) MarshalBinary() ([]byte, error) {
+	if m, ok := r.source.(encoding.BinaryMarshaler); ok {
+		return m.MarshalBinary()
+	}
+	return nil, errors.New("moea: source state cannot be serialised")
+}
+
+func (r *RandV2) UnmarshalBinary(data []byte) error {
+	if u, ok := r.source.(encoding.BinaryUnmarshaler); ok {
+		return u.UnmarshalBinary(data)
+	}
+	return errors.New("moea: source state cannot be serialised")
+}
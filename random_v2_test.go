@@ -0,0 +1,17 @@
+//go:build go1.22
+// +build go1.22
+
+package moea
+
+import "math/rand/v2"
+
+func init() {
+	generators = append(generators,
+		namedRNG{"PCG-DXSM", func(seed uint64) RNG { return NewRandV2(rand.NewPCG(seed, 0)) }},
+		namedRNG{"ChaCha8", func(seed uint64) RNG {
+			var key [32]byte
+			key[0] = byte(seed)
+			return NewRandV2(rand.NewChaCha8(key))
+		}},
+	)
+}
@@ -0,0 +1,113 @@
+package moea
+
+import "math/bits"
+
+// Xoshiro256 is Blackman and Vigna's xoshiro256** generator, with a period
+// of 2^256-1. Jump and LongJump split it into non-overlapping streams.
+type Xoshiro256 struct {
+	s     [4]uint64
+	cache normalCache
+}
+
+// NewXoshiro256 expands seed into the generator state with SplitMix64, as
+// recommended by the authors.
+func NewXoshiro256(seed uint64) *Xoshiro256 {
+	x := &Xoshiro256{}
+	sm := seed
+	for i := range x.s {
+		x.s[i] = splitMix64(&sm)
+	}
+	return x
+}
+
+func (x *Xoshiro256) Uint64() uint64 {
+	s := &x.s
+	result := bits.RotateLeft64(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+	return result
+}
+
+// Jump advances the generator by 2^128 steps, giving 2^128 non-overlapping
+// streams of 2^128 numbers each.
+func (x *Xoshiro256) Jump() {
+	x.jump([4]uint64{0x180ec6d33cfd0aba, 0xd5a61266f0c9392c, 0xa9582618e03fc9aa, 0x39abdc4529b1661c})
+}
+
+// LongJump advances the generator by 2^192 steps.
+func (x *Xoshiro256) LongJump() {
+	x.jump([4]uint64{0x76e15d3efefdcbbf, 0xc5004e441c522fb3, 0x77710069854ee241, 0x39109bb02acbe635})
+}
+
+func (x *Xoshiro256) jump(polynomial [4]uint64) {
+	var s [4]uint64
+	for _, p := range polynomial {
+		for b := uint(0); b < 64; b++ {
+			if p&(1<<b) != 0 {
+				for i := range s {
+					s[i] ^= x.s[i]
+				}
+			}
+			x.Uint64()
+		}
+	}
+	x.s = s
+	x.cache = normalCache{}
+}
+
+// Streams returns n generators, each one Jump ahead of the previous one and
+// the first one a copy of x. x itself is left after the last stream.
+func (x *Xoshiro256) Streams(n int) []RNG {
+	result := make([]RNG, n)
+	for i := range result {
+		stream := *x
+		result[i] = &stream
+		x.Jump()
+	}
+	return result
+}
+
+func (x *Xoshiro256) Flip(probability float64) bool {
+	return x.Float64() < probability
+}
+
+func (x *Xoshiro256) FairFlip() bool {
+	return x.Uint64()>>63 == 1
+}
+
+func (x *Xoshiro256) Float64() float64 {
+	return float64From(x)
+}
+
+func (x *Xoshiro256) Intn(n int) int {
+	return intnFrom(x, n)
+}
+
+func (x *Xoshiro256) NormFloat64() float64 {
+	return polarNormal(x, &x.cache.spare, &x.cache.hasSpare)
+}
+
+func (x *Xoshiro256) Perm(n int) []int {
+	return perm(x, n)
+}
+
+func (x *Xoshiro256) MarshalBinary() ([]byte, error) {
+	return marshalWords("x256", &x.cache, x.s[0], x.s[1], x.s[2], x.s[3]), nil
+}
+
+func (x *Xoshiro256) UnmarshalBinary(data []byte) error {
+	var s [4]uint64
+	if err := unmarshalWords(data, "x256", &x.cache, &s[0], &s[1], &s[2], &s[3]); err != nil {
+		return err
+	}
+	if s == [4]uint64{} {
+		return errInvalidState
+	}
+	x.s = s
+	return nil
+}
@@ -0,0 +1,204 @@
+// Package reference builds well spread sets of points on the unit simplex,
+// the reference directions of NSGA-III and the basis of the reference
+// fronts of the benchmark problems.
+package reference
+
+import (
+	"math"
+
+	"github.com/project-draco/moea"
+)
+
+// DasDennis returns the points of the unit simplex in m dimensions whose
+// coordinates are multiples of 1/divisions (Das and Dennis, 1998). There
+// are Size(divisions, m) of them.
+func DasDennis(divisions, m int) [][]float64 {
+	var result [][]float64
+	dasDennis(&result, make([]float64, m), divisions, divisions, 0)
+	return result
+}
+
+func dasDennis(result *[][]float64, current []float64, left int, total int, element int) {
+	if element == len(current)-1 {
+		current[element] = float64(left) / float64(total)
+		*result = append(*result, append([]float64(nil), current...))
+		return
+	}
+	for i := 0; i <= left; i++ {
+		current[element] = float64(i) / float64(total)
+		dasDennis(result, current, left-i, total, element+1)
+	}
+}
+
+// Size returns the number of Das and Dennis points with the given
+// divisions in m dimensions, the binomial coefficient of divisions+m-1 and
+// m-1.
+func Size(divisions, m int) int {
+	result := 1
+	for i := 1; i < m; i++ {
+		result = result * (divisions + i) / i
+	}
+	return result
+}
+
+// RieszEnergy returns n points of the unit simplex in m dimensions, the m
+// vertices among them, spread by minimizing their Riesz s-energy, the sum
+// of 1/|x_i - x_j|^s over the pairs of points, with s = 2m (Blank et al.,
+// 2021). Unlike DasDennis, it gives any number of points. The other points
+// start at random and descend the energy gradient, projected onto the
+// simplex, for at most 1000 iterations, each taking time quadratic in n.
+func RieszEnergy(n, m int, rng moea.RNG) [][]float64 {
+	if n < m {
+		panic("the Riesz s-energy set needs at least one point per vertex")
+	}
+	x := make([][]float64, n)
+	for i := range x {
+		x[i] = make([]float64, m)
+		if i < m {
+			x[i][i] = 1
+			continue
+		}
+		sum := 0.0
+		for j := range x[i] {
+			x[i][j] = -math.Log(1 - rng.Float64())
+			sum += x[i][j]
+		}
+		for j := range x[i] {
+			x[i][j] /= sum
+		}
+	}
+	gradient, candidate := make([][]float64, n), make([][]float64, n)
+	for i := range gradient {
+		gradient[i], candidate[i] = make([]float64, m), append([]float64(nil), x[i]...)
+	}
+	energy := logEnergy(x, m)
+	step := 0.1
+	for iteration := 0; iteration < 1000 && step > 1e-8; iteration++ {
+		energyGradient(x, m, gradient)
+		largest := 0.0
+		for i := m; i < n; i++ {
+			largest = math.Max(largest, norm(gradient[i]))
+		}
+		if largest == 0 {
+			break
+		}
+		for i := m; i < n; i++ {
+			sum := 0.0
+			for j := range candidate[i] {
+				candidate[i][j] = math.Max(0, x[i][j]-step*gradient[i][j]/largest)
+				sum += candidate[i][j]
+			}
+			for j := range candidate[i] {
+				candidate[i][j] /= sum
+			}
+		}
+		if e := logEnergy(candidate, m); e < energy {
+			energy = e
+			x, candidate = candidate, x
+			step *= 1.2
+		} else {
+			step /= 2
+		}
+		for i := m; i < n; i++ {
+			copy(candidate[i], x[i])
+		}
+	}
+	return x
+}
+
+// logEnergy returns the logarithm of the Riesz s-energy of x, scaled by the
+// smallest distance so as not to overflow at high s.
+func logEnergy(x [][]float64, m int) float64 {
+	closest := closestSquaredDistance(x)
+	sum := 0.0
+	for i := range x {
+		for k := i + 1; k < len(x); k++ {
+			sum += power(closest/squaredDistance(x[i], x[k]), m)
+		}
+	}
+	return math.Log(sum) - float64(m)*math.Log(closest)
+}
+
+// energyGradient stores in gradient the derivative of the energy, up to a
+// positive factor, with respect to every point, projected onto the plane of
+// the simplex.
+func energyGradient(x [][]float64, m int, gradient [][]float64) {
+	closest := closestSquaredDistance(x)
+	for i := range x {
+		for j := range gradient[i] {
+			gradient[i][j] = 0
+		}
+	}
+	for i := range x {
+		for k := i + 1; k < len(x); k++ {
+			scale := power(closest/squaredDistance(x[i], x[k]), m+1)
+			for j := range gradient[i] {
+				d := scale * (x[i][j] - x[k][j])
+				gradient[i][j] -= d
+				gradient[k][j] += d
+			}
+		}
+	}
+	for i := range x {
+		mean := 0.0
+		for _, g := range gradient[i] {
+			mean += g / float64(len(gradient[i]))
+		}
+		for j := range gradient[i] {
+			gradient[i][j] -= mean
+		}
+	}
+}
+
+func closestSquaredDistance(x [][]float64) float64 {
+	result := math.Inf(1)
+	for i := range x {
+		for k := i + 1; k < len(x); k++ {
+			result = math.Min(result, squaredDistance(x[i], x[k]))
+		}
+	}
+	return math.Max(result, 1e-24)
+}
+
+// power returns x^n by repeated squaring.
+func power(x float64, n int) float64 {
+	result := 1.0
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result *= x
+		}
+		x *= x
+	}
+	return result
+}
+
+// Uniform returns n points of the unit simplex in m dimensions: the Das
+// and Dennis points when some number of divisions gives exactly n of them,
+// and otherwise the Riesz s-energy points from a fixed seed.
+func Uniform(n, m int) [][]float64 {
+	if m == 1 {
+		return [][]float64{{1}}
+	}
+	for divisions := 1; Size(divisions, m) <= n; divisions++ {
+		if Size(divisions, m) == n {
+			return DasDennis(divisions, m)
+		}
+	}
+	return RieszEnergy(n, m, moea.NewXorshiftWithSeed(1))
+}
+
+func squaredDistance(a, b []float64) float64 {
+	sum := 0.0
+	for j := range a {
+		sum += (a[j] - b[j]) * (a[j] - b[j])
+	}
+	return sum
+}
+
+func norm(a []float64) float64 {
+	sum := 0.0
+	for _, x := range a {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
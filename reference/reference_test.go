@@ -0,0 +1,62 @@
+package reference
+
+import (
+	"math"
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+func TestDasDennis(t *testing.T) {
+	for _, f := range []struct{ divisions, m, size int }{{4, 3, 15}, {12, 3, 91}, {3, 8, 120}, {1, 15, 15}} {
+		points := DasDennis(f.divisions, f.m)
+		if len(points) != f.size || Size(f.divisions, f.m) != f.size {
+			t.Errorf("Expected %v points, got %v and %v", f.size, len(points), Size(f.divisions, f.m))
+		}
+		checkSimplex(t, points, f.m)
+	}
+}
+
+func TestRieszEnergy(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(3)
+	points := RieszEnergy(50, 3, rng)
+	if len(points) != 50 {
+		t.Fatal("Expected 50 points, got", len(points))
+	}
+	checkSimplex(t, points, 3)
+	for j := 0; j < 3; j++ {
+		if points[j][j] != 1 {
+			t.Error("Expected the vertices first, got", points[j])
+		}
+	}
+	// 45 Das and Dennis points, with 8 divisions, are 0.177 apart.
+	if d := math.Sqrt(closestSquaredDistance(points)); d < 0.12 {
+		t.Error("Expected the points spread over the simplex, closest at", d)
+	}
+}
+
+func TestUniform(t *testing.T) {
+	if points := Uniform(91, 3); len(points) != 91 || points[1][2] != 11.0/12 {
+		t.Error("Expected the Das and Dennis points for 91 points in 3 dimensions")
+	}
+	points := Uniform(40, 5)
+	if len(points) != 40 {
+		t.Error("Expected 40 points, got", len(points))
+	}
+	checkSimplex(t, points, 5)
+}
+
+func checkSimplex(t *testing.T, points [][]float64, m int) {
+	for _, p := range points {
+		sum := 0.0
+		for _, x := range p {
+			if x < 0 {
+				t.Error("Point", p, "is off the simplex")
+			}
+			sum += x
+		}
+		if len(p) != m || math.Abs(sum-1) > 1e-9 {
+			t.Error("Point", p, "is off the simplex")
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package moea
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ReplacementOperator chooses the next generation. survivors has one slot per
+// member of the population; each slot receives an index below parents.Len()
+// for a parent or parents.Len()+i for the i-th offspring. The built-in
+// operators compare the first objective only.
+type ReplacementOperator interface {
+	Replacement(config *Config, parents Population, parentObjectives [][]float64,
+		offspring Population, offspringResults []IndividualResult, survivors []int)
+}
+
+// GenerationalReplacement keeps the Elitism best parents in place of the
+// worst offspring.
+type GenerationalReplacement struct{ Elitism int }
+
+// MuPlusLambdaReplacement keeps the best of parents and Lambda offspring.
+// Lambda defaults to the population size.
+type MuPlusLambdaReplacement struct{ Lambda int }
+
+// MuCommaLambdaReplacement keeps the best of Lambda offspring. Lambda is at
+// least the population size.
+type MuCommaLambdaReplacement struct{ Lambda int }
+
+// WorstReplacement inserts Lambda offspring (2 by default) one by one, each
+// replacing the current worst member when it is better.
+type WorstReplacement struct{ Lambda int }
+
+// CrowdingReplacement is De Jong's crowding: each of Lambda offspring (2 by
+// default) replaces the most similar of CrowdingFactor (3 by default)
+// members drawn at random.
+type CrowdingReplacement struct {
+	Lambda         int
+	CrowdingFactor int
+	Distance       func(a, b Individual) float64
+}
+
+// DeterministicCrowding is Mahfoud's deterministic crowding: each child
+// competes with the most similar of its two parents and replaces it when it
+// is at least as good.
+type DeterministicCrowding struct {
+	Distance func(a, b Individual) float64
+}
+
+func (r *GenerationalReplacement) Replacement(config *Config, parents Population, parentObjectives [][]float64,
+	offspring Population, offspringResults []IndividualResult, survivors []int) {
+	elitism := r.Elitism
+	if elitism > len(survivors) {
+		elitism = len(survivors)
+	}
+	bestParents := sortedByFirstObjective(parentObjectives, len(parentObjectives))
+	copy(survivors, bestParents[:elitism])
+	bestOffspring := sortedByFirstObjective(resultObjectives(offspringResults), offspring.Len())
+	for i := elitism; i < len(survivors); i++ {
+		survivors[i] = parents.Len() + bestOffspring[i-elitism]
+	}
+}
+
+func (r *MuPlusLambdaReplacement) OffspringSize(config *Config) int {
+	if r.Lambda <= 0 {
+		return config.Population.Len()
+	}
+	return r.Lambda
+}
+
+func (r *MuPlusLambdaReplacement) Replacement(config *Config, parents Population, parentObjectives [][]float64,
+	offspring Population, offspringResults []IndividualResult, survivors []int) {
+	// Offspring come first so that they win ties and the population can drift
+	// across plateaus.
+	all := append(resultObjectives(offspringResults), parentObjectives...)
+	for i, index := range sortedByFirstObjective(all, len(all))[:len(survivors)] {
+		if index < offspring.Len() {
+			survivors[i] = parents.Len() + index
+		} else {
+			survivors[i] = index - offspring.Len()
+		}
+	}
+}
+
+func (r *MuCommaLambdaReplacement) OffspringSize(config *Config) int {
+	if r.Lambda < config.Population.Len() {
+		return config.Population.Len()
+	}
+	return r.Lambda
+}
+
+func (r *MuCommaLambdaReplacement) Replacement(config *Config, parents Population, parentObjectives [][]float64,
+	offspring Population, offspringResults []IndividualResult, survivors []int) {
+	for i, index := range sortedByFirstObjective(resultObjectives(offspringResults), offspring.Len())[:len(survivors)] {
+		survivors[i] = parents.Len() + index
+	}
+}
+
+func (r *WorstReplacement) OffspringSize(config *Config) int {
+	return defaultLambda(r.Lambda)
+}
+
+func (r *WorstReplacement) Replacement(config *Config, parents Population, parentObjectives [][]float64,
+	offspring Population, offspringResults []IndividualResult, survivors []int) {
+	objectives := make([][]float64, len(survivors))
+	for i := range survivors {
+		survivors[i] = i
+		objectives[i] = parentObjectives[i]
+	}
+	for i, o := range offspringResults {
+		worst := 0
+		for j := range objectives {
+			if objectives[j][0] > objectives[worst][0] {
+				worst = j
+			}
+		}
+		if o.Objective[0] < objectives[worst][0] {
+			survivors[worst] = parents.Len() + i
+			objectives[worst] = o.Objective
+		}
+	}
+}
+
+func (r *CrowdingReplacement) OffspringSize(config *Config) int {
+	return defaultLambda(r.Lambda)
+}
+
+func (r *CrowdingReplacement) Replacement(config *Config, parents Population, parentObjectives [][]float64,
+	offspring Population, offspringResults []IndividualResult, survivors []int) {
+	distance := r.Distance
+	if distance == nil {
+		distance = valueDistance(config)
+	}
+	crowdingFactor := r.CrowdingFactor
+	if crowdingFactor <= 0 {
+		crowdingFactor = 3
+	}
+	for i := range survivors {
+		survivors[i] = i
+	}
+	for i := 0; i < offspring.Len(); i++ {
+		closest, closestDistance := -1, math.Inf(1)
+		for j := 0; j < crowdingFactor; j++ {
+			k := config.RandomNumberGenerator.Intn(len(survivors))
+			if d := distance(offspring.Individual(i), individualAt(parents, offspring, survivors[k])); d < closestDistance {
+				closest, closestDistance = k, d
+			}
+		}
+		survivors[closest] = parents.Len() + i
+	}
+}
+
+func (r *DeterministicCrowding) Replacement(config *Config, parents Population, parentObjectives [][]float64,
+	offspring Population, offspringResults []IndividualResult, survivors []int) {
+	distance := r.Distance
+	if distance == nil {
+		distance = valueDistance(config)
+	}
+	for i := 0; i+1 < offspring.Len() && i+1 < len(survivors); i += 2 {
+		p1, p2 := offspringResults[i].Parent1, offspringResults[i].Parent2
+		c1, c2 := i, i+1
+		if distance(parents.Individual(p1), offspring.Individual(c1))+distance(parents.Individual(p2), offspring.Individual(c2)) >
+			distance(parents.Individual(p1), offspring.Individual(c2))+distance(parents.Individual(p2), offspring.Individual(c1)) {
+			c1, c2 = c2, c1
+		}
+		survivors[i] = compete(parents, parentObjectives, offspringResults, p1, c1)
+		survivors[i+1] = compete(parents, parentObjectives, offspringResults, p2, c2)
+	}
+}
+
+func compete(parents Population, parentObjectives [][]float64, offspringResults []IndividualResult, parent, child int) int {
+	if offspringResults[child].Objective[0] <= parentObjectives[parent][0] {
+		return parents.Len() + child
+	}
+	return parent
+}
+
+func individualAt(parents, offspring Population, survivor int) Individual {
+	if survivor < parents.Len() {
+		return parents.Individual(survivor)
+	}
+	return offspring.Individual(survivor - parents.Len())
+}
+
+func defaultLambda(lambda int) int {
+	if lambda <= 0 {
+		return 2
+	}
+	return lambda
+}
+
+func resultObjectives(results []IndividualResult) [][]float64 {
+	objectives := make([][]float64, len(results))
+	for i, r := range results {
+		objectives[i] = r.Objective
+	}
+	return objectives
+}
+
+func sortedByFirstObjective(objectives [][]float64, n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return objectives[indexes[i]][0] < objectives[indexes[j]][0] })
+	return indexes
+}
+
+// valueDistance compares the first NumberOfValues values of two individuals:
+// bit strings and []bool by Hamming distance, numbers by absolute difference
+// and anything else by whether they print the same. Each value only one of
+// two individuals of different lengths has adds one.
+func valueDistance(config *Config) func(a, b Individual) float64 {
+	type bitString interface {
+		Len() int
+		Test(int) bool
+	}
+	return func(a, b Individual) float64 {
+		result := 0.0
+		n := config.NumberOfValues
+		if shortest(a, b) < n {
+			n = shortest(a, b)
+			result = math.Min(float64(config.NumberOfValues), math.Max(float64(a.Len()), float64(b.Len()))) - float64(n)
+		}
+		for i := 0; i < n; i++ {
+			switch va := a.Value(i).(type) {
+			case bitString:
+				vb := b.Value(i).(bitString)
+				for j := 0; j < va.Len(); j++ {
+					if va.Test(j) != vb.Test(j) {
+						result++
+					}
+				}
+			case []bool:
+				vb := b.Value(i).([]bool)
+				for j := range va {
+					if va[j] != vb[j] {
+						result++
+					}
+				}
+			case float64:
+				result += math.Abs(va - b.Value(i).(float64))
+			case int:
+				result += math.Abs(float64(va - b.Value(i).(int)))
+			default:
+				if fmt.Sprint(va) != fmt.Sprint(b.Value(i)) {
+					result++
+				}
+			}
+		}
+		return result
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/benchmark"
 	"github.com/project-draco/moea/binary"
 	"github.com/project-draco/moea/nsga"
 )
@@ -38,24 +39,21 @@ func main() {
 		}
 		return []float64{-a, -(x - 5) * (x - 5)}
 	}
+	srn, err := benchmark.Get("srn", 0, 0)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
 	f3 := func(individual moea.Individual) []float64 {
-		a1 := valueAsFloat3(individual.Value(0))
-		a2 := valueAsFloat3(individual.Value(1))
-		result := []float64{
-			(a1-2)*(a1-2) + (a2-1)*(a2-1) + 2,
-			9*a1 - (a2-1)*(a2-1),
-		}
-		g := []float64{
-			-(a1*a1 + a2*a2 - 225.0),
-			-(a1 - 3.0*a2 + 10.0),
-		}
+		x := []float64{valueAsFloat3(individual.Value(0)), valueAsFloat3(individual.Value(1))}
+		result := srn.Evaluate(x)
 		penalty := 0.0
-		for i := 0; i < 2; i++ {
-			if g[i] < 0.0 {
-				penalty += 1.0e3 * g[i] * g[i]
+		for _, g := range srn.Constraints(x) {
+			if g < 0.0 {
+				penalty += 1.0e3 * g * g
 			}
 		}
-		for i := 0; i < 2; i++ {
+		for i := range result {
 			result[i] += penalty
 			result[i] = -result[i]
 		}
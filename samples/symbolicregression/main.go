@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/gp"
+	"github.com/project-draco/moea/nsgaii"
+)
+
+// target is the quartic polynomial of Koza's symbolic regression problem.
+func target(x float64) float64 { return x*x*x*x + x*x*x + x*x + x }
+
+func main() {
+	rng := moea.NewXorshiftWithSeed(uint32(time.Now().UTC().UnixNano()))
+	config := &moea.Config{
+		Algorithm: moea.NewSimpleAlgorithmWithOperators(moea.SimpleAlgorithmOperators{
+			Selection: &nsgaii.NsgaIISelection{},
+			Mutation:  &gp.SubtreeMutation{},
+			Crossover: &gp.SubtreeCrossover{},
+		}),
+		Population:     gp.NewRandomTreePopulation(500, gp.ArithmeticSet(1), gp.Options{SizeLimit: 100}, rng),
+		NumberOfValues: 1,
+		// accuracy versus size
+		ObjectiveFunc: func(individual moea.Individual) []float64 {
+			tree := gp.TreeOf(individual)
+			error := 0.0
+			for x := -1.0; x <= 1; x += 0.1 {
+				error += math.Abs(tree.Eval(x).(float64) - target(x))
+			}
+			return []float64{error, float64(tree.Size())}
+		},
+		NumberOfObjectives:    2,
+		MaxGenerations:        100,
+		CrossoverProbability:  0.9,
+		MutationProbability:   0.1,
+		RandomNumberGenerator: rng,
+	}
+	result, err := moea.Run(config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	best := map[float64]moea.IndividualResult{}
+	for _, r := range result.Individuals {
+		if b, ok := best[r.Objective[1]]; !ok || r.Objective[0] < b.Objective[0] {
+			best[r.Objective[1]] = r
+		}
+	}
+	var sizes []float64
+	for size := range best {
+		sizes = append(sizes, size)
+	}
+	sort.Float64s(sizes)
+	for _, size := range sizes {
+		fmt.Printf("%3v %10.6f %v\n", size, best[size].Objective[0], best[size].Values[0])
+	}
+}
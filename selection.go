@@ -0,0 +1,311 @@
+package moea
+
+import (
+	"math"
+	"sort"
+)
+
+// FitnessScaling turns the first objective, which is minimised and may have
+// any sign, into non-negative fitness values to be maximised by
+// fitness-proportionate selection. It is called once per generation.
+type FitnessScaling interface {
+	Scale(config *Config, objectives [][]float64, fitness []float64)
+}
+
+// WindowingScaling subtracts each objective from the worst objective seen
+// in the last Window generations (1 by default).
+type WindowingScaling struct {
+	Window int
+	worst  []float64
+	next   int
+}
+
+// LinearScaling is Goldberg's linear scaling: fitness is scaled so that the
+// best individual expects Multiple (2 by default) times the copies of an
+// average one, without letting any fitness become negative.
+type LinearScaling struct{ Multiple float64 }
+
+// SigmaScaling is sigma truncation: the mean minus C (2 by default) standard
+// deviations is subtracted from the fitness and negative values become 0.
+type SigmaScaling struct{ C float64 }
+
+// RouletteWheelSelection picks individuals with probability proportional to
+// their fitness, computed by Scaling (WindowingScaling by default).
+type RouletteWheelSelection struct {
+	Scaling FitnessScaling
+	wheel
+}
+
+// StochasticUniversalSampling is Baker's stochastic universal sampling. It
+// draws a whole population of parents with a single spin of a wheel with
+// evenly spaced pointers and hands them out in random order.
+type StochasticUniversalSampling struct {
+	Scaling  FitnessScaling
+	selected []int
+	next     int
+	wheel
+}
+
+// LinearRankingSelection selects by rank with selection pressure Pressure,
+// the expected copies of the best individual, in [1, 2] (1.5 by default).
+type LinearRankingSelection struct {
+	Pressure float64
+	wheel
+}
+
+// ExponentialRankingSelection gives the individual with rank i from the best
+// a weight of Base^i, with Base in (0, 1) (0.99 by default).
+type ExponentialRankingSelection struct {
+	Base float64
+	wheel
+}
+
+// BoltzmannSelection weights each individual by exp((best-objective)/T). T
+// starts at Temperature (1 by default) and is multiplied by Cooling (1 by
+// default) every generation.
+type BoltzmannSelection struct {
+	Temperature float64
+	Cooling     float64
+	temperature float64
+	wheel
+}
+
+// TruncationSelection picks uniformly among the best Proportion (0.5 by
+// default) of the population.
+type TruncationSelection struct {
+	Proportion float64
+	best       []int
+}
+
+// wheel holds the cumulative weights of a fitness-proportionate selection.
+type wheel struct {
+	fitness    []float64
+	cumulative []float64
+}
+
+// reset returns the fitness slice, sized for n individuals, to be filled in
+// before calling accumulate.
+func (w *wheel) reset(n int) []float64 {
+	if len(w.fitness) != n {
+		w.fitness = make([]float64, n)
+		w.cumulative = make([]float64, n)
+	}
+	return w.fitness
+}
+
+func (w *wheel) accumulate() {
+	sum := 0.0
+	for i, f := range w.fitness {
+		sum += f
+		w.cumulative[i] = sum
+	}
+}
+
+func (w *wheel) total() float64 {
+	return w.cumulative[len(w.cumulative)-1]
+}
+
+// spin returns the individual whose slice of the wheel contains r, or a
+// uniformly chosen one when all weights are zero.
+func (w *wheel) spin(rng RNG, r float64) int {
+	if w.total() <= 0 {
+		return rng.Intn(len(w.cumulative))
+	}
+	i := sort.Search(len(w.cumulative), func(i int) bool { return w.cumulative[i] > r })
+	if i == len(w.cumulative) {
+		i--
+	}
+	return i
+}
+
+func (w *wheel) Selection(config *Config, objectives [][]float64) int {
+	return w.spin(config.RandomNumberGenerator, config.RandomNumberGenerator.Float64()*w.total())
+}
+
+// ranks returns the indexes of objectives from best to worst.
+func ranks(objectives [][]float64) []int {
+	return sortedByFirstObjective(objectives, len(objectives))
+}
+
+func (s *WindowingScaling) Scale(config *Config, objectives [][]float64, fitness []float64) {
+	window := s.Window
+	if window <= 0 {
+		window = 1
+	}
+	if cap(s.worst) != window {
+		s.worst = make([]float64, 0, window)
+		s.next = 0
+	}
+	worst := objectives[0][0]
+	for _, o := range objectives {
+		worst = math.Max(worst, o[0])
+	}
+	if len(s.worst) < window {
+		s.worst = append(s.worst, worst)
+	} else {
+		s.worst[s.next] = worst
+	}
+	s.next = (s.next + 1) % window
+	for _, w := range s.worst {
+		worst = math.Max(worst, w)
+	}
+	for i, o := range objectives {
+		fitness[i] = worst - o[0]
+	}
+}
+
+func (s *LinearScaling) Scale(config *Config, objectives [][]float64, fitness []float64) {
+	multiple := s.Multiple
+	if multiple <= 1 {
+		multiple = 2
+	}
+	(&WindowingScaling{}).Scale(config, objectives, fitness)
+	min, max, avg := fitness[0], fitness[0], 0.0
+	for _, f := range fitness {
+		min = math.Min(min, f)
+		max = math.Max(max, f)
+		avg += f / float64(len(fitness))
+	}
+	if max == avg {
+		for i := range fitness {
+			fitness[i] = 1
+		}
+		return
+	}
+	var a, b float64
+	if min > (multiple*avg-max)/(multiple-1) {
+		delta := max - avg
+		a = (multiple - 1) * avg / delta
+		b = avg * (max - multiple*avg) / delta
+	} else {
+		delta := avg - min
+		a = avg / delta
+		b = -min * avg / delta
+	}
+	for i, f := range fitness {
+		fitness[i] = math.Max(0, a*f+b)
+	}
+}
+
+func (s *SigmaScaling) Scale(config *Config, objectives [][]float64, fitness []float64) {
+	c := s.C
+	if c <= 0 {
+		c = 2
+	}
+	avg, variance := 0.0, 0.0
+	for _, o := range objectives {
+		avg -= o[0] / float64(len(objectives))
+	}
+	for _, o := range objectives {
+		variance += (-o[0] - avg) * (-o[0] - avg) / float64(len(objectives))
+	}
+	sigma := math.Sqrt(variance)
+	for i, o := range objectives {
+		if sigma == 0 {
+			fitness[i] = 1
+		} else {
+			fitness[i] = math.Max(0, -o[0]-(avg-c*sigma))
+		}
+	}
+}
+
+func (rws *RouletteWheelSelection) OnGeneration(config *Config, population Population, objectives [][]float64) {
+	if rws.Scaling == nil {
+		rws.Scaling = &WindowingScaling{}
+	}
+	rws.Scaling.Scale(config, objectives, rws.reset(len(objectives)))
+	rws.accumulate()
+}
+
+func (sus *StochasticUniversalSampling) OnGeneration(config *Config, population Population, objectives [][]float64) {
+	if sus.Scaling == nil {
+		sus.Scaling = &WindowingScaling{}
+	}
+	sus.Scaling.Scale(config, objectives, sus.reset(len(objectives)))
+	sus.accumulate()
+	sus.next = len(sus.selected)
+}
+
+func (sus *StochasticUniversalSampling) Selection(config *Config, objectives [][]float64) int {
+	if sus.next >= len(sus.selected) {
+		n := len(objectives)
+		if len(sus.selected) != n {
+			sus.selected = make([]int, n)
+		}
+		step := sus.total() / float64(n)
+		r := config.RandomNumberGenerator.Float64() * step
+		for i, j := range config.RandomNumberGenerator.Perm(n) {
+			sus.selected[j] = sus.spin(config.RandomNumberGenerator, r+float64(i)*step)
+		}
+		sus.next = 0
+	}
+	sus.next++
+	return sus.selected[sus.next-1]
+}
+
+func (lrs *LinearRankingSelection) OnGeneration(config *Config, population Population, objectives [][]float64) {
+	pressure := lrs.Pressure
+	if pressure < 1 || pressure > 2 {
+		pressure = 1.5
+	}
+	n := float64(len(objectives))
+	order := ranks(objectives)
+	fitness := lrs.reset(len(objectives))
+	for rank, i := range order {
+		// the worst individual has position 0 and the best n-1
+		position := n - 1 - float64(rank)
+		fitness[i] = (2-pressure)/n + 2*position*(pressure-1)/(n*(n-1))
+	}
+	lrs.accumulate()
+}
+
+func (ers *ExponentialRankingSelection) OnGeneration(config *Config, population Population, objectives [][]float64) {
+	base := ers.Base
+	if base <= 0 || base >= 1 {
+		base = 0.99
+	}
+	order := ranks(objectives)
+	fitness := ers.reset(len(objectives))
+	for rank, i := range order {
+		fitness[i] = math.Pow(base, float64(rank))
+	}
+	ers.accumulate()
+}
+
+func (bs *BoltzmannSelection) Initialize(config *Config) {
+	bs.temperature = bs.Temperature
+	if bs.temperature <= 0 {
+		bs.temperature = 1
+	}
+}
+
+func (bs *BoltzmannSelection) OnGeneration(config *Config, population Population, objectives [][]float64) {
+	if bs.temperature <= 0 {
+		bs.Initialize(config)
+	}
+	best := objectives[0][0]
+	for _, o := range objectives {
+		best = math.Min(best, o[0])
+	}
+	fitness := bs.reset(len(objectives))
+	for i, o := range objectives {
+		fitness[i] = math.Exp((best - o[0]) / bs.temperature)
+	}
+	bs.accumulate()
+	if bs.Cooling > 0 {
+		bs.temperature *= bs.Cooling
+	}
+}
+
+func (ts *TruncationSelection) OnGeneration(config *Config, population Population, objectives [][]float64) {
+	proportion := ts.Proportion
+	if proportion <= 0 || proportion > 1 {
+		proportion = 0.5
+	}
+	ts.best = ranks(objectives)
+	ts.best = ts.best[:int(math.Max(1, math.Ceil(proportion*float64(len(objectives)))))]
+}
+
+func (ts *TruncationSelection) Selection(config *Config, objectives [][]float64) int {
+	return ts.best[config.RandomNumberGenerator.Intn(len(ts.best))]
+}
@@ -0,0 +1,90 @@
+package moea
+
+import (
+	"testing"
+)
+
+func selectionCounts(operator SelectionOperator, objectives [][]float64, draws int) []int {
+	config := &Config{
+		Population:            make(offspringPopulation, len(objectives)),
+		NumberOfObjectives:    1,
+		RandomNumberGenerator: NewXorshiftWithSeed(17),
+	}
+	if i, ok := operator.(interface{ Initialize(*Config) }); ok {
+		i.Initialize(config)
+	}
+	if l, ok := operator.(interface {
+		OnGeneration(*Config, Population, [][]float64)
+	}); ok {
+		l.OnGeneration(config, config.Population, objectives)
+	}
+	counts := make([]int, len(objectives))
+	for i := 0; i < draws; i++ {
+		counts[operator.Selection(config, objectives)]++
+	}
+	return counts
+}
+
+func TestSelectionPrefersBetterObjectives(t *testing.T) {
+	// negative, mixed and positive objectives, best last
+	for _, objectives := range [][][]float64{
+		{{-1}, {-2}, {-3}, {-4}},
+		{{3}, {1}, {-1}, {-3}},
+		{{40}, {30}, {20}, {10}},
+	} {
+		for _, operator := range []SelectionOperator{
+			&TournamentSelection{TournamentSize: 2},
+			&RouletteWheelSelection{},
+			&RouletteWheelSelection{Scaling: &LinearScaling{}},
+			&RouletteWheelSelection{Scaling: &SigmaScaling{C: 1}},
+			&RouletteWheelSelection{Scaling: &WindowingScaling{Window: 3}},
+			&StochasticUniversalSampling{},
+			&LinearRankingSelection{},
+			&ExponentialRankingSelection{Base: 0.5},
+			&BoltzmannSelection{Temperature: 5},
+			&TruncationSelection{},
+		} {
+			counts := selectionCounts(operator, objectives, 10000)
+			for i := 1; i < len(counts); i++ {
+				if float64(counts[i]) < 0.95*float64(counts[i-1]) {
+					t.Errorf("%T with %v: counts %v not increasing", operator, objectives, counts)
+					break
+				}
+			}
+			if counts[len(counts)-1] == 0 {
+				t.Errorf("%T with %v: best never selected", operator, objectives)
+			}
+		}
+	}
+}
+
+func TestLinearRankingExpectedCopies(t *testing.T) {
+	objectives := [][]float64{{4}, {3}, {2}, {1}, {0}}
+	counts := selectionCounts(&LinearRankingSelection{Pressure: 2}, objectives, 50000)
+	if counts[0] != 0 {
+		t.Errorf("expected worst never selected with pressure 2 but was %v", counts[0])
+	}
+	if copies := float64(counts[4]) / 10000; copies < 1.9 || copies > 2.1 {
+		t.Errorf("expected 2 copies of the best but was %v", copies)
+	}
+}
+
+func TestStochasticUniversalSamplingSpread(t *testing.T) {
+	objectives := [][]float64{{3}, {2}, {1}, {0}}
+	operator := &StochasticUniversalSampling{Scaling: &SigmaScaling{}}
+	counts := selectionCounts(operator, objectives, 4)
+	// fitness 0.44, 1.56, 2.68, 3.79 of a total 8.47, so each individual is
+	// drawn floor or ceil of its expected 0.2, 0.74, 1.27, 1.79 copies
+	for i, expected := range [][2]int{{0, 1}, {0, 1}, {1, 2}, {1, 2}} {
+		if counts[i] < expected[0] || counts[i] > expected[1] {
+			t.Errorf("unexpected counts %v", counts)
+		}
+	}
+}
+
+func TestTournamentSelectsLast(t *testing.T) {
+	counts := selectionCounts(&TournamentSelection{TournamentSize: 1}, [][]float64{{0}, {0}, {0}}, 3000)
+	if counts[2] == 0 {
+		t.Errorf("last individual never selected: %v", counts)
+	}
+}
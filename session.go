@@ -0,0 +1,218 @@
+package moea
+
+import (
+	"errors"
+	"math"
+)
+
+// Session runs a configuration a few generations at a time, so that a
+// decision maker can look at the current front and change their
+// preferences between steps. Run is a session resumed to the end.
+type Session struct {
+	config     *Config
+	result     *Result
+	generation int
+	finished   bool
+}
+
+// Preferences of a decision maker. Nil fields leave the current
+// preferences unchanged.
+type Preferences struct {
+	// ReferencePoints are aspiration points in objective space; for
+	// NSGA-III they are given in its normalized objective space.
+	ReferencePoints [][]float64
+	// LowerBounds and UpperBounds bound the objectives of the region of
+	// interest. Individuals outside it are treated as infeasible.
+	LowerBounds []float64
+	UpperBounds []float64
+	// Weights are the relative importance of the objectives.
+	Weights []float64
+}
+
+// preferenceListener is implemented by algorithms and operators that take
+// the preferences of a decision maker into account. Changes apply from the
+// next generation on, and any state derived from the previous preferences
+// must be brought up to date. Invalid preferences are rejected with an
+// error before any of them is applied.
+type preferenceListener interface {
+	UpdatePreferences(*Config, Preferences) error
+}
+
+// populationReporter is implemented by algorithms and operators whose
+// current population is not the one reported by the last generation, such
+// as the survivors NSGA-II keeps between generations. A nil population
+// means there is none yet.
+type populationReporter interface {
+	CurrentPopulation(*Config) (Population, [][]float64)
+}
+
+var (
+	errSessionFinished = errors.New("moea: session already finished")
+	errNoPreferences   = errors.New("moea: the algorithm does not take preferences")
+)
+
+// NewSession initializes the algorithm of config, and the population is
+// evaluated, but no generation is run. Callers must either resume the
+// session or close it, for algorithms such as the steady-state one hold
+// goroutines until they are finalized.
+func NewSession(config *Config) *Session {
+	result := &Result{}
+	config.Algorithm.Initialize(config)
+	result.BestIndividual = config.Population.Individual(0).Clone()
+	result.BestObjective = make([]float64, config.NumberOfObjectives)
+	for i := 0; i < config.NumberOfObjectives; i++ {
+		result.BestObjective[i] = math.MaxFloat64
+	}
+	return &Session{config: config, result: result}
+}
+
+// Step runs n more generations, or fewer if MaxGenerations or
+// MaxEvaluations are reached, and returns the result so far. Like Resume,
+// it runs none when the configuration sets neither limit.
+func (s *Session) Step(n int) (*Result, error) {
+	if s.finished {
+		return nil, errSessionFinished
+	}
+	for i := 0; i < n && s.running(); i++ {
+		if err := s.next(); err != nil {
+			return nil, err
+		}
+	}
+	return s.result, nil
+}
+
+// Resume runs the remaining generations, finalizes the algorithm and
+// returns the final result. The session cannot be stepped afterwards.
+func (s *Session) Resume() (*Result, error) {
+	if s.finished {
+		return nil, errSessionFinished
+	}
+	for s.running() {
+		if err := s.next(); err != nil {
+			return nil, err
+		}
+	}
+	s.finish()
+	return s.result, nil
+}
+
+// Close finalizes the algorithm without running the remaining generations,
+// releasing what it holds, and completes the result returned by the last
+// step. It does nothing on a finished session.
+func (s *Session) Close() error {
+	if !s.finished {
+		s.finish()
+	}
+	return nil
+}
+
+func (s *Session) finish() {
+	type finalizer interface {
+		Finalize(*Result)
+	}
+	if f, ok := s.config.Algorithm.(finalizer); ok {
+		f.Finalize(s.result)
+	}
+	s.finished = true
+}
+
+// Generation returns the number of generations run so far.
+func (s *Session) Generation() int {
+	return s.generation
+}
+
+// CurrentFront returns copies of the nondominated individuals of the
+// current population, without duplicated objectives.
+func (s *Session) CurrentFront() []IndividualResult {
+	individuals := s.result.Individuals
+	if r, ok := s.config.Algorithm.(populationReporter); ok {
+		if population, objectives := r.CurrentPopulation(s.config); population != nil {
+			individuals = make([]IndividualResult, population.Len())
+			for i := range individuals {
+				values := RecordValues(s.config, population.Individual(i), nil)
+				individuals[i] = IndividualResult{objectives[i], -1, -1, -1, values}
+			}
+		}
+	}
+	var front []IndividualResult
+	for i, a := range individuals {
+		keep := true
+		for j, b := range individuals {
+			if Dominates(b.Objective, a.Objective) || j < i && equalObjectives(a.Objective, b.Objective) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			a.Objective = append([]float64(nil), a.Objective...)
+			a.Values = append([]interface{}(nil), a.Values...)
+			front = append(front, a)
+		}
+	}
+	return front
+}
+
+// UpdatePreferences hands the preferences to the algorithm, which uses
+// them from the next step on.
+func (s *Session) UpdatePreferences(preferences Preferences) error {
+	if s.finished {
+		return errSessionFinished
+	}
+	l, ok := s.config.Algorithm.(preferenceListener)
+	if !ok {
+		return errNoPreferences
+	}
+	return l.UpdatePreferences(s.config, preferences)
+}
+
+// running tells whether the limits of the configuration allow another
+// generation. Without MaxGenerations the run stops on MaxEvaluations, and
+// without either there is nothing to run.
+func (s *Session) running() bool {
+	if s.config.MaxGenerations <= 0 && s.config.MaxEvaluations <= 0 {
+		return false
+	}
+	return (s.config.MaxGenerations <= 0 || s.generation < s.config.MaxGenerations) &&
+		(s.config.MaxEvaluations <= 0 || s.result.Evaluations < s.config.MaxEvaluations)
+}
+
+func (s *Session) next() error {
+	config, result := s.config, s.result
+	generationResult, err := config.Algorithm.Generation()
+	if err != nil {
+		return err
+	}
+	if config.OnGenerationFunc != nil {
+		config.OnGenerationFunc(s.generation, generationResult)
+	}
+	for j := 0; j < config.NumberOfObjectives; j++ {
+		if generationResult.BestObjective[j] < result.BestObjective[j] {
+			if j == 0 {
+				CopyIndividual(result.BestIndividual, generationResult.BestIndividual)
+				result.BestIndividualIndex = generationResult.BestIndividualIndex
+			}
+			result.BestObjective[j] = generationResult.BestObjective[j]
+		}
+	}
+	result.Mutations += generationResult.Mutations
+	result.Crossovers += generationResult.Crossovers
+	result.Evaluations += generationResult.Evaluations
+	result.Individuals = generationResult.Individuals
+	result.CrossoverProbability = generationResult.CrossoverProbability
+	result.MutationProbability = generationResult.MutationProbability
+	result.CrossoverOperatorProbabilities = generationResult.CrossoverOperatorProbabilities
+	result.MutationOperatorProbabilities = generationResult.MutationOperatorProbabilities
+	result.NicheCounts = generationResult.NicheCounts
+	result.Archive = generationResult.Archive
+	s.generation++
+	return nil
+}
+
+func equalObjectives(a, b []float64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
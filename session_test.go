@@ -0,0 +1,103 @@
+package moea
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSessionSteps(t *testing.T) {
+	expected, err := Run(onemaxConfig(NewXorshiftWithSeed(3)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSession(onemaxConfig(NewXorshiftWithSeed(3)))
+	if front := s.CurrentFront(); len(front) != 1 {
+		t.Errorf("Expected the best initial individual, got %v", front)
+	}
+	if _, err := s.Step(4); err != nil {
+		t.Fatal(err)
+	}
+	front := s.CurrentFront()
+	if s.Generation() != 4 || len(front) != 1 {
+		t.Errorf("Expected a single best objective after 4 generations, got %v at %v", front, s.Generation())
+	}
+	s.Step(100)
+	if s.Generation() != 10 {
+		t.Errorf("Expected steps to stop at MaxGenerations, got %v", s.Generation())
+	}
+	result, err := s.Resume()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("A session stepped to the end must give the result of Run")
+	}
+	if _, err := s.Step(1); err != errSessionFinished {
+		t.Errorf("Expected %v, got %v", errSessionFinished, err)
+	}
+}
+
+func TestSessionWithoutLimits(t *testing.T) {
+	config := onemaxConfig(NewXorshiftWithSeed(3))
+	config.MaxGenerations = 0
+	s := NewSession(config)
+	if s.Step(3); s.Generation() != 0 {
+		t.Errorf("Expected no generation without limits, got %v", s.Generation())
+	}
+	if s.Resume(); s.Generation() != 0 {
+		t.Errorf("Expected no generation without limits, got %v", s.Generation())
+	}
+}
+
+func TestSessionCurrentFront(t *testing.T) {
+	s := &Session{config: &Config{}, result: &Result{Individuals: []IndividualResult{
+		{Objective: []float64{1, 2}, Values: []interface{}{"a"}},
+		{Objective: []float64{2, 1}, Values: []interface{}{"b"}},
+		{Objective: []float64{2, 2}, Values: []interface{}{"c"}},
+		{Objective: []float64{1, 2}, Values: []interface{}{"d"}},
+	}}}
+	front := s.CurrentFront()
+	if len(front) != 2 || front[0].Values[0] != "a" || front[1].Values[0] != "b" {
+		t.Errorf("Unexpected front %v", front)
+	}
+	front[0].Objective[0] = 9
+	if s.result.Individuals[0].Objective[0] != 1 {
+		t.Error("The front must be a copy")
+	}
+}
+
+func TestSessionWithoutPreferences(t *testing.T) {
+	config := onemaxConfig(NewXorshiftWithSeed(3))
+	config.Algorithm = NewSimulatedAnnealing(SimulatedAnnealingOptions{})
+	s := NewSession(config)
+	if err := s.UpdatePreferences(Preferences{Weights: []float64{1}}); err != errNoPreferences {
+		t.Errorf("Expected %v, got %v", errNoPreferences, err)
+	}
+}
+
+func TestSessionClose(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	config := onemaxConfig(NewXorshiftWithSeed(3))
+	config.Algorithm = NewSteadyStateAlgorithm(SteadyStateOperators{Workers: 4})
+	s := NewSession(config)
+	if _, err := s.Step(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100 && runtime.NumGoroutine() > goroutines; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > goroutines {
+		t.Errorf("Expected the workers to stop, %v goroutines left of %v", n, goroutines)
+	}
+	if _, err := s.Step(1); err != errSessionFinished {
+		t.Errorf("Expected %v, got %v", errSessionFinished, err)
+	}
+	if err := s.Close(); err != nil {
+		t.Error("Closing twice must do nothing, got", err)
+	}
+}
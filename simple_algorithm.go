@@ -11,13 +11,48 @@ type simpleAlgorithm struct {
 	objectivesSum        []float64
 	oldPopulation        Population
 	newPopulation        Population
+	offspring            Population
+	offspringObjectives  [][]float64
+	offspringResults     []IndividualResult
+	survivors            []int
 	selectionOperator    SelectionOperator
 	mutationOperator     MutationOperator
+	replacementOperator  ReplacementOperator
+	crossoverOperator    CrossoverOperator
+	parameterControl     ParameterControl
+	offspringListeners   []offspringListener
+	reporters            []reporter
+	generation           int
 	crossoverProbability float64
 	mutationProbability  float64
+	search               LocalSearch
+	searchProbability    float64
+	baldwinian           bool
+	scratch              Individual
+	evaluations          int
 	result               *Result
 }
 
+type SimpleAlgorithmOperators struct {
+	Selection SelectionOperator
+	Mutation  MutationOperator
+	// Replacement decides which parents and offspring make up the next
+	// generation. When nil the offspring replace the whole population.
+	Replacement ReplacementOperator
+	// Crossover defaults to OnePointCrossover.
+	Crossover CrossoverOperator
+	// ParameterControl sets the crossover and mutation probabilities of
+	// each generation. When nil the configured ones are used throughout.
+	ParameterControl ParameterControl
+	// LocalSearch makes the algorithm memetic: it is applied to a fraction
+	// LocalSearchProbability of the evaluated offspring, all of them when
+	// 0. Improvements are written back into the offspring (Lamarckian)
+	// unless Baldwinian is set, in which case only their objectives change.
+	LocalSearch            LocalSearch
+	LocalSearchProbability float64
+	Baldwinian             bool
+}
+
 type SelectionOperator interface {
 	Selection(config *Config, objectives [][]float64) int
 }
@@ -26,7 +61,32 @@ type MutationOperator interface {
 	Mutation(config *Config, individual Individual, probability float64)
 }
 
-type RouletteWheelSelection struct{ objectivesSum float64 }
+type initializer interface {
+	Initialize(*Config)
+}
+
+type finalizer interface {
+	Finalize(*Config, Population, [][]float64, *Result)
+}
+
+// offspringListener is implemented by operators and parameter controls that
+// learn from each evaluated offspring and the objectives of its parents.
+type offspringListener interface {
+	OnOffspring(config *Config, parent1, parent2 []float64, child Individual, objective []float64)
+}
+
+// resizer is implemented by individuals whose length varies, such as those
+// of package varlen. Resize sets the length to n, keeping the first values;
+// Copy never changes it.
+type resizer interface {
+	Resize(n int)
+}
+
+// reporter is implemented by operators and parameter controls that add to
+// the result of every generation.
+type reporter interface {
+	Report(*Result)
+}
 
 type TournamentSelection struct{ TournamentSize int }
 
@@ -38,13 +98,32 @@ type RegularMutation struct {
 type FastMutation struct{ RegularMutation }
 
 func NewSimpleAlgorithm(selectionOperator SelectionOperator, mutationOperator MutationOperator) Algorithm {
-	if selectionOperator == nil {
-		selectionOperator = &TournamentSelection{10}
+	return NewSimpleAlgorithmWithOperators(SimpleAlgorithmOperators{Selection: selectionOperator, Mutation: mutationOperator})
+}
+
+func NewSimpleAlgorithmWithOperators(operators SimpleAlgorithmOperators) Algorithm {
+	if operators.Selection == nil {
+		operators.Selection = &TournamentSelection{10}
+	}
+	if operators.Mutation == nil {
+		operators.Mutation = &RegularMutation{}
+	}
+	if operators.Crossover == nil {
+		operators.Crossover = &OnePointCrossover{}
 	}
-	if mutationOperator == nil {
-		mutationOperator = &RegularMutation{}
+	a := &simpleAlgorithm{
+		selectionOperator:   operators.Selection,
+		mutationOperator:    operators.Mutation,
+		replacementOperator: operators.Replacement,
+		crossoverOperator:   operators.Crossover,
+		parameterControl:    operators.ParameterControl,
+		search:              operators.LocalSearch,
+		searchProbability:   operators.LocalSearchProbability,
+		baldwinian:          operators.Baldwinian,
+	}
+	if a.searchProbability <= 0 {
+		a.searchProbability = 1
 	}
-	a := &simpleAlgorithm{selectionOperator: selectionOperator, mutationOperator: mutationOperator}
 	return a
 }
 
@@ -55,6 +134,13 @@ func (a *simpleAlgorithm) Generation() (*Result, error) {
 		WorstObjective:   a.result.WorstObjective,
 		BestObjective:    a.result.BestObjective,
 	}
+	if a.parameterControl != nil {
+		a.crossoverProbability, a.mutationProbability = a.parameterControl.Rates(a.config, a.generation)
+	}
+	if a.generation == 0 {
+		a.result.Evaluations = a.config.Population.Len()
+	}
+	a.generation++
 	for i := 0; i < a.config.NumberOfObjectives; i++ {
 		a.objectivesSum[i] = 0
 		a.result.AverageObjective[i] = 0
@@ -67,9 +153,9 @@ func (a *simpleAlgorithm) Generation() (*Result, error) {
 	if l, ok := a.selectionOperator.(onGenerationListener); ok {
 		l.OnGeneration(a.config, a.oldPopulation, a.oldObjectives)
 	}
-	for i := 0; i < a.newPopulation.Len(); i += 2 {
-		child1 := a.newPopulation.Individual(i)
-		child2 := a.newPopulation.Individual(i + 1)
+	for i := 0; i < a.offspring.Len(); i += 2 {
+		child1 := a.offspring.Individual(i)
+		child2 := a.offspring.Individual(i + 1)
 		parentIndex1 := a.selectionOperator.Selection(a.config, a.oldObjectives)
 		parentIndex2 := a.selectionOperator.Selection(a.config, a.oldObjectives)
 		parent1 := a.oldPopulation.Individual(parentIndex1)
@@ -77,10 +163,16 @@ func (a *simpleAlgorithm) Generation() (*Result, error) {
 		crossSite := a.crossover(parent1, parent2, child1, child2)
 		a.mutationOperator.Mutation(a.config, child1, a.mutationProbability)
 		a.mutationOperator.Mutation(a.config, child2, a.mutationProbability)
-		f1 := a.config.ObjectiveFunc(child1)
-		f2 := a.config.ObjectiveFunc(child2)
-		a.newObjectives[i] = f1
-		a.newObjectives[i+1] = f2
+		f1 := a.localSearch(child1, a.config.ObjectiveFunc(child1))
+		f2 := a.localSearch(child2, a.config.ObjectiveFunc(child2))
+		a.evaluations += 2
+		a.result.Evaluations += 2
+		a.offspringObjectives[i] = f1
+		a.offspringObjectives[i+1] = f2
+		for _, l := range a.offspringListeners {
+			l.OnOffspring(a.config, a.oldObjectives[parentIndex1], a.oldObjectives[parentIndex2], child1, f1)
+			l.OnOffspring(a.config, a.oldObjectives[parentIndex1], a.oldObjectives[parentIndex2], child2, f2)
+		}
 		if f1[0] <= f2[0] && f1[0] < a.result.BestObjective[0] {
 			a.result.BestIndividual = child1
 			a.result.BestIndividualIndex = i
@@ -97,35 +189,75 @@ func (a *simpleAlgorithm) Generation() (*Result, error) {
 				a.result.WorstObjective[j] = math.Max(f1[j], f2[j])
 			}
 		}
-		a.result.Individuals[i].Objective = f1
-		a.result.Individuals[i+1].Objective = f2
-		a.result.Individuals[i].Parent1 = parentIndex1
-		a.result.Individuals[i].Parent2 = parentIndex2
-		a.result.Individuals[i+1].Parent1 = parentIndex1
-		a.result.Individuals[i+1].Parent2 = parentIndex2
-		a.result.Individuals[i].CrossSite = crossSite
-		a.result.Individuals[i+1].CrossSite = crossSite
-		if a.result.Individuals[i].Values == nil {
-			a.result.Individuals[i].Values = make([]interface{}, a.config.NumberOfValues)
-			a.result.Individuals[i+1].Values = make([]interface{}, a.config.NumberOfValues)
-		}
-		for j := 0; j < a.config.NumberOfValues; j++ {
-			a.result.Individuals[i].Values[j] = child1.Value(j)
-			a.result.Individuals[i+1].Values[j] = child2.Value(j)
-		}
+		a.offspringResults[i].Objective = f1
+		a.offspringResults[i+1].Objective = f2
+		a.offspringResults[i].Parent1 = parentIndex1
+		a.offspringResults[i].Parent2 = parentIndex2
+		a.offspringResults[i+1].Parent1 = parentIndex1
+		a.offspringResults[i+1].Parent2 = parentIndex2
+		a.offspringResults[i].CrossSite = crossSite
+		a.offspringResults[i+1].CrossSite = crossSite
+		a.offspringResults[i].Values = RecordValues(a.config, child1, a.offspringResults[i].Values)
+		a.offspringResults[i+1].Values = RecordValues(a.config, child2, a.offspringResults[i+1].Values)
+	}
+	if a.replacementOperator != nil {
+		a.replace()
 	}
 	a.oldObjectives, a.newObjectives = a.newObjectives, a.oldObjectives
 	a.oldPopulation, a.newPopulation = a.newPopulation, a.oldPopulation
+	if a.replacementOperator == nil {
+		a.offspring, a.offspringObjectives = a.newPopulation, a.newObjectives
+	}
 	for i := 0; i < a.config.NumberOfObjectives; i++ {
 		a.result.AverageObjective[i] = a.objectivesSum[i] / float64(a.newPopulation.Len())
 	}
+	a.result.CrossoverProbability = a.crossoverProbability
+	a.result.MutationProbability = a.mutationProbability
+	for _, r := range a.reporters {
+		r.Report(a.result)
+	}
 	return a.result, nil
 }
 
-func (a *simpleAlgorithm) Finalize(result *Result) {
-	type finalizer interface {
-		Finalize(*Config, Population, [][]float64, *Result)
+// replace copies the survivors chosen by the replacement operator into the
+// new population and recomputes the statistics of the result over them.
+func (a *simpleAlgorithm) replace() {
+	a.replacementOperator.Replacement(a.config, a.oldPopulation, a.oldObjectives, a.offspring, a.offspringResults, a.survivors)
+	for i := 0; i < a.config.NumberOfObjectives; i++ {
+		a.objectivesSum[i] = 0
+		a.result.WorstObjective[i] = 0
+		a.result.BestObjective[i] = math.MaxFloat64
 	}
+	for i, s := range a.survivors {
+		var individual Individual
+		if s < a.oldPopulation.Len() {
+			individual = a.oldPopulation.Individual(s)
+			a.newObjectives[i] = a.oldObjectives[s]
+			values := a.result.Individuals[i].Values
+			a.result.Individuals[i] = IndividualResult{a.oldObjectives[s], s, s, -1, values}
+		} else {
+			individual = a.offspring.Individual(s - a.oldPopulation.Len())
+			a.newObjectives[i] = a.offspringObjectives[s-a.oldPopulation.Len()]
+			values := a.result.Individuals[i].Values
+			a.result.Individuals[i] = a.offspringResults[s-a.oldPopulation.Len()]
+			a.result.Individuals[i].Values = values
+		}
+		CopyIndividual(a.newPopulation.Individual(i), individual)
+		a.result.Individuals[i].Values = RecordValues(a.config, a.newPopulation.Individual(i), a.result.Individuals[i].Values)
+		f := a.newObjectives[i]
+		if f[0] < a.result.BestObjective[0] {
+			a.result.BestIndividual = a.newPopulation.Individual(i)
+			a.result.BestIndividualIndex = i
+		}
+		for j := 0; j < a.config.NumberOfObjectives; j++ {
+			a.objectivesSum[j] += f[j]
+			a.result.BestObjective[j] = math.Min(a.result.BestObjective[j], f[j])
+			a.result.WorstObjective[j] = math.Max(a.result.WorstObjective[j], f[j])
+		}
+	}
+}
+
+func (a *simpleAlgorithm) Finalize(result *Result) {
 	if f, ok := a.selectionOperator.(finalizer); ok {
 		f.Finalize(a.config, a.oldPopulation, a.oldObjectives, result)
 	}
@@ -134,29 +266,35 @@ func (a *simpleAlgorithm) Finalize(result *Result) {
 	}
 }
 
-func (rws *RouletteWheelSelection) OnGeneration(config *Config, objectives [][]float64) {
-	rws.objectivesSum = 0
-	for _, o := range objectives {
-		rws.objectivesSum += o[0]
+// CurrentPopulation is that of the selection operator, if it keeps one,
+// and otherwise the population the next generation breeds from.
+func (a *simpleAlgorithm) CurrentPopulation(config *Config) (Population, [][]float64) {
+	if r, ok := a.selectionOperator.(populationReporter); ok {
+		if population, objectives := r.CurrentPopulation(config); population != nil {
+			return population, objectives
+		}
 	}
+	return a.oldPopulation, a.oldObjectives
 }
 
-func (rws *RouletteWheelSelection) Selection(config *Config, objectives [][]float64) int {
-	r := config.RandomNumberGenerator.Float64() * rws.objectivesSum
-	sum := 0.0
-	for i := 0; i < config.Population.Len(); i++ {
-		sum += objectives[i][0]
-		if -sum >= -r {
-			return i
+// UpdatePreferences hands the preferences to the operators that take them,
+// stopping at the first that rejects them.
+func (a *simpleAlgorithm) UpdatePreferences(config *Config, preferences Preferences) error {
+	for _, operator := range []interface{}{a.selectionOperator, a.mutationOperator, a.replacementOperator,
+		a.crossoverOperator, a.parameterControl, a.search} {
+		if l, ok := operator.(preferenceListener); ok {
+			if err := l.UpdatePreferences(config, preferences); err != nil {
+				return err
+			}
 		}
 	}
-	return config.Population.Len() - 1
+	return nil
 }
 
 func (ts *TournamentSelection) Selection(config *Config, objectives [][]float64) int {
 	result := -1
 	for i := 0; i < ts.TournamentSize; i++ {
-		r := int(config.RandomNumberGenerator.Float64() * float64(config.Population.Len()-1))
+		r := config.RandomNumberGenerator.Intn(len(objectives))
 		if result == -1 || objectives[r][0] < objectives[result][0] {
 			result = r
 		}
@@ -165,26 +303,74 @@ func (ts *TournamentSelection) Selection(config *Config, objectives [][]float64)
 }
 
 func (a *simpleAlgorithm) crossover(parent1, parent2, child1, child2 Individual) int {
-	if !a.config.RandomNumberGenerator.Flip(a.crossoverProbability) {
-		child1.Copy(parent1, 0, child1.Len())
-		child2.Copy(parent2, 0, child2.Len())
+	cross := a.crossoverOperator.Crossover(a.config, parent1, parent2, child1, child2, a.crossoverProbability)
+	if cross >= 0 {
+		a.result.Crossovers++
+	}
+	return cross
+}
+
+// crossover cuts both parents at the same site, within the shorter one, so
+// that each child takes the length of the parent giving it its tail.
+func crossover(config *Config, probability float64, parent1, parent2, child1, child2 Individual) int {
+	if !config.RandomNumberGenerator.Flip(probability) {
+		CopyIndividual(child1, parent1)
+		CopyIndividual(child2, parent2)
 		return -1
 	}
-	cross := 1 + int(a.config.RandomNumberGenerator.Float64()*float64(parent1.Len()-2))
+	cross := 1 + int(config.RandomNumberGenerator.Float64()*float64(shortest(parent1, parent2)-2))
+	resize(child1, parent2.Len())
+	resize(child2, parent1.Len())
 	child1.Copy(parent1, 0, cross)
-	child1.Copy(parent2, cross, child1.Len())
+	child1.Copy(parent2, cross, parent2.Len())
 	child2.Copy(parent2, 0, cross)
-	child2.Copy(parent1, cross, child2.Len())
-	a.result.Crossovers++
+	child2.Copy(parent1, cross, parent1.Len())
 	return cross
 }
 
+// CopyIndividual makes dst a copy of the whole of src, resizing it first
+// when its length varies.
+func CopyIndividual(dst, src Individual) {
+	resize(dst, src.Len())
+	dst.Copy(src, 0, src.Len())
+}
+
+func resize(individual Individual, n int) {
+	if r, ok := individual.(resizer); ok && individual.Len() != n {
+		r.Resize(n)
+	}
+}
+
+func shortest(individual1, individual2 Individual) int {
+	if individual1.Len() < individual2.Len() {
+		return individual1.Len()
+	}
+	return individual2.Len()
+}
+
+// RecordValues stores the first NumberOfValues values of individual in
+// values, reusing its storage, or all of them when the individual is
+// shorter. Selections that report their own results use it to fill
+// IndividualResult.Values.
+func RecordValues(config *Config, individual Individual, values []interface{}) []interface{} {
+	n := config.NumberOfValues
+	if individual.Len() < n {
+		n = individual.Len()
+	}
+	values = values[:0]
+	for j := 0; j < n; j++ {
+		values = append(values, individual.Value(j))
+	}
+	return values
+}
+
 func (m *RegularMutation) Initialize(config *Config) {
 	m.mutationsIndexes = make([]int, config.Population.Individual(0).Len())
 }
 
 func (m *RegularMutation) Mutation(config *Config, individual Individual, probability float64) {
 	len := individual.Len()
+	m.grow(len)
 	j := 0
 	for i := 0; i < len; i++ {
 		f := config.RandomNumberGenerator.Flip(probability)
@@ -197,6 +383,15 @@ func (m *RegularMutation) Mutation(config *Config, individual Individual, probab
 	individual.Mutate(m.mutationsIndexes[0:j])
 }
 
+// grow makes room for the mutations of individuals longer than the first
+// one of the population.
+func (m *RegularMutation) grow(length int) {
+	if length > cap(m.mutationsIndexes) {
+		m.mutationsIndexes = make([]int, length)
+	}
+	m.mutationsIndexes = m.mutationsIndexes[:cap(m.mutationsIndexes)]
+}
+
 func (m *RegularMutation) Finalize(_ *Config, _ Population, _ [][]float64, result *Result) {
 	result.Mutations = m.mutations
 }
@@ -206,6 +401,7 @@ func (m *FastMutation) Initialize(config *Config) {
 }
 
 func (m *FastMutation) Mutation(config *Config, individual Individual, probability float64) {
+	m.grow(individual.Len() + 1)
 	j := 0
 	for len := float64(individual.Len()) * probability; len > 0; len-- {
 		i := int(config.RandomNumberGenerator.Float64() * float64(individual.Len()))
@@ -238,21 +434,72 @@ func (a *simpleAlgorithm) Initialize(config *Config) {
 	}
 	a.oldPopulation = config.Population
 	a.newPopulation = config.Population.Clone()
+	a.offspring, a.offspringObjectives = a.newPopulation, a.newObjectives
 	a.crossoverProbability = a.config.CrossoverProbability
 	a.mutationProbability = a.config.MutationProbability
+	a.generation = 0
+	a.evaluations = config.Population.Len()
+	if a.search != nil {
+		a.scratch = config.Population.Individual(0).Clone()
+	}
 	a.result = &Result{
 		Individuals:      make([]IndividualResult, config.Population.Len()),
 		AverageObjective: make([]float64, a.config.NumberOfObjectives),
 		WorstObjective:   make([]float64, a.config.NumberOfObjectives),
 		BestObjective:    make([]float64, a.config.NumberOfObjectives),
 	}
-	type initializer interface {
-		Initialize(*Config)
+	a.offspringResults = a.result.Individuals
+	if a.replacementOperator != nil {
+		a.initializeReplacement()
+	}
+	a.offspringListeners, a.reporters = nil, nil
+	for _, operator := range []interface{}{a.selectionOperator, a.mutationOperator, a.replacementOperator,
+		a.crossoverOperator, a.parameterControl, a.search} {
+		if i, ok := operator.(initializer); ok {
+			i.Initialize(a.config)
+		}
+		if l, ok := operator.(offspringListener); ok {
+			a.offspringListeners = append(a.offspringListeners, l)
+		}
+		if r, ok := operator.(reporter); ok {
+			a.reporters = append(a.reporters, r)
+		}
 	}
-	if i, ok := a.selectionOperator.(initializer); ok {
-		i.Initialize(a.config)
+}
+
+// initializeReplacement sizes a separate offspring population, because
+// survivors may come from both parents and offspring.
+func (a *simpleAlgorithm) initializeReplacement() {
+	type offspringSizer interface {
+		OffspringSize(*Config) int
 	}
-	if i, ok := a.mutationOperator.(initializer); ok {
-		i.Initialize(a.config)
+	lambda := a.config.Population.Len()
+	if o, ok := a.replacementOperator.(offspringSizer); ok {
+		lambda = o.OffspringSize(a.config)
 	}
+	if lambda%2 == 1 {
+		lambda++
+	}
+	individuals := make(offspringPopulation, lambda)
+	for i := range individuals {
+		individuals[i] = a.config.Population.Individual(i % a.config.Population.Len()).Clone()
+	}
+	a.offspring = individuals
+	a.offspringObjectives = make([][]float64, lambda)
+	a.offspringResults = make([]IndividualResult, lambda)
+	a.survivors = make([]int, a.config.Population.Len())
+}
+
+type offspringPopulation []Individual
+
+func (p offspringPopulation) Len() int { return len(p) }
+
+func (p offspringPopulation) Individual(i int) Individual { return p[i] }
+
+func (p offspringPopulation) Clone() Population {
+	result := make(offspringPopulation, len(p))
+	for i, individual := range p {
+		result[i] = individual.Clone()
+	}
+	return result
 }
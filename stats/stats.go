@@ -0,0 +1,303 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+func Mean(x []float64) float64 {
+	sum := 0.0
+	for _, v := range x {
+		sum += v
+	}
+	return sum / float64(len(x))
+}
+
+func Median(x []float64) float64 {
+	return Quantile(x, 0.5)
+}
+
+// Quantile interpolates linearly between order statistics (type 7 in
+// Hyndman and Fan's classification, the default of R).
+func Quantile(x []float64, q float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	sorted := make([]float64, len(x))
+	copy(sorted, x)
+	sort.Float64s(sorted)
+	h := q * float64(len(sorted)-1)
+	lo := math.Floor(h)
+	if int(lo)+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[int(lo)] + (h-lo)*(sorted[int(lo)+1]-sorted[int(lo)])
+}
+
+func IQR(x []float64) float64 {
+	return Quantile(x, 0.75) - Quantile(x, 0.25)
+}
+
+// Ranks returns the ranks (starting at 1) of x, assigning tied values the
+// average of the ranks they span.
+func Ranks(x []float64) []float64 {
+	indexes := make([]int, len(x))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return x[indexes[i]] < x[indexes[j]] })
+	ranks := make([]float64, len(x))
+	for i := 0; i < len(indexes); {
+		j := i
+		for j+1 < len(indexes) && x[indexes[j+1]] == x[indexes[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			ranks[indexes[k]] = rank
+		}
+		i = j + 1
+	}
+	return ranks
+}
+
+// RankSum performs the two-sided Wilcoxon rank-sum (Mann-Whitney U) test
+// using the normal approximation with tie and continuity corrections. It
+// returns the z statistic, positive when x tends to be larger than y, and
+// the p-value.
+func RankSum(x, y []float64) (float64, float64) {
+	n1, n2 := float64(len(x)), float64(len(y))
+	if n1 == 0 || n2 == 0 {
+		return 0, 1
+	}
+	all := append(append([]float64{}, x...), y...)
+	ranks := Ranks(all)
+	w := 0.0
+	for i := range x {
+		w += ranks[i]
+	}
+	u := w - n1*(n1+1)/2
+	mean := n1 * n2 / 2
+	n := n1 + n2
+	ties := 0.0
+	counts := map[float64]float64{}
+	for _, v := range all {
+		counts[v]++
+	}
+	for _, t := range counts {
+		ties += t*t*t - t
+	}
+	variance := n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance <= 0 {
+		return 0, 1
+	}
+	d := u - mean
+	switch {
+	case d > 0.5:
+		d -= 0.5
+	case d < -0.5:
+		d += 0.5
+	default:
+		d = 0
+	}
+	z := d / math.Sqrt(variance)
+	return z, math.Min(1, 2*NormalSurvival(math.Abs(z)))
+}
+
+// Holm adjusts p-values for multiple comparisons with the Holm-Bonferroni
+// step-down procedure. The result is in the same order as p.
+func Holm(p []float64) []float64 {
+	indexes := make([]int, len(p))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return p[indexes[i]] < p[indexes[j]] })
+	adjusted := make([]float64, len(p))
+	max := 0.0
+	for rank, i := range indexes {
+		v := math.Min(1, float64(len(p)-rank)*p[i])
+		max = math.Max(max, v)
+		adjusted[i] = max
+	}
+	return adjusted
+}
+
+// NormalSurvival returns P(Z > z) for a standard normal Z.
+func NormalSurvival(z float64) float64 {
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
+
+// Friedman performs the Friedman test for k treatments measured in each of
+// the blocks, with the tie correction of Conover. blocks[i][j] is the value
+// of treatment j in block i. It returns the statistic, approximately
+// chi-square with k-1 degrees of freedom, the p-value, and the sums of the
+// within-block ranks of each treatment.
+func Friedman(blocks [][]float64) (float64, float64, []float64) {
+	if len(blocks) == 0 || len(blocks[0]) < 2 {
+		return 0, 1, nil
+	}
+	b, k := float64(len(blocks)), float64(len(blocks[0]))
+	sums := make([]float64, len(blocks[0]))
+	a := 0.0
+	for _, block := range blocks {
+		for j, r := range Ranks(block) {
+			sums[j] += r
+			a += r * r
+		}
+	}
+	c := b * k * (k + 1) * (k + 1) / 4
+	if a == c {
+		return 0, 1, sums
+	}
+	s := 0.0
+	for _, r := range sums {
+		s += r * r
+	}
+	statistic := (k - 1) * (s - b*c) / (a - c)
+	return statistic, ChiSquareSurvival(statistic, k-1), sums
+}
+
+// FriedmanPostHoc returns the two-sided p-values of Conover's post-hoc test
+// comparing the rank sum of treatment j with that of treatment best, given
+// the blocks and rank sums of Friedman.
+func FriedmanPostHoc(blocks [][]float64, sums []float64, best int) []float64 {
+	b, k := float64(len(blocks)), float64(len(sums))
+	a, s := 0.0, 0.0
+	for _, block := range blocks {
+		for _, r := range Ranks(block) {
+			a += r * r
+		}
+	}
+	for _, r := range sums {
+		s += r * r
+	}
+	df := (b - 1) * (k - 1)
+	p := make([]float64, len(sums))
+	variance := 2 * (b*a - s) / df
+	for j := range sums {
+		if variance <= 0 || j == best {
+			p[j] = 1
+			continue
+		}
+		t := math.Abs(sums[j]-sums[best]) / math.Sqrt(variance)
+		p[j] = 2 * StudentTSurvival(t, df)
+	}
+	return p
+}
+
+// ChiSquareSurvival returns P(X > x) for X chi-square distributed with df
+// degrees of freedom.
+func ChiSquareSurvival(x, df float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	return upperIncompleteGamma(df/2, x/2)
+}
+
+// StudentTSurvival returns P(T > t) for T Student-t distributed with df
+// degrees of freedom.
+func StudentTSurvival(t, df float64) float64 {
+	p := 0.5 * incompleteBeta(df/(df+t*t), df/2, 0.5)
+	if t < 0 {
+		return 1 - p
+	}
+	return p
+}
+
+// upperIncompleteGamma is the regularized Q(a, x), from the series for small
+// x and the continued fraction otherwise (Numerical Recipes, 6.2).
+func upperIncompleteGamma(a, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1.0; n < 1000; n++ {
+			term *= x / (a + n)
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-x+a*math.Log(x)-lg)
+	}
+	b := x + 1 - a
+	c := 1 / 1e-300
+	d := 1 / b
+	h := d
+	for i := 1.0; i < 1000; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < 1e-300 {
+			d = 1e-300
+		}
+		c = b + an/c
+		if math.Abs(c) < 1e-300 {
+			c = 1e-300
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lg) * h
+}
+
+// incompleteBeta is the regularized I_x(a, b), evaluated with the continued
+// fraction of Numerical Recipes, 6.4.
+func incompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+	if x > (a+1)/(a+b+2) {
+		return 1 - front*betaFraction(1-x, b, a)/b
+	}
+	return front * betaFraction(x, a, b) / a
+}
+
+func betaFraction(x, a, b float64) float64 {
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1.0; m < 1000; m++ {
+		numerator := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		numerator = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return h
+}
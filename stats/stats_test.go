@@ -0,0 +1,93 @@
+package stats
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestQuantile(t *testing.T) {
+	x := []float64{4, 1, 3, 2}
+	for _, f := range []struct{ q, out float64 }{{0, 1}, {0.5, 2.5}, {1, 4}, {0.25, 1.75}} {
+		if v := Quantile(x, f.q); math.Abs(v-f.out) > 1e-12 {
+			t.Error("Expected", f.out, "but was", v)
+		}
+	}
+	if v := IQR(x); math.Abs(v-1.5) > 1e-12 {
+		t.Error("Expected 1.5 but was", v)
+	}
+}
+
+func TestRanks(t *testing.T) {
+	if r := Ranks([]float64{10, 20, 10, 30}); !reflect.DeepEqual(r, []float64{1.5, 3, 1.5, 4}) {
+		t.Error("Unexpected ranks", r)
+	}
+}
+
+func TestRankSum(t *testing.T) {
+	// Reference values from R: wilcox.test(x, y, exact=FALSE, correct=TRUE)
+	x := []float64{1.83, 0.50, 1.62, 2.48, 1.68, 1.88, 1.55, 3.06, 1.30}
+	y := []float64{0.878, 0.647, 0.598, 2.05, 1.06, 1.29, 1.06, 3.14, 1.29}
+	z, p := RankSum(x, y)
+	if z <= 0 || math.Abs(p-0.1329) > 1e-3 {
+		t.Error("Expected p=0.1329 but was", z, p)
+	}
+	if _, p := RankSum([]float64{1, 1}, []float64{1, 1}); p != 1 {
+		t.Error("Expected p=1 for identical samples but was", p)
+	}
+}
+
+func TestHolm(t *testing.T) {
+	adjusted := Holm([]float64{0.01, 0.04, 0.03, 0.005})
+	expected := []float64{0.03, 0.06, 0.06, 0.02}
+	for i := range expected {
+		if math.Abs(adjusted[i]-expected[i]) > 1e-12 {
+			t.Error("Expected", expected, "but was", adjusted)
+		}
+	}
+}
+
+func TestFriedman(t *testing.T) {
+	// RoundingTimes from the R documentation of friedman.test:
+	// Friedman chi-squared = 11.143, df = 2, p-value = 0.003805
+	blocks := [][]float64{
+		{5.40, 5.50, 5.55}, {5.85, 5.70, 5.75}, {5.20, 5.60, 5.50}, {5.55, 5.50, 5.40},
+		{5.90, 5.85, 5.70}, {5.45, 5.55, 5.60}, {5.40, 5.40, 5.35}, {5.45, 5.50, 5.35},
+		{5.25, 5.15, 5.00}, {5.85, 5.80, 5.70}, {5.25, 5.20, 5.10}, {5.65, 5.55, 5.45},
+		{5.60, 5.35, 5.45}, {5.05, 5.00, 4.95}, {5.50, 5.50, 5.40}, {5.45, 5.55, 5.50},
+		{5.55, 5.55, 5.35}, {5.45, 5.50, 5.55}, {5.50, 5.45, 5.25}, {5.65, 5.60, 5.40},
+		{5.70, 5.65, 5.55}, {6.30, 6.30, 6.25},
+	}
+	statistic, p, sums := Friedman(blocks)
+	if math.Abs(statistic-11.143) > 1e-3 || math.Abs(p-0.003805) > 1e-6 {
+		t.Error("Expected 11.143 and p=0.003805 but was", statistic, p)
+	}
+	posthoc := FriedmanPostHoc(blocks, sums, 2)
+	if posthoc[2] != 1 || posthoc[1] > 0.05 || posthoc[0] > posthoc[1] {
+		t.Error("Unexpected post-hoc p-values", sums, posthoc)
+	}
+	if _, p, _ := Friedman([][]float64{{1, 1}, {2, 2}}); p != 1 {
+		t.Error("Expected p=1 for ties but was", p)
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	// closed forms for df 1 and 2, and the closed form for even df or
+	// numerical integration otherwise
+	for _, c := range []struct{ x, df, chi, t float64 }{
+		{3.841459, 1, 0.05, 0.5 - math.Atan(3.841459)/math.Pi},
+		{11.143, 2, math.Exp(-11.143 / 2), 0.5 - 11.143/(2*math.Sqrt(11.143*11.143+2))},
+		{2.228139, 10, 0.9942737, 0.025},
+		{30, 25, 0.2242890, 1.997931e-21},
+	} {
+		if chi := ChiSquareSurvival(c.x, c.df); math.Abs(chi-c.chi) > 1e-6 {
+			t.Errorf("ChiSquareSurvival(%v, %v): expected %v but was %v", c.x, c.df, c.chi, chi)
+		}
+		if st := StudentTSurvival(c.x, c.df); math.Abs(st-c.t) > 1e-6*math.Max(c.t, 1e-20) {
+			t.Errorf("StudentTSurvival(%v, %v): expected %v but was %v", c.x, c.df, c.t, st)
+		}
+	}
+	if p := StudentTSurvival(-2.228139, 10); math.Abs(p-0.975) > 1e-6 {
+		t.Error("Expected 0.975 but was", p)
+	}
+}
@@ -0,0 +1,281 @@
+package moea
+
+import (
+	"math"
+	"runtime"
+)
+
+type SteadyStateOperators struct {
+	Selection SelectionOperator
+	Mutation  MutationOperator
+	// Crossover defaults to OnePointCrossover. Only its first child is
+	// evaluated, so it must not learn from offspring, as AdaptiveCrossover
+	// does.
+	Crossover CrossoverOperator
+	// Replacement picks the member each evaluated offspring replaces. It
+	// defaults to ReplaceWorst.
+	Replacement SteadyStateReplacement
+	// Workers is the number of offspring evaluated concurrently. It defaults
+	// to GOMAXPROCS. Runs are only reproducible with a single worker, since
+	// offspring are inserted, and handed to the operators that learn from
+	// them, in the order their evaluations finish.
+	Workers int
+	// ReportInterval is the number of evaluations each call to Generation
+	// waits for, and so the number between OnGenerationFunc calls. It
+	// defaults to the population size.
+	ReportInterval int
+}
+
+// SteadyStateReplacement returns the index of the population member an
+// offspring with the given objectives replaces, or -1 to discard it. births
+// holds the evaluation count at which each member was inserted.
+type SteadyStateReplacement interface {
+	Replace(config *Config, objectives [][]float64, births []int, offspring []float64) int
+}
+
+// ReplaceWorst replaces the worst member when the offspring is better.
+type ReplaceWorst struct{}
+
+// ReplaceRandom replaces a member chosen uniformly at random.
+type ReplaceRandom struct{}
+
+// ReplaceOldest replaces the member that has been in the population longest.
+type ReplaceOldest struct{}
+
+type steadyStateAlgorithm struct {
+	config         *Config
+	selection      SelectionOperator
+	mutation       MutationOperator
+	crossover      CrossoverOperator
+	replacement    SteadyStateReplacement
+	listeners      []offspringListener
+	reporters      []reporter
+	workers        int
+	reportInterval int
+	objectives     [][]float64
+	births         []int
+	evaluations    int
+	scratch        Individual
+	jobs           chan *steadyStateJob
+	done           chan *steadyStateJob
+	result         *Result
+}
+
+type steadyStateJob struct {
+	individual Individual
+	objective  []float64
+	index      int
+	parent1    int
+	parent2    int
+	crossSite  int
+	// parentObjectives are those of the parents when the offspring was
+	// bred, since they may be replaced while it is evaluated.
+	parentObjectives [2][]float64
+}
+
+// NewSteadyStateAlgorithm returns an algorithm that keeps Workers offspring
+// under evaluation at all times and inserts each one into the population as
+// soon as its evaluation finishes. Selection, variation and replacement run
+// on the caller's goroutine; only ObjectiveFunc runs on the workers.
+func NewSteadyStateAlgorithm(operators SteadyStateOperators) Algorithm {
+	if operators.Selection == nil {
+		operators.Selection = &TournamentSelection{10}
+	}
+	if operators.Mutation == nil {
+		operators.Mutation = &RegularMutation{}
+	}
+	if operators.Crossover == nil {
+		operators.Crossover = &OnePointCrossover{}
+	}
+	if _, ok := operators.Crossover.(offspringListener); ok {
+		panic("moea: the steady-state algorithm cannot evaluate both children of a crossover that learns from offspring")
+	}
+	if operators.Replacement == nil {
+		operators.Replacement = &ReplaceWorst{}
+	}
+	if operators.Workers <= 0 {
+		operators.Workers = runtime.GOMAXPROCS(0)
+	}
+	return &steadyStateAlgorithm{
+		selection:      operators.Selection,
+		mutation:       operators.Mutation,
+		crossover:      operators.Crossover,
+		replacement:    operators.Replacement,
+		workers:        operators.Workers,
+		reportInterval: operators.ReportInterval,
+	}
+}
+
+func (a *steadyStateAlgorithm) Initialize(config *Config) {
+	a.config = config
+	n := config.Population.Len()
+	if a.reportInterval <= 0 {
+		a.reportInterval = n
+	}
+	a.jobs = make(chan *steadyStateJob, a.workers)
+	a.done = make(chan *steadyStateJob, a.workers)
+	for i := 0; i < a.workers; i++ {
+		go a.work()
+	}
+	go func() {
+		for i := 0; i < n; i++ {
+			a.jobs <- &steadyStateJob{individual: config.Population.Individual(i), index: i}
+		}
+	}()
+	a.objectives = make([][]float64, n)
+	a.births = make([]int, n)
+	a.result = &Result{
+		Individuals:      make([]IndividualResult, n),
+		AverageObjective: make([]float64, config.NumberOfObjectives),
+		WorstObjective:   make([]float64, config.NumberOfObjectives),
+		BestObjective:    make([]float64, config.NumberOfObjectives),
+	}
+	for i := 0; i < n; i++ {
+		job := <-a.done
+		a.objectives[job.index] = job.objective
+		a.result.Individuals[job.index] = IndividualResult{Objective: job.objective, Parent1: -1, Parent2: -1, CrossSite: -1}
+	}
+	a.scratch = config.Population.Individual(0).Clone()
+	a.listeners, a.reporters = nil, nil
+	for _, operator := range []interface{}{a.selection, a.mutation, a.crossover, a.replacement} {
+		if i, ok := operator.(initializer); ok {
+			i.Initialize(config)
+		}
+		if l, ok := operator.(offspringListener); ok {
+			a.listeners = append(a.listeners, l)
+		}
+		if r, ok := operator.(reporter); ok {
+			a.reporters = append(a.reporters, r)
+		}
+	}
+	for i := 0; i < a.workers; i++ {
+		job := &steadyStateJob{individual: config.Population.Individual(i % n).Clone()}
+		a.breed(job)
+		a.jobs <- job
+	}
+}
+
+func (a *steadyStateAlgorithm) work() {
+	for job := range a.jobs {
+		job.objective = a.config.ObjectiveFunc(job.individual)
+		a.done <- job
+	}
+}
+
+func (a *steadyStateAlgorithm) Generation() (*Result, error) {
+	type onGenerationListener interface {
+		OnGeneration(*Config, Population, [][]float64)
+	}
+	if l, ok := a.selection.(onGenerationListener); ok {
+		l.OnGeneration(a.config, a.config.Population, a.objectives)
+	}
+	a.result.Crossovers = 0
+	a.result.Evaluations = a.reportInterval
+	if a.evaluations == 0 {
+		a.result.Evaluations += len(a.objectives)
+	}
+	for i := 0; i < a.reportInterval; i++ {
+		job := <-a.done
+		a.evaluations++
+		a.insert(job)
+		a.breed(job)
+		a.jobs <- job
+	}
+	a.summarize()
+	return a.result, nil
+}
+
+// breed fills the job's individual with a new offspring of two selected
+// members. The second child of the crossover is dropped.
+func (a *steadyStateAlgorithm) breed(job *steadyStateJob) {
+	job.parent1 = a.selection.Selection(a.config, a.objectives)
+	job.parent2 = a.selection.Selection(a.config, a.objectives)
+	job.parentObjectives = [2][]float64{a.objectives[job.parent1], a.objectives[job.parent2]}
+	job.crossSite = a.crossover.Crossover(a.config, a.config.Population.Individual(job.parent1),
+		a.config.Population.Individual(job.parent2), job.individual, a.scratch, a.config.CrossoverProbability)
+	if job.crossSite >= 0 {
+		a.result.Crossovers++
+	}
+	a.mutation.Mutation(a.config, job.individual, a.config.MutationProbability)
+}
+
+func (a *steadyStateAlgorithm) insert(job *steadyStateJob) {
+	for _, l := range a.listeners {
+		l.OnOffspring(a.config, job.parentObjectives[0], job.parentObjectives[1], job.individual, job.objective)
+	}
+	i := a.replacement.Replace(a.config, a.objectives, a.births, job.objective)
+	if i < 0 {
+		return
+	}
+	CopyIndividual(a.config.Population.Individual(i), job.individual)
+	a.objectives[i] = job.objective
+	a.births[i] = a.evaluations
+	a.result.Individuals[i].Objective = job.objective
+	a.result.Individuals[i].Parent1 = job.parent1
+	a.result.Individuals[i].Parent2 = job.parent2
+	a.result.Individuals[i].CrossSite = job.crossSite
+}
+
+func (a *steadyStateAlgorithm) summarize() {
+	for j := 0; j < a.config.NumberOfObjectives; j++ {
+		a.result.BestObjective[j] = math.MaxFloat64
+		a.result.WorstObjective[j] = -math.MaxFloat64
+		a.result.AverageObjective[j] = 0
+	}
+	for i, f := range a.objectives {
+		if f[0] < a.result.BestObjective[0] {
+			a.result.BestIndividual = a.config.Population.Individual(i)
+			a.result.BestIndividualIndex = i
+		}
+		for j := 0; j < a.config.NumberOfObjectives; j++ {
+			a.result.BestObjective[j] = math.Min(a.result.BestObjective[j], f[j])
+			a.result.WorstObjective[j] = math.Max(a.result.WorstObjective[j], f[j])
+			a.result.AverageObjective[j] += f[j] / float64(len(a.objectives))
+		}
+		a.result.Individuals[i].Values = RecordValues(a.config, a.config.Population.Individual(i), a.result.Individuals[i].Values)
+	}
+	for _, r := range a.reporters {
+		r.Report(a.result)
+	}
+}
+
+// Finalize waits for the evaluations still in flight, discarding them, and
+// stops the workers.
+func (a *steadyStateAlgorithm) Finalize(result *Result) {
+	close(a.jobs)
+	for i := 0; i < a.workers; i++ {
+		<-a.done
+	}
+	for _, operator := range []interface{}{a.selection, a.mutation, a.crossover, a.replacement} {
+		if f, ok := operator.(finalizer); ok {
+			f.Finalize(a.config, a.config.Population, a.objectives, result)
+		}
+	}
+}
+
+func (r *ReplaceWorst) Replace(config *Config, objectives [][]float64, births []int, offspring []float64) int {
+	worst := 0
+	for i := range objectives {
+		if objectives[i][0] > objectives[worst][0] {
+			worst = i
+		}
+	}
+	if offspring[0] < objectives[worst][0] {
+		return worst
+	}
+	return -1
+}
+
+func (r *ReplaceRandom) Replace(config *Config, objectives [][]float64, births []int, offspring []float64) int {
+	return config.RandomNumberGenerator.Intn(len(objectives))
+}
+
+func (r *ReplaceOldest) Replace(config *Config, objectives [][]float64, births []int, offspring []float64) int {
+	oldest := 0
+	for i := range births {
+		if births[i] < births[oldest] {
+			oldest = i
+		}
+	}
+	return oldest
+}
@@ -0,0 +1,89 @@
+package moea
+
+type TabuSearchOptions struct {
+	// Neighbours is the number of random moves evaluated per iteration,
+	// each mutating one position. It defaults to the length of the
+	// individuals, at most 50.
+	Neighbours int
+	// Tenure is the number of iterations a mutated position stays tabu. It
+	// defaults to 7, less than the length of the individuals.
+	Tenure int
+	// Iterations per generation, 1 by default.
+	Iterations int
+}
+
+type tabuSearch struct {
+	trajectory
+	options   TabuSearchOptions
+	iteration int
+	tabu      [][]int
+	chosen    Individual
+}
+
+// NewTabuSearch returns tabu search over the first objective. Each
+// iteration moves every member of the population to its best neighbour,
+// even if worse, among those not changing a tabu position; a tabu move is
+// still taken when it improves on the best solution found (aspiration).
+func NewTabuSearch(options TabuSearchOptions) Algorithm {
+	if options.Iterations <= 0 {
+		options.Iterations = 1
+	}
+	return &tabuSearch{options: options}
+}
+
+func (a *tabuSearch) Initialize(config *Config) {
+	a.initialize(config)
+	length := config.Population.Individual(0).Len()
+	if a.options.Neighbours <= 0 {
+		a.options.Neighbours = length
+		if a.options.Neighbours > 50 {
+			a.options.Neighbours = 50
+		}
+	}
+	if a.options.Tenure <= 0 {
+		a.options.Tenure = 7
+		if a.options.Tenure >= length {
+			a.options.Tenure = length - 1
+		}
+	}
+	a.iteration = 0
+	a.tabu = make([][]int, config.Population.Len())
+	for i := range a.tabu {
+		a.tabu[i] = make([]int, length)
+	}
+	a.chosen = config.Population.Individual(0).Clone()
+}
+
+func (a *tabuSearch) Generation() (*Result, error) {
+	a.reset()
+	for k := 0; k < a.options.Iterations; k++ {
+		a.iteration++
+		for i := range a.objectives {
+			a.step(i)
+		}
+	}
+	return a.summarize(), nil
+}
+
+func (a *tabuSearch) step(i int) {
+	length := a.config.Population.Individual(i).Len()
+	var chosen []float64
+	position := -1
+	for k := 0; k < a.options.Neighbours; k++ {
+		p := a.config.RandomNumberGenerator.Intn(length)
+		f := a.neighbour(a.candidate, i, p)
+		admissible := p >= len(a.tabu[i]) || a.tabu[i][p] < a.iteration || f[0] < a.bestObjectives[i][0]
+		if admissible && (chosen == nil || f[0] < chosen[0]) {
+			chosen, position = f, p
+			a.chosen, a.candidate = a.candidate, a.chosen
+		}
+	}
+	if chosen == nil {
+		return
+	}
+	a.move(i, a.chosen, chosen)
+	for position >= len(a.tabu[i]) {
+		a.tabu[i] = append(a.tabu[i], 0)
+	}
+	a.tabu[i][position] = a.iteration + a.options.Tenure
+}
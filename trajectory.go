@@ -0,0 +1,98 @@
+package moea
+
+import "math"
+
+// trajectory holds what simulated annealing and tabu search share: every
+// member of the population is the current solution of an independent
+// search, compared on the first objective, and the best solution each
+// search has found is kept apart. With a population of one they are
+// single-solution algorithms.
+type trajectory struct {
+	config         *Config
+	objectives     [][]float64
+	best           []Individual
+	bestObjectives [][]float64
+	candidate      Individual
+	generation     int
+	result         *Result
+}
+
+func (t *trajectory) initialize(config *Config) {
+	t.config = config
+	n := config.Population.Len()
+	t.objectives = make([][]float64, n)
+	t.best = make([]Individual, n)
+	t.bestObjectives = make([][]float64, n)
+	for i := 0; i < n; i++ {
+		t.objectives[i] = config.ObjectiveFunc(config.Population.Individual(i))
+		t.best[i] = config.Population.Individual(i).Clone()
+		t.bestObjectives[i] = t.objectives[i]
+	}
+	t.candidate = config.Population.Individual(0).Clone()
+	t.generation = 0
+	t.result = &Result{
+		Individuals:      make([]IndividualResult, n),
+		AverageObjective: make([]float64, config.NumberOfObjectives),
+		WorstObjective:   make([]float64, config.NumberOfObjectives),
+		BestObjective:    make([]float64, config.NumberOfObjectives),
+		Evaluations:      n,
+	}
+}
+
+// neighbour makes candidate a copy of the i-th solution mutated at
+// position, and evaluates it.
+func (t *trajectory) neighbour(candidate Individual, i, position int) []float64 {
+	current := t.config.Population.Individual(i)
+	CopyIndividual(candidate, current)
+	candidate.Mutate([]int{position})
+	t.result.Mutations++
+	t.result.Evaluations++
+	return t.config.ObjectiveFunc(candidate)
+}
+
+// move makes candidate, with objective f, the i-th solution.
+func (t *trajectory) move(i int, candidate Individual, f []float64) {
+	CopyIndividual(t.config.Population.Individual(i), candidate)
+	t.objectives[i] = f
+	if f[0] < t.bestObjectives[i][0] {
+		CopyIndividual(t.best[i], candidate)
+		t.bestObjectives[i] = f
+	}
+}
+
+// summarize reports the current solutions, except for the best individual
+// and objectives, which are the best found so far.
+func (t *trajectory) summarize() *Result {
+	for j := 0; j < t.config.NumberOfObjectives; j++ {
+		t.result.BestObjective[j] = math.MaxFloat64
+		t.result.WorstObjective[j] = -math.MaxFloat64
+		t.result.AverageObjective[j] = 0
+	}
+	for i, f := range t.objectives {
+		if t.bestObjectives[i][0] < t.result.BestObjective[0] {
+			t.result.BestIndividual = t.best[i]
+			t.result.BestIndividualIndex = i
+		}
+		for j := 0; j < t.config.NumberOfObjectives; j++ {
+			t.result.BestObjective[j] = math.Min(t.result.BestObjective[j], t.bestObjectives[i][j])
+			t.result.WorstObjective[j] = math.Max(t.result.WorstObjective[j], f[j])
+			t.result.AverageObjective[j] += f[j] / float64(len(t.objectives))
+		}
+		t.result.Individuals[i].Objective = f
+		t.result.Individuals[i].Parent1 = -1
+		t.result.Individuals[i].Parent2 = -1
+		t.result.Individuals[i].CrossSite = -1
+		t.result.Individuals[i].Values = RecordValues(t.config, t.config.Population.Individual(i), t.result.Individuals[i].Values)
+	}
+	return t.result
+}
+
+// reset clears the counters of the result of the previous generation; the
+// first one keeps the evaluations made by Initialize.
+func (t *trajectory) reset() {
+	t.result.Mutations = 0
+	if t.generation > 0 {
+		t.result.Evaluations = 0
+	}
+	t.generation++
+}
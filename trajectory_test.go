@@ -0,0 +1,109 @@
+package moea
+
+import (
+	"math"
+	"testing"
+)
+
+func trajectoryConfig(algorithm Algorithm, seed uint32) (*Config, *int) {
+	rng := NewXorshiftWithSeed(seed)
+	evaluations := 0
+	return &Config{
+		Algorithm:          algorithm,
+		Population:         NewRandomBooleanPopulationWithRNG(1, []int{60}, rng),
+		NumberOfValues:     1,
+		NumberOfObjectives: 1,
+		ObjectiveFunc: func(individual Individual) []float64 {
+			evaluations++
+			return countOnes(individual)
+		},
+		MaxGenerations:        100,
+		RandomNumberGenerator: rng,
+	}, &evaluations
+}
+
+func TestSimulatedAnnealing(t *testing.T) {
+	for _, cooling := range []Cooling{&GeometricCooling{0.9}, &AdaptiveCooling{}} {
+		config, evaluations := trajectoryConfig(NewSimulatedAnnealing(SimulatedAnnealingOptions{Cooling: cooling}), 1)
+		result, err := Run(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.BestObjective[0] != -60 || countOnes(result.BestIndividual)[0] != -60 {
+			t.Errorf("%T: expected the optimum but was %v", cooling, result.BestObjective)
+		}
+		if result.Evaluations != *evaluations {
+			t.Errorf("%T: counted %v evaluations but made %v", cooling, result.Evaluations, *evaluations)
+		}
+		if result.Mutations != 100*60 {
+			t.Errorf("%T: expected %v moves but were %v", cooling, 100*60, result.Mutations)
+		}
+	}
+}
+
+func TestReheating(t *testing.T) {
+	algorithm := NewSimulatedAnnealing(SimulatedAnnealingOptions{InitialTemperature: 10, ReheatAfter: 3}).(*simulatedAnnealing)
+	config, _ := trajectoryConfig(algorithm, 2)
+	config.MaxGenerations = 0
+	algorithm.Initialize(config)
+	reheated := false
+	for i := 0; i < 200 && !reheated; i++ {
+		before := algorithm.temperature
+		if _, err := algorithm.Generation(); err != nil {
+			t.Fatal(err)
+		}
+		reheated = algorithm.temperature == 5 && before != 5
+	}
+	if !reheated {
+		t.Error("expected the temperature to be raised to half the initial one")
+	}
+}
+
+func TestAdaptiveCooling(t *testing.T) {
+	c := &AdaptiveCooling{Alpha: 0.8, Target: 0.5}
+	for _, test := range []struct{ acceptance, temperature float64 }{{0.9, 8}, {0.5, 8}, {0.25, 9}, {0, 9.8}} {
+		if got := c.Cool(10, test.acceptance); math.Abs(got-test.temperature) > 1e-12 {
+			t.Errorf("expected %v at acceptance %v but was %v", test.temperature, test.acceptance, got)
+		}
+	}
+}
+
+func TestTabuSearch(t *testing.T) {
+	config, evaluations := trajectoryConfig(NewTabuSearch(TabuSearchOptions{}), 3)
+	config.MaxGenerations = 200
+	result, err := Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.BestObjective[0] != -60 {
+		t.Errorf("expected the optimum but was %v", result.BestObjective)
+	}
+	if result.Evaluations != *evaluations || *evaluations != 1+200*50 {
+		t.Errorf("counted %v evaluations but made %v", result.Evaluations, *evaluations)
+	}
+}
+
+func TestTabuPositions(t *testing.T) {
+	algorithm := NewTabuSearch(TabuSearchOptions{Neighbours: 60, Tenure: 5}).(*tabuSearch)
+	config, _ := trajectoryConfig(algorithm, 4)
+	algorithm.Initialize(config)
+	previous := append([]bool(nil), config.Population.Individual(0).Value(0).([]bool)...)
+	var changed []int
+	for i := 0; i < 50; i++ {
+		algorithm.Generation()
+		current := config.Population.Individual(0).Value(0).([]bool)
+		for j := range current {
+			if current[j] != previous[j] {
+				changed = append(changed, j)
+			}
+		}
+		copy(previous, current)
+	}
+	for i := range changed {
+		for j := i + 1; j < len(changed) && j <= i+5; j++ {
+			if changed[i] == changed[j] && algorithm.bestObjectives[0][0] != -60 {
+				t.Errorf("position %d changed again within the tenure: %v", changed[i], changed)
+			}
+		}
+	}
+}
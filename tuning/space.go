@@ -0,0 +1,178 @@
+package tuning
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/project-draco/moea"
+)
+
+type ParameterType int
+
+const (
+	Integer ParameterType = iota
+	Real
+	Categorical
+)
+
+type Parameter struct {
+	Name string
+	Type ParameterType
+	// Min and Max bound Integer and Real parameters, both inclusive.
+	Min, Max float64
+	// Values are the choices of a Categorical parameter.
+	Values []string
+}
+
+// Configuration maps parameter names to values: int for Integer parameters,
+// float64 for Real ones and string for Categorical ones.
+type Configuration map[string]interface{}
+
+func IntegerParameter(name string, min, max int) Parameter {
+	return Parameter{Name: name, Type: Integer, Min: float64(min), Max: float64(max)}
+}
+
+func RealParameter(name string, min, max float64) Parameter {
+	return Parameter{Name: name, Type: Real, Min: min, Max: max}
+}
+
+func CategoricalParameter(name string, values ...string) Parameter {
+	return Parameter{Name: name, Type: Categorical, Values: values}
+}
+
+func (c Configuration) Int(name string) int { return c[name].(int) }
+
+func (c Configuration) Float(name string) float64 { return c[name].(float64) }
+
+func (c Configuration) String(name string) string { return c[name].(string) }
+
+// key identifies a configuration, so that duplicates are not raced twice.
+func (c Configuration) key() string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s=%v;", name, c[name])
+	}
+	return b.String()
+}
+
+func validate(parameters []Parameter) error {
+	if len(parameters) == 0 {
+		return fmt.Errorf("no parameters to tune")
+	}
+	names := map[string]bool{}
+	for _, p := range parameters {
+		if names[p.Name] {
+			return fmt.Errorf("duplicate parameter %q", p.Name)
+		}
+		names[p.Name] = true
+		switch p.Type {
+		case Integer, Real:
+			if p.Min > p.Max {
+				return fmt.Errorf("parameter %q: min greater than max", p.Name)
+			}
+		case Categorical:
+			if len(p.Values) == 0 {
+				return fmt.Errorf("parameter %q: no values", p.Name)
+			}
+		default:
+			return fmt.Errorf("parameter %q: unknown type %d", p.Name, p.Type)
+		}
+	}
+	return nil
+}
+
+// model is the sampling distribution attached to an elite configuration:
+// a normal distribution around its value for numerical parameters, with a
+// standard deviation shrinking over the iterations, and a probability
+// vector for categorical ones.
+type model struct {
+	probabilities map[string][]float64
+}
+
+func uniformModel(parameters []Parameter) *model {
+	m := &model{map[string][]float64{}}
+	for _, p := range parameters {
+		if p.Type == Categorical {
+			m.probabilities[p.Name] = make([]float64, len(p.Values))
+			for i := range p.Values {
+				m.probabilities[p.Name][i] = 1 / float64(len(p.Values))
+			}
+		}
+	}
+	return m
+}
+
+func sampleUniform(parameters []Parameter, rng moea.RNG) Configuration {
+	c := Configuration{}
+	for _, p := range parameters {
+		switch p.Type {
+		case Integer:
+			c[p.Name] = int(p.Min) + rng.Intn(int(p.Max)-int(p.Min)+1)
+		case Real:
+			c[p.Name] = p.Min + rng.Float64()*(p.Max-p.Min)
+		case Categorical:
+			c[p.Name] = p.Values[rng.Intn(len(p.Values))]
+		}
+	}
+	return c
+}
+
+// sampleAround draws a configuration from the model of parent, with the
+// standard deviation of numerical parameters being their range times
+// (1/n)^(iteration/parameters), as in irace.
+func sampleAround(parameters []Parameter, parent Configuration, m *model, iteration, n int, rng moea.RNG) Configuration {
+	c := Configuration{}
+	shrink := math.Pow(1/float64(n), float64(iteration)/float64(len(parameters)))
+	for _, p := range parameters {
+		switch p.Type {
+		case Integer:
+			// sample on [min, max+1) and truncate, so the bounds are as
+			// likely as the other values
+			v := float64(parent.Int(p.Name)) + 0.5 + rng.NormFloat64()*(p.Max+1-p.Min)*shrink
+			v = math.Max(p.Min, math.Min(p.Max, math.Floor(v)))
+			c[p.Name] = int(v)
+		case Real:
+			v := parent.Float(p.Name) + rng.NormFloat64()*(p.Max-p.Min)*shrink
+			c[p.Name] = math.Max(p.Min, math.Min(p.Max, v))
+		case Categorical:
+			probabilities := m.probabilities[p.Name]
+			r := rng.Float64()
+			c[p.Name] = p.Values[len(p.Values)-1]
+			for i, probability := range probabilities {
+				if r < probability {
+					c[p.Name] = p.Values[i]
+					break
+				}
+				r -= probability
+			}
+		}
+	}
+	return c
+}
+
+// update moves the categorical probabilities of the model of an elite
+// towards its own values, by iteration/iterations.
+func (m *model) update(parameters []Parameter, elite Configuration, iteration, iterations int) *model {
+	result := &model{map[string][]float64{}}
+	weight := float64(iteration) / float64(iterations)
+	for _, p := range parameters {
+		if p.Type != Categorical {
+			continue
+		}
+		result.probabilities[p.Name] = make([]float64, len(p.Values))
+		for i, v := range p.Values {
+			result.probabilities[p.Name][i] = m.probabilities[p.Name][i] * (1 - weight)
+			if v == elite.String(p.Name) {
+				result.probabilities[p.Name][i] += weight
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,346 @@
+// Package tuning implements iterated racing (irace) for algorithm
+// configurations: configurations are sampled, raced on a stream of training
+// instances, eliminated with Friedman tests, and the surviving elites bias
+// the sampling of the next iteration.
+package tuning
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/project-draco/moea"
+	"github.com/project-draco/moea/stats"
+)
+
+type Instance struct {
+	Name string
+	// Data is handed back to Config and Cost, e.g. a *benchmark.Problem.
+	Data interface{}
+}
+
+type Tuner struct {
+	Parameters []Parameter
+	Instances  []Instance
+	// Config returns a fresh configuration running configuration on instance.
+	Config func(configuration Configuration, instance *Instance, seed uint32) *moea.Config
+	// Cost is minimised; it defaults to the best first objective.
+	Cost func(result *moea.Result, instance *Instance) float64
+	// Budget is the maximum number of calls to moea.Run.
+	Budget int
+	// Seed seeds the sampling; the i-th instance of the stream runs with
+	// seed Seed+i.
+	Seed uint32
+	// Iterations defaults to 2+log2(len(Parameters)).
+	Iterations int
+	// Elites is the number of configurations a race stops at and carries to
+	// the next iteration. It defaults to 2+log2(len(Parameters)).
+	Elites int
+	// FirstTest is the number of instances every configuration of a race
+	// runs before the first test (5 by default).
+	FirstTest int
+	// Significance of the Friedman tests, 0.05 by default.
+	Significance float64
+	// Initial configurations are raced in the first iteration along with
+	// the sampled ones.
+	Initial []Configuration
+	// Workers defaults to GOMAXPROCS.
+	Workers int
+}
+
+type Elite struct {
+	Configuration Configuration
+	// MeanCost is the mean cost on the Instances the elite ran.
+	MeanCost  float64
+	Instances int
+}
+
+type Result struct {
+	// Elites are ordered from best to worst.
+	Elites      []Elite
+	Evaluations int
+	Iterations  int
+}
+
+type candidate struct {
+	configuration Configuration
+	model         *model
+	costs         []float64
+	alive         bool
+}
+
+type streamEntry struct {
+	instance int
+	seed     uint32
+}
+
+type tuning struct {
+	*Tuner
+	rng         moea.RNG
+	stream      []streamEntry
+	evaluations int
+	workers     int
+}
+
+// Tune runs iterated racing until the budget is spent.
+func (t *Tuner) Tune() (*Result, error) {
+	if err := validate(t.Parameters); err != nil {
+		return nil, err
+	}
+	if len(t.Instances) == 0 {
+		return nil, fmt.Errorf("no training instances")
+	}
+	if t.Config == nil {
+		return nil, fmt.Errorf("no Config function")
+	}
+	tu := &tuning{Tuner: t, rng: moea.NewXorshiftWithSeed(t.Seed), workers: t.Workers}
+	if tu.workers <= 0 {
+		tu.workers = runtime.GOMAXPROCS(0)
+	}
+	logParameters := int(math.Log2(float64(len(t.Parameters))))
+	iterations, elites, firstTest := t.Iterations, t.Elites, t.FirstTest
+	if iterations <= 0 {
+		iterations = 2 + logParameters
+	}
+	if elites <= 0 {
+		elites = 2 + logParameters
+	}
+	if firstTest <= 0 {
+		firstTest = 5
+	}
+	if t.Budget < firstTest*(elites+1) {
+		return nil, fmt.Errorf("budget %d too small for %d elites and %d instances before the first test",
+			t.Budget, elites, firstTest)
+	}
+	var best []*candidate
+	result := &Result{}
+	for iteration := 1; iteration <= iterations && t.Budget-tu.evaluations >= firstTest*(elites+1); iteration++ {
+		budget := (t.Budget - tu.evaluations) / (iterations - iteration + 1)
+		n := budget / (firstTest + min(5, iteration))
+		if n < elites+1 {
+			n = elites + 1
+		}
+		candidates := append([]*candidate{}, best...)
+		seen := map[string]bool{}
+		for _, c := range candidates {
+			seen[c.configuration.key()] = true
+		}
+		if iteration == 1 {
+			for _, c := range t.Initial {
+				if !seen[c.key()] && len(candidates) < n {
+					seen[c.key()] = true
+					candidates = append(candidates, &candidate{configuration: c, model: uniformModel(t.Parameters)})
+				}
+			}
+		}
+		for attempts := 0; len(candidates) < n && attempts < 100*n; attempts++ {
+			c := tu.sample(best, iteration, n, iterations)
+			if !seen[c.configuration.key()] {
+				seen[c.configuration.key()] = true
+				candidates = append(candidates, c)
+			}
+		}
+		if err := tu.race(candidates, budget, elites, firstTest); err != nil {
+			return nil, err
+		}
+		best = survivors(candidates, elites)
+		result.Iterations = iteration
+	}
+	for _, c := range best {
+		result.Elites = append(result.Elites, Elite{c.configuration, stats.Mean(c.costs), len(c.costs)})
+	}
+	result.Evaluations = tu.evaluations
+	return result, nil
+}
+
+// sample draws a new candidate, uniformly in the first iteration and
+// otherwise around an elite chosen with probability decreasing linearly
+// with its rank.
+func (tu *tuning) sample(elites []*candidate, iteration, n, iterations int) *candidate {
+	if len(elites) == 0 {
+		return &candidate{configuration: sampleUniform(tu.Parameters, tu.rng), model: uniformModel(tu.Parameters)}
+	}
+	k := len(elites)
+	r := tu.rng.Float64() * float64(k*(k+1)/2)
+	parent := elites[k-1]
+	for i, e := range elites {
+		r -= float64(k - i)
+		if r < 0 {
+			parent = e
+			break
+		}
+	}
+	m := parent.model.update(tu.Parameters, parent.configuration, iteration-1, iterations)
+	parent.model = m
+	return &candidate{
+		configuration: sampleAround(tu.Parameters, parent.configuration, m, iteration-1, n, tu.rng),
+		model:         m,
+	}
+}
+
+// instance returns the i-th entry of the instance stream, which goes
+// through the instances in a new random order on every pass.
+func (tu *tuning) instance(i int) streamEntry {
+	for len(tu.stream) <= i {
+		for _, j := range tu.rng.Perm(len(tu.Instances)) {
+			tu.stream = append(tu.stream, streamEntry{j, tu.Seed + uint32(len(tu.stream))})
+		}
+	}
+	return tu.stream[i]
+}
+
+// race evaluates the alive candidates instance by instance, eliminating
+// those significantly worse than the best after every instance from
+// firstTest on, until elites remain or the budget runs out. Elites of the
+// previous iteration already ran the first instances of the stream.
+func (tu *tuning) race(candidates []*candidate, budget, elites, firstTest int) error {
+	for _, c := range candidates {
+		c.alive = true
+	}
+	spent := 0
+	for i := 0; ; i++ {
+		alive := aliveCandidates(candidates)
+		var pending []*candidate
+		for _, c := range alive {
+			if len(c.costs) <= i {
+				pending = append(pending, c)
+			}
+		}
+		if spent+len(pending) > budget || tu.evaluations+len(pending) > tu.Budget {
+			return nil
+		}
+		if err := tu.evaluate(pending, i); err != nil {
+			return err
+		}
+		spent += len(pending)
+		if i+1 < firstTest {
+			continue
+		}
+		blocks := make([][]float64, i+1)
+		for b := range blocks {
+			blocks[b] = make([]float64, len(alive))
+			for j, c := range alive {
+				blocks[b][j] = c.costs[b]
+			}
+		}
+		_, p, sums := stats.Friedman(blocks)
+		if p < tu.significance() {
+			best := 0
+			for j := range sums {
+				if sums[j] < sums[best] {
+					best = j
+				}
+			}
+			for j, q := range stats.FriedmanPostHoc(blocks, sums, best) {
+				if q < tu.significance() {
+					alive[j].alive = false
+				}
+			}
+		}
+		if len(aliveCandidates(candidates)) <= elites {
+			return nil
+		}
+	}
+}
+
+func (tu *tuning) significance() float64 {
+	if tu.Significance <= 0 {
+		return 0.05
+	}
+	return tu.Significance
+}
+
+// evaluate runs the candidates on the i-th instance of the stream.
+func (tu *tuning) evaluate(candidates []*candidate, i int) error {
+	entry := tu.instance(i)
+	instance := &tu.Instances[entry.instance]
+	costs := make([]float64, len(candidates))
+	errs := make([]error, len(candidates))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < tu.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				result, err := moea.Run(tu.Config(candidates[j].configuration, instance, entry.seed))
+				if err != nil {
+					errs[j] = fmt.Errorf("%v on %s: %v", candidates[j].configuration, instance.Name, err)
+					continue
+				}
+				if tu.Cost != nil {
+					costs[j] = tu.Cost(result, instance)
+				} else {
+					costs[j] = result.BestObjective[0]
+				}
+			}
+		}()
+	}
+	for j := range candidates {
+		jobs <- j
+	}
+	close(jobs)
+	wg.Wait()
+	tu.evaluations += len(candidates)
+	for j, c := range candidates {
+		if errs[j] != nil {
+			return errs[j]
+		}
+		c.costs = append(c.costs, costs[j])
+	}
+	return nil
+}
+
+func aliveCandidates(candidates []*candidate) []*candidate {
+	var result []*candidate
+	for _, c := range candidates {
+		if c.alive {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// survivors returns up to n alive candidates by increasing mean rank on the
+// instances all of them ran.
+func survivors(candidates []*candidate, n int) []*candidate {
+	alive := aliveCandidates(candidates)
+	common := len(alive[0].costs)
+	for _, c := range alive {
+		if len(c.costs) < common {
+			common = len(c.costs)
+		}
+	}
+	rankSums := make([]float64, len(alive))
+	block := make([]float64, len(alive))
+	for b := 0; b < common; b++ {
+		for j, c := range alive {
+			block[j] = c.costs[b]
+		}
+		for j, r := range stats.Ranks(block) {
+			rankSums[j] += r
+		}
+	}
+	indexes := make([]int, len(alive))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return rankSums[indexes[i]] < rankSums[indexes[j]] })
+	if n > len(alive) {
+		n = len(alive)
+	}
+	result := make([]*candidate, n)
+	for i := range result {
+		result[i] = alive[indexes[i]]
+	}
+	return result
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
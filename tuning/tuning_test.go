@@ -0,0 +1,82 @@
+package tuning
+
+import (
+	"math"
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+// constantAlgorithm reports a fixed objective in every generation.
+type constantAlgorithm struct {
+	objective float64
+	best      moea.Individual
+}
+
+func (a *constantAlgorithm) Initialize(config *moea.Config) { a.best = config.Population.Individual(0) }
+
+func (a *constantAlgorithm) Generation() (*moea.Result, error) {
+	return &moea.Result{BestIndividual: a.best, BestObjective: []float64{a.objective}}, nil
+}
+
+func TestTune(t *testing.T) {
+	tuner := &Tuner{
+		Parameters: []Parameter{
+			RealParameter("x", 0, 10),
+			IntegerParameter("n", 1, 5),
+			CategoricalParameter("c", "a", "b", "c"),
+		},
+		Instances: []Instance{{Name: "shifted", Data: 0.0}, {Name: "scaled", Data: 1.0}},
+		Config: func(c Configuration, instance *Instance, seed uint32) *moea.Config {
+			rng := moea.NewXorshiftWithSeed(seed)
+			cost := (c.Float("x")-3)*(c.Float("x")-3) + math.Abs(float64(c.Int("n")-2)) + rng.Float64()
+			if c.String("c") != "a" {
+				cost += 5
+			}
+			cost *= 1 + instance.Data.(float64)
+			return &moea.Config{
+				Algorithm:          &constantAlgorithm{objective: cost},
+				Population:         moea.NewRandomBooleanPopulationWithRNG(2, []int{1}, rng),
+				NumberOfObjectives: 1,
+				MaxGenerations:     1,
+			}
+		},
+		Budget: 600,
+		Seed:   1,
+	}
+	result, err := tuner.Tune()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Evaluations > 600 || result.Iterations < 2 || len(result.Elites) == 0 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	best := result.Elites[0].Configuration
+	if math.Abs(best.Float("x")-3) > 1 || best.Int("n") != 2 || best.String("c") != "a" {
+		t.Errorf("unexpected best configuration %v", best)
+	}
+	for i := 1; i < len(result.Elites); i++ {
+		if result.Elites[i].Instances > result.Elites[0].Instances {
+			t.Errorf("elites are not ordered: %+v", result.Elites)
+		}
+	}
+	again, _ := tuner.Tune()
+	if again.Elites[0].Configuration.key() != best.key() {
+		t.Errorf("same seed gave %v and %v", best, again.Elites[0].Configuration)
+	}
+}
+
+func TestTuneErrors(t *testing.T) {
+	config := func(Configuration, *Instance, uint32) *moea.Config { return nil }
+	for _, tuner := range []*Tuner{
+		{Instances: []Instance{{}}, Config: config, Budget: 100},
+		{Parameters: []Parameter{RealParameter("x", 1, 0)}, Instances: []Instance{{}}, Config: config, Budget: 100},
+		{Parameters: []Parameter{CategoricalParameter("c")}, Instances: []Instance{{}}, Config: config, Budget: 100},
+		{Parameters: []Parameter{RealParameter("x", 0, 1)}, Config: config, Budget: 100},
+		{Parameters: []Parameter{RealParameter("x", 0, 1)}, Instances: []Instance{{}}, Config: config, Budget: 5},
+	} {
+		if _, err := tuner.Tune(); err == nil {
+			t.Errorf("expected an error for %+v", tuner)
+		}
+	}
+}
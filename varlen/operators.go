@@ -0,0 +1,97 @@
+package varlen
+
+import (
+	"math"
+
+	"github.com/project-draco/moea"
+)
+
+// CutAndSpliceCrossover cuts each parent at its own random site and splices
+// the head of each to the tail of the other, so the children may differ in
+// length from both parents. The second site is drawn so that both children
+// respect the length bounds. It returns the site of the first parent.
+type CutAndSpliceCrossover struct{}
+
+// LengthMutation applies Operator to the genes and then inserts a new gene
+// at a random position with probability Insertion and deletes a random gene
+// with probability Deletion, within the length bounds. When both are zero
+// each one defaults to the mutation probability times the length, capped at
+// 1, i.e. as likely as a gene mutation.
+type LengthMutation struct {
+	Insertion, Deletion float64
+	// Operator defaults to moea.RegularMutation.
+	Operator moea.MutationOperator
+}
+
+func (c *CutAndSpliceCrossover) Crossover(config *moea.Config, parent1, parent2, child1, child2 moea.Individual,
+	probability float64) int {
+	if !config.RandomNumberGenerator.Flip(probability) {
+		moea.CopyIndividual(child1, parent1)
+		moea.CopyIndividual(child2, parent2)
+		return -1
+	}
+	p1, p2 := parent1.(*individual), parent2.(*individual)
+	min, max := p1.options.MinLength, p1.options.MaxLength
+	l1, l2 := len(p1.genes), len(p2.genes)
+	site1 := config.RandomNumberGenerator.Intn(l1 + 1)
+	// child1 has site1+l2-site2 genes and child2 site2+l1-site1
+	low := maxInt(0, maxInt(site1+l2-max, min-l1+site1))
+	high := minInt(l2, minInt(site1+l2-min, max-l1+site1))
+	site2 := low + config.RandomNumberGenerator.Intn(high-low+1)
+	head1, head2 := p1.genes[:site1], p2.genes[:site2]
+	tail1, tail2 := p1.genes[site1:], p2.genes[site2:]
+	c1, c2 := child1.(*individual), child2.(*individual)
+	c1.splice(head1, tail2)
+	c2.splice(head2, tail1)
+	return site1
+}
+
+func (m *LengthMutation) Initialize(config *moea.Config) {
+	if m.Operator == nil {
+		m.Operator = &moea.RegularMutation{}
+	}
+	if i, ok := m.Operator.(interface{ Initialize(*moea.Config) }); ok {
+		i.Initialize(config)
+	}
+}
+
+func (m *LengthMutation) Mutation(config *moea.Config, mutated moea.Individual, probability float64) {
+	m.Operator.Mutation(config, mutated, probability)
+	ind := mutated.(*individual)
+	insertion, deletion := m.Insertion, m.Deletion
+	if insertion == 0 && deletion == 0 {
+		insertion = math.Min(1, probability*float64(len(ind.genes)))
+		deletion = insertion
+	}
+	rng := config.RandomNumberGenerator
+	if len(ind.genes) < ind.options.MaxLength && rng.Flip(insertion) {
+		ind.insert(rng.Intn(len(ind.genes)+1), ind.options.Gene(ind.rng))
+	}
+	if len(ind.genes) > ind.options.MinLength && rng.Flip(deletion) {
+		ind.delete(rng.Intn(len(ind.genes)))
+	}
+}
+
+func (m *LengthMutation) Finalize(config *moea.Config, population moea.Population, objectives [][]float64,
+	result *moea.Result) {
+	type finalizer interface {
+		Finalize(*moea.Config, moea.Population, [][]float64, *moea.Result)
+	}
+	if f, ok := m.Operator.(finalizer); ok {
+		f.Finalize(config, population, objectives, result)
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,125 @@
+// Package varlen encodes chromosomes whose length varies between
+// individuals, such as feature subset lists or rule sets. Each position
+// holds a gene of any type, produced by a user supplied generator.
+//
+// Copy(other, start, end) writes the genes of other in [start, end) at the
+// same positions and never changes the length of the receiver, which
+// Resize sets; moea.CopyIndividual and the crossovers of package moea
+// resize the individuals they fill. Set NumberOfValues to the maximum
+// length to record every gene in the results.
+package varlen
+
+import (
+	"fmt"
+
+	"github.com/project-draco/moea"
+)
+
+type Options struct {
+	// MinLength and MaxLength bound the number of genes, both inclusive.
+	// MinLength must be at least 1.
+	MinLength, MaxLength int
+	// Gene returns a new random gene.
+	Gene func(rng moea.RNG) interface{}
+	// MutateGene returns a mutated copy of gene. It defaults to a new gene
+	// from Gene.
+	MutateGene func(gene interface{}, rng moea.RNG) interface{}
+}
+
+type population []*individual
+
+type individual struct {
+	genes   []interface{}
+	options *Options
+	rng     moea.RNG
+}
+
+// NewRandomVariableLengthPopulation returns size individuals, rounded up to
+// an even number, with lengths drawn uniformly within the bounds.
+func NewRandomVariableLengthPopulation(size int, options Options, rng moea.RNG) moea.Population {
+	if options.MinLength < 1 || options.MinLength > options.MaxLength {
+		panic(fmt.Sprintf("Invalid length bounds [%d, %d]", options.MinLength, options.MaxLength))
+	}
+	if options.Gene == nil {
+		panic("No gene generator")
+	}
+	if size%2 == 1 {
+		size++
+	}
+	result := make(population, size)
+	for i := range result {
+		length := options.MinLength + rng.Intn(options.MaxLength-options.MinLength+1)
+		result[i] = &individual{make([]interface{}, length), &options, rng}
+		for j := range result[i].genes {
+			result[i].genes[j] = options.Gene(rng)
+		}
+	}
+	return result
+}
+
+// Genes returns the genes of a variable-length individual. The slice is
+// owned by the individual.
+func Genes(ind moea.Individual) []interface{} {
+	return ind.(*individual).genes
+}
+
+func (p population) Len() int { return len(p) }
+
+func (p population) Individual(i int) moea.Individual { return p[i] }
+
+func (p population) Clone() moea.Population {
+	result := make(population, len(p))
+	for i, individual := range p {
+		result[i] = individual.clone()
+	}
+	return result
+}
+
+func (ind *individual) Len() int { return len(ind.genes) }
+
+func (ind *individual) Value(i int) interface{} { return ind.genes[i] }
+
+func (ind *individual) Copy(other moea.Individual, start, end int) {
+	copy(ind.genes[start:end], other.(*individual).genes[start:end])
+}
+
+// Resize sets the number of genes to n, keeping the first ones. The new
+// genes are nil until copied.
+func (ind *individual) Resize(n int) {
+	for len(ind.genes) < n {
+		ind.genes = append(ind.genes, nil)
+	}
+	ind.genes = ind.genes[:n]
+}
+
+func (ind *individual) Mutate(mutations []int) {
+	for _, m := range mutations {
+		if ind.options.MutateGene != nil {
+			ind.genes[m] = ind.options.MutateGene(ind.genes[m], ind.rng)
+		} else {
+			ind.genes[m] = ind.options.Gene(ind.rng)
+		}
+	}
+}
+
+func (ind *individual) Clone() moea.Individual { return ind.clone() }
+
+func (ind *individual) clone() *individual {
+	return &individual{append([]interface{}(nil), ind.genes...), ind.options, ind.rng}
+}
+
+// splice sets the genes of ind to head followed by tail.
+func (ind *individual) splice(head, tail []interface{}) {
+	genes := make([]interface{}, 0, len(head)+len(tail))
+	ind.genes = append(append(genes, head...), tail...)
+}
+
+func (ind *individual) insert(i int, gene interface{}) {
+	ind.genes = append(ind.genes, nil)
+	copy(ind.genes[i+1:], ind.genes[i:])
+	ind.genes[i] = gene
+}
+
+func (ind *individual) delete(i int) {
+	ind.genes = append(ind.genes[:i], ind.genes[i+1:]...)
+}
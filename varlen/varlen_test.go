@@ -0,0 +1,175 @@
+package varlen
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/project-draco/moea"
+)
+
+var options = Options{
+	MinLength: 2,
+	MaxLength: 12,
+	Gene:      func(rng moea.RNG) interface{} { return rng.Intn(20) },
+}
+
+func sortedGenes(individuals ...moea.Individual) []int {
+	var result []int
+	for _, ind := range individuals {
+		for _, g := range Genes(ind) {
+			result = append(result, g.(int))
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCopy(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(1)
+	population := NewRandomVariableLengthPopulation(2, Options{MinLength: 3, MaxLength: 3, Gene: options.Gene}, rng)
+	short, long := population.Individual(0), population.Individual(1)
+	long.(*individual).genes = append(Genes(long), 100, 101)
+	short.Copy(long, 2, short.Len())
+	if short.Len() != 3 || short.Value(2) != long.Value(2) {
+		t.Errorf("a copy up to the end of %v changed the length of %v", Genes(long), Genes(short))
+	}
+	moea.CopyIndividual(short, long)
+	if short.Len() != 5 || short.Value(4) != 101 {
+		t.Errorf("expected a copy of %v in %v", Genes(long), Genes(short))
+	}
+	short.(*individual).Resize(3)
+	long.Copy(short, 0, 3)
+	if long.Len() != 5 {
+		t.Errorf("a partial copy changed the length to %v", long.Len())
+	}
+	clone := long.Clone()
+	moea.CopyIndividual(short, long)
+	clone.Mutate([]int{0, 1, 2, 3, 4})
+	if !equal(sortedGenes(long), sortedGenes(short)) {
+		t.Error("clone is not independent")
+	}
+}
+
+func TestCutAndSpliceCrossover(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(2)
+	population := NewRandomVariableLengthPopulation(4, options, rng)
+	config := &moea.Config{Population: population, RandomNumberGenerator: rng}
+	p1, p2, c1, c2 := population.Individual(0), population.Individual(1), population.Individual(2), population.Individual(3)
+	lengths := map[int]bool{}
+	for n := 0; n < 1000; n++ {
+		if (&CutAndSpliceCrossover{}).Crossover(config, p1, p2, c1, c2, 1) < 0 {
+			t.Fatal("expected crossover")
+		}
+		for _, c := range []moea.Individual{c1, c2} {
+			if c.Len() < options.MinLength || c.Len() > options.MaxLength {
+				t.Fatalf("length %v out of bounds", c.Len())
+			}
+			lengths[c.Len()] = true
+		}
+		if !equal(sortedGenes(p1, p2), sortedGenes(c1, c2)) {
+			t.Fatalf("genes of %v and %v not conserved in %v and %v", Genes(p1), Genes(p2), Genes(c1), Genes(c2))
+		}
+		p1, c1 = c1, p1
+		p2, c2 = c2, p2
+	}
+	if len(lengths) < 3 {
+		t.Errorf("expected lengths to vary but got %v", lengths)
+	}
+}
+
+func TestFixedSiteCrossovers(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(3)
+	population := NewRandomVariableLengthPopulation(4, options, rng)
+	config := &moea.Config{Population: population, RandomNumberGenerator: rng}
+	p1, p2, c1, c2 := population.Individual(0), population.Individual(1), population.Individual(2), population.Individual(3)
+	for _, operator := range []moea.CrossoverOperator{&moea.OnePointCrossover{}, &moea.TwoPointCrossover{},
+		&moea.UniformCrossover{}} {
+		for n := 0; n < 100; n++ {
+			operator.Crossover(config, p1, p2, c1, c2, 1)
+			if c1.Len()+c2.Len() != p1.Len()+p2.Len() || !equal(sortedGenes(p1, p2), sortedGenes(c1, c2)) {
+				t.Fatalf("%T: genes of %v and %v not conserved in %v and %v", operator, Genes(p1), Genes(p2), Genes(c1), Genes(c2))
+			}
+		}
+	}
+}
+
+func TestLengthMutation(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(4)
+	population := NewRandomVariableLengthPopulation(2, options, rng)
+	config := &moea.Config{Population: population, RandomNumberGenerator: rng}
+	mutation := &LengthMutation{Insertion: 0.5, Deletion: 0.5}
+	mutation.Initialize(config)
+	individual := population.Individual(0)
+	lengths := map[int]bool{}
+	for n := 0; n < 2000; n++ {
+		mutation.Mutation(config, individual, 0.1)
+		if individual.Len() < options.MinLength || individual.Len() > options.MaxLength {
+			t.Fatalf("length %v out of bounds", individual.Len())
+		}
+		lengths[individual.Len()] = true
+	}
+	if len(lengths) != options.MaxLength-options.MinLength+1 {
+		t.Errorf("expected every length but got %v", lengths)
+	}
+}
+
+func TestRun(t *testing.T) {
+	rng := moea.NewXorshiftWithSeed(5)
+	target := map[int]bool{2: true, 5: true, 7: true, 11: true, 13: true}
+	config := &moea.Config{
+		Algorithm: moea.NewSimpleAlgorithmWithOperators(moea.SimpleAlgorithmOperators{
+			Selection:   &moea.TournamentSelection{TournamentSize: 2},
+			Mutation:    &LengthMutation{},
+			Crossover:   &CutAndSpliceCrossover{},
+			Replacement: &moea.GenerationalReplacement{Elitism: 1},
+		}),
+		Population:     NewRandomVariableLengthPopulation(50, options, rng),
+		NumberOfValues: options.MaxLength,
+		// the subset of 20 features equal to target
+		ObjectiveFunc: func(individual moea.Individual) []float64 {
+			seen := map[int]bool{}
+			f := 0.0
+			for _, g := range Genes(individual) {
+				if !target[g.(int)] || seen[g.(int)] {
+					f++
+				}
+				seen[g.(int)] = true
+			}
+			for g := range target {
+				if !seen[g] {
+					f++
+				}
+			}
+			return []float64{f}
+		},
+		NumberOfObjectives:    1,
+		MaxGenerations:        200,
+		CrossoverProbability:  0.9,
+		MutationProbability:   0.05,
+		RandomNumberGenerator: rng,
+	}
+	result, err := moea.Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.BestObjective[0] != 0 || result.BestIndividual.Len() != len(target) {
+		t.Errorf("expected the target subset but was %v with objective %v", Genes(result.BestIndividual), result.BestObjective)
+	}
+	for _, r := range result.Individuals {
+		if len(r.Values) < options.MinLength || len(r.Values) > options.MaxLength {
+			t.Errorf("recorded %v values", len(r.Values))
+		}
+	}
+}